@@ -2,13 +2,18 @@
 package app
 
 import (
+	"context"
+	"crypto/rand"
 	"log"
+	"time"
 
+	"meeting-slot-service/internal/auth"
 	"meeting-slot-service/internal/config"
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/handler"
 	"meeting-slot-service/internal/repository"
 	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
 )
 
 // App holds the fully-wired handler dependencies and the database connection
@@ -18,6 +23,22 @@ type App struct {
 	UserHandler         *handler.UserHandler
 	EventHandler        *handler.EventHandler
 	AvailabilityHandler *handler.AvailabilityHandler
+	ProposedSlotHandler *handler.ProposedSlotHandler
+	GuestHandler        *handler.GuestHandler
+	APIKeyHandler       *handler.APIKeyHandler
+	GroupHandler        *handler.GroupHandler
+	ImportHandler       *handler.ImportHandler
+	RetentionHandler    *handler.RetentionHandler
+	PrivacyHandler      *handler.PrivacyHandler
+	AuditHandler        *handler.AuditHandler
+	// RetentionService runs the purge job every PurgeInterval; zero disables
+	// it.
+	RetentionService *service.RetentionService
+	PurgeInterval    time.Duration
+	// Authenticator verifies credentials on every API route except guest
+	// respond links. AuthRequired rejects requests that carry none.
+	Authenticator auth.Authenticator
+	AuthRequired  bool
 }
 
 // New initialises the database, runs migrations, and wires all layers
@@ -38,26 +59,139 @@ func New(cfg *config.Config) (*App, error) {
 	eventRepo := repository.NewEventRepository(db)
 	availabilityRepo := repository.NewAvailabilityRepository(db)
 	participantRepo := repository.NewParticipantRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	groupRepo := repository.NewGroupRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	uow := repository.NewUnitOfWork(db)
+
+	authenticator, err := newAuthenticator(cfg.Auth, apiKeyRepo, userRepo)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
 
 	// Services
-	userService := service.NewUserService(userRepo)
-	eventService := service.NewEventService(eventRepo, userRepo, participantRepo)
-	availabilityService := service.NewAvailabilityService(availabilityRepo, eventRepo, participantRepo, userRepo)
+	userService := service.NewUserService(userRepo, auditRepo, uow)
+	eventService := service.NewEventService(eventRepo, userRepo, participantRepo, auditRepo, uow)
+	availabilityService := service.NewAvailabilityService(availabilityRepo, eventRepo, participantRepo, userRepo, idempotencyRepo, auditRepo, uow)
+	proposedSlotService := service.NewProposedSlotService(eventRepo, availabilityRepo, auditRepo, uow)
 	recommendationService := service.NewRecommendationService(eventRepo, availabilityRepo, participantRepo)
+	guestService := service.NewGuestService(eventRepo, userRepo, participantRepo, auditRepo, uow, availabilityService,
+		utils.NewGuestTokenSigner(guestTokenSecret(cfg.Guest), cfg.Guest.TokenTTL), cfg.Guest.PublicBaseURL)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo)
+	groupService := service.NewGroupService(groupRepo, userRepo, eventRepo, participantRepo, auditRepo, uow)
+	importService := service.NewImportService(userService, eventService, eventRepo)
+	retentionService := service.NewRetentionService(userRepo, eventRepo, auditRepo, uow, cfg.Retention.Period)
+	privacyService := service.NewPrivacyService(userRepo, eventRepo, participantRepo, availabilityRepo, idempotencyRepo, auditRepo, uow)
+	auditService := service.NewAuditService(auditRepo, eventRepo)
 
 	// Handlers
 	userHandler := handler.NewUserHandler(userService)
-	eventHandler := handler.NewEventHandler(eventService)
+	eventHandler := handler.NewEventHandler(eventService, guestService, groupService)
 	availabilityHandler := handler.NewAvailabilityHandler(availabilityService, recommendationService)
+	proposedSlotHandler := handler.NewProposedSlotHandler(proposedSlotService)
+	guestHandler := handler.NewGuestHandler(guestService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	groupHandler := handler.NewGroupHandler(groupService)
+	importHandler := handler.NewImportHandler(importService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	privacyHandler := handler.NewPrivacyHandler(privacyService)
+	auditHandler := handler.NewAuditHandler(auditService)
 
 	return &App{
 		DB:                  db,
 		UserHandler:         userHandler,
 		EventHandler:        eventHandler,
 		AvailabilityHandler: availabilityHandler,
+		ProposedSlotHandler: proposedSlotHandler,
+		GuestHandler:        guestHandler,
+		APIKeyHandler:       apiKeyHandler,
+		GroupHandler:        groupHandler,
+		ImportHandler:       importHandler,
+		RetentionHandler:    retentionHandler,
+		PrivacyHandler:      privacyHandler,
+		AuditHandler:        auditHandler,
+		RetentionService:    retentionService,
+		PurgeInterval:       cfg.Retention.PurgeInterval,
+		Authenticator:       authenticator,
+		AuthRequired:        cfg.Auth.Required,
 	}, nil
 }
 
+// newAuthenticator builds the request authenticator: bearer JWTs when a key
+// is configured, then API keys. The principal's timezone defaults to the
+// stored user's.
+func newAuthenticator(cfg config.AuthConfig, apiKeys auth.APIKeyStore, users auth.UserStore) (auth.Authenticator, error) {
+	var chain auth.Chain
+
+	if cfg.HasJWTKey() {
+		opts := auth.JWTOptions{
+			HS256Secret: []byte(cfg.JWTHS256Secret),
+			Issuer:      cfg.JWTIssuer,
+			Audience:    cfg.JWTAudience,
+		}
+		if cfg.JWTPublicKeyFile != "" {
+			key, err := auth.LoadRSAPublicKeyFile(cfg.JWTPublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			opts.RS256PublicKey = key
+		}
+		if cfg.JWKSFile != "" {
+			jwks, err := auth.LoadJWKSFile(cfg.JWKSFile)
+			if err != nil {
+				return nil, err
+			}
+			opts.JWKS = jwks
+		}
+
+		jwtAuth, err := auth.NewJWTAuthenticator(opts)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, jwtAuth)
+	}
+
+	chain = append(chain, auth.NewAPIKeyAuthenticator(apiKeys))
+
+	if !cfg.Required {
+		log.Println("AUTH_REQUIRED is false; requests without credentials are served unauthenticated")
+	}
+
+	return auth.WithUsers(chain, users), nil
+}
+
+// guestTokenSecret returns the configured guest token secret, or a random one
+// when none is set, which config only allows with AUTH_REQUIRED=false. Links
+// signed with a random secret stop working when the process restarts.
+func guestTokenSecret(cfg config.GuestConfig) []byte {
+	if cfg.TokenSecret != "" {
+		return []byte(cfg.TokenSecret)
+	}
+
+	log.Println("GUEST_TOKEN_SECRET is not set; guest respond links will not survive a restart")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate guest token secret: %v", err)
+	}
+	return secret
+}
+
+// StartPurgeJob starts purging deleted users and events past their
+// retention period in the background, unless the job is disabled. The
+// returned function stops it.
+func (a *App) StartPurgeJob() (stop func()) {
+	if a.PurgeInterval <= 0 {
+		log.Println("PURGE_INTERVAL_HOURS is 0; deleted users and events are only purged through POST /api/v1/admin/purge")
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go a.RetentionService.RunPurgeJob(ctx, a.PurgeInterval)
+	return cancel
+}
+
 // Close releases all resources owned by the app.
 func (a *App) Close() {
 	if err := a.DB.Close(); err != nil {
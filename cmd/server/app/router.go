@@ -17,6 +17,7 @@ func NewRouter(a *App) *mux.Router {
 
 	// Global middleware
 	router.Use(middleware.Recovery)
+	router.Use(middleware.RequestID)
 	router.Use(middleware.Logger)
 	router.Use(middleware.CORS)
 
@@ -29,9 +30,23 @@ func NewRouter(a *App) *mux.Router {
 	// API v1 sub-router
 	api := router.PathPrefix("/api/v1").Subrouter()
 
-	registerUserRoutes(api, a.UserHandler)
-	registerEventRoutes(api, a.EventHandler)
-	registerAvailabilityRoutes(api, a.AvailabilityHandler)
+	// Guest respond links carry their own credential
+	registerGuestRoutes(api, a.GuestHandler)
+
+	// Everything else requires authentication
+	protected := api.NewRoute().Subrouter()
+	protected.Use(middleware.Authenticate(a.Authenticator, a.AuthRequired))
+
+	registerUserRoutes(protected, a.UserHandler)
+	registerEventRoutes(protected, a.EventHandler)
+	registerProposedSlotRoutes(protected, a.ProposedSlotHandler)
+	registerAvailabilityRoutes(protected, a.AvailabilityHandler)
+	registerAPIKeyRoutes(protected, a.APIKeyHandler)
+	registerGroupRoutes(protected, a.GroupHandler)
+	registerImportRoutes(protected, a.ImportHandler)
+	registerRetentionRoutes(protected, a.RetentionHandler)
+	registerPrivacyRoutes(protected, a.PrivacyHandler)
+	registerAuditRoutes(protected, a.AuditHandler)
 
 	return router
 }
@@ -41,6 +56,7 @@ func registerUserRoutes(api *mux.Router, h *handler.UserHandler) {
 	api.HandleFunc("/users", h.ListUsers).Methods(http.MethodGet)
 	api.HandleFunc("/users/{id}", h.GetUser).Methods(http.MethodGet)
 	api.HandleFunc("/users/{id}", h.UpdateUser).Methods(http.MethodPut)
+	api.HandleFunc("/users/{id}", h.PatchUser).Methods(http.MethodPatch)
 	api.HandleFunc("/users/{id}", h.DeleteUser).Methods(http.MethodDelete)
 }
 
@@ -49,19 +65,84 @@ func registerEventRoutes(api *mux.Router, h *handler.EventHandler) {
 	api.HandleFunc("/events", h.GetEventList).Methods(http.MethodGet)
 	api.HandleFunc("/events/{id}", h.GetEvent).Methods(http.MethodGet)
 	api.HandleFunc("/events/{id}", h.UpdateEvent).Methods(http.MethodPut)
+	api.HandleFunc("/events/{id}", h.PatchEvent).Methods(http.MethodPatch)
 	api.HandleFunc("/events/{id}", h.DeleteEvent).Methods(http.MethodDelete)
+	api.HandleFunc("/events/{id}/transfer", h.TransferOwnership).Methods(http.MethodPost)
 
 	// Participants nested under events
 	api.HandleFunc("/events/{id}/participants", h.AddParticipant).Methods(http.MethodPost)
 	api.HandleFunc("/events/{id}/participants", h.GetParticipants).Methods(http.MethodGet)
+	api.HandleFunc("/events/{id}/participants:batch", h.BatchParticipants).Methods(http.MethodPost)
 	api.HandleFunc("/events/{id}/participants/{user_id}", h.RemoveParticipant).Methods(http.MethodDelete)
+	api.HandleFunc("/events/{id}/participants/{user_id}/role", h.SetParticipantRole).Methods(http.MethodPut)
+
+	// A user's own events
+	api.HandleFunc("/users/{id}/events", h.GetUserEvents).Methods(http.MethodGet)
+	api.HandleFunc("/users/{id}/inbox", h.GetUserInbox).Methods(http.MethodGet)
+}
+
+func registerProposedSlotRoutes(api *mux.Router, h *handler.ProposedSlotHandler) {
+	api.HandleFunc("/events/{id}/proposed-slots", h.AddProposedSlot).Methods(http.MethodPost)
+	api.HandleFunc("/events/{id}/proposed-slots", h.ListProposedSlots).Methods(http.MethodGet)
+	api.HandleFunc("/events/{id}/proposed-slots/{slot_id}", h.GetProposedSlot).Methods(http.MethodGet)
+	api.HandleFunc("/events/{id}/proposed-slots/{slot_id}", h.UpdateProposedSlot).Methods(http.MethodPut)
+	api.HandleFunc("/events/{id}/proposed-slots/{slot_id}", h.PatchProposedSlot).Methods(http.MethodPatch)
+	api.HandleFunc("/events/{id}/proposed-slots/{slot_id}", h.RemoveProposedSlot).Methods(http.MethodDelete)
 }
 
 func registerAvailabilityRoutes(api *mux.Router, h *handler.AvailabilityHandler) {
 	api.HandleFunc("/events/{id}/participants/{user_id}/availability", h.SubmitAvailability).Methods(http.MethodPost)
 	api.HandleFunc("/events/{id}/participants/{user_id}/availability", h.UpdateAvailability).Methods(http.MethodPut)
 	api.HandleFunc("/events/{id}/participants/{user_id}/availability", h.GetAvailability).Methods(http.MethodGet)
+	api.HandleFunc("/events/{id}/participants/{user_id}/availability", h.WithdrawAvailability).Methods(http.MethodDelete)
+	api.HandleFunc("/events/{id}/participants/{user_id}/rsvp", h.RespondToInvitation).Methods(http.MethodPut)
 
 	// Recommendations nested under events
 	api.HandleFunc("/events/{id}/recommendations", h.GetRecommendations).Methods(http.MethodGet)
 }
+
+func registerAPIKeyRoutes(api *mux.Router, h *handler.APIKeyHandler) {
+	api.HandleFunc("/api-keys", h.CreateAPIKey).Methods(http.MethodPost)
+	api.HandleFunc("/api-keys", h.ListAPIKeys).Methods(http.MethodGet)
+	api.HandleFunc("/api-keys/{id}", h.RevokeAPIKey).Methods(http.MethodDelete)
+}
+
+func registerGroupRoutes(api *mux.Router, h *handler.GroupHandler) {
+	api.HandleFunc("/groups", h.CreateGroup).Methods(http.MethodPost)
+	api.HandleFunc("/groups", h.ListGroups).Methods(http.MethodGet)
+	api.HandleFunc("/groups/{id}", h.GetGroup).Methods(http.MethodGet)
+	api.HandleFunc("/groups/{id}", h.UpdateGroup).Methods(http.MethodPut)
+	api.HandleFunc("/groups/{id}", h.DeleteGroup).Methods(http.MethodDelete)
+	api.HandleFunc("/groups/{id}/members", h.GetMembers).Methods(http.MethodGet)
+	api.HandleFunc("/groups/{id}/members", h.AddMembers).Methods(http.MethodPost)
+	api.HandleFunc("/groups/{id}/members/{user_id}", h.RemoveMember).Methods(http.MethodDelete)
+}
+
+func registerImportRoutes(api *mux.Router, h *handler.ImportHandler) {
+	api.HandleFunc("/users/import", h.ImportUsers).Methods(http.MethodPost)
+	api.HandleFunc("/events/{id}/participants/import", h.ImportParticipants).Methods(http.MethodPost)
+}
+
+func registerRetentionRoutes(api *mux.Router, h *handler.RetentionHandler) {
+	api.HandleFunc("/users/{id}:restore", h.RestoreUser).Methods(http.MethodPost)
+	api.HandleFunc("/events/{id}:restore", h.RestoreEvent).Methods(http.MethodPost)
+	api.HandleFunc("/admin/purge", h.Purge).Methods(http.MethodPost)
+}
+
+func registerPrivacyRoutes(api *mux.Router, h *handler.PrivacyHandler) {
+	api.HandleFunc("/users/{id}/export", h.ExportUser).Methods(http.MethodGet)
+	api.HandleFunc("/users/{id}/erase", h.EraseUser).Methods(http.MethodPost)
+}
+
+func registerAuditRoutes(api *mux.Router, h *handler.AuditHandler) {
+	api.HandleFunc("/events/{id}/history", h.EventHistory).Methods(http.MethodGet)
+	api.HandleFunc("/audit", h.ListAudit).Methods(http.MethodGet)
+}
+
+func registerGuestRoutes(api *mux.Router, h *handler.GuestHandler) {
+	// Guest respond links; the signed token is the only credential
+	api.HandleFunc("/respond/{token}", h.GetInvitation).Methods(http.MethodGet)
+	api.HandleFunc("/respond/{token}", h.SubmitAvailability).Methods(http.MethodPost)
+	api.HandleFunc("/respond/{token}", h.UpdateAvailability).Methods(http.MethodPut)
+	api.HandleFunc("/respond/{token}/rsvp", h.RespondToInvitation).Methods(http.MethodPut)
+}
@@ -1,13 +1,18 @@
 package app_test
 
 import (
+	"bytes"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 
 	"meeting-slot-service/cmd/server/app"
 	"meeting-slot-service/internal/handler"
 	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -16,10 +21,12 @@ import (
 // can be called without a database.  Handler methods are never invoked in
 // these tests — we only probe the routing table.
 func newTestApp() *app.App {
-	userHandler := handler.NewUserHandler(service.NewUserService(nil))
-	eventHandler := handler.NewEventHandler(service.NewEventService(nil, nil, nil))
+	userHandler := handler.NewUserHandler(service.NewUserService(nil, nil, nil))
+	guestService := service.NewGuestService(nil, nil, nil, nil, nil, nil, nil, "")
+	groupService := service.NewGroupService(nil, nil, nil, nil, nil, nil)
+	eventHandler := handler.NewEventHandler(service.NewEventService(nil, nil, nil, nil, nil), guestService, groupService)
 	availabilityHandler := handler.NewAvailabilityHandler(
-		service.NewAvailabilityService(nil, nil, nil, nil),
+		service.NewAvailabilityService(nil, nil, nil, nil, nil, nil, nil),
 		service.NewRecommendationService(nil, nil, nil),
 	)
 
@@ -27,6 +34,14 @@ func newTestApp() *app.App {
 		UserHandler:         userHandler,
 		EventHandler:        eventHandler,
 		AvailabilityHandler: availabilityHandler,
+		ProposedSlotHandler: handler.NewProposedSlotHandler(service.NewProposedSlotService(nil, nil, nil, nil)),
+		GuestHandler:        handler.NewGuestHandler(guestService),
+		APIKeyHandler:       handler.NewAPIKeyHandler(service.NewAPIKeyService(nil, nil)),
+		GroupHandler:        handler.NewGroupHandler(groupService),
+		ImportHandler:       handler.NewImportHandler(service.NewImportService(nil, nil, nil)),
+		RetentionHandler:    handler.NewRetentionHandler(service.NewRetentionService(nil, nil, nil, nil, 0)),
+		PrivacyHandler:      handler.NewPrivacyHandler(service.NewPrivacyService(nil, nil, nil, nil, nil, nil, nil)),
+		AuditHandler:        handler.NewAuditHandler(service.NewAuditService(nil, nil)),
 	}
 }
 
@@ -63,6 +78,7 @@ func TestNewRouter_UserRoutes(t *testing.T) {
 		{http.MethodGet, "/api/v1/users"},
 		{http.MethodGet, "/api/v1/users/abc"},
 		{http.MethodPut, "/api/v1/users/abc"},
+		{http.MethodPatch, "/api/v1/users/abc"},
 		{http.MethodDelete, "/api/v1/users/abc"},
 	}
 
@@ -85,10 +101,39 @@ func TestNewRouter_EventRoutes(t *testing.T) {
 		{http.MethodGet, "/api/v1/events"},
 		{http.MethodGet, "/api/v1/events/abc"},
 		{http.MethodPut, "/api/v1/events/abc"},
+		{http.MethodPatch, "/api/v1/events/abc"},
 		{http.MethodDelete, "/api/v1/events/abc"},
+		{http.MethodPost, "/api/v1/events/abc/transfer"},
 		{http.MethodPost, "/api/v1/events/abc/participants"},
 		{http.MethodGet, "/api/v1/events/abc/participants"},
+		{http.MethodPost, "/api/v1/events/abc/participants:batch"},
 		{http.MethodDelete, "/api/v1/events/abc/participants/user1"},
+		{http.MethodPut, "/api/v1/events/abc/participants/user1/role"},
+		{http.MethodGet, "/api/v1/users/user1/events"},
+		{http.MethodGet, "/api/v1/users/user1/inbox"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_ProposedSlotRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/events/abc/proposed-slots"},
+		{http.MethodGet, "/api/v1/events/abc/proposed-slots"},
+		{http.MethodGet, "/api/v1/events/abc/proposed-slots/7"},
+		{http.MethodPut, "/api/v1/events/abc/proposed-slots/7"},
+		{http.MethodPatch, "/api/v1/events/abc/proposed-slots/7"},
+		{http.MethodDelete, "/api/v1/events/abc/proposed-slots/7"},
 	}
 
 	for _, r := range routes {
@@ -109,6 +154,8 @@ func TestNewRouter_AvailabilityRoutes(t *testing.T) {
 		{http.MethodPost, "/api/v1/events/abc/participants/user1/availability"},
 		{http.MethodPut, "/api/v1/events/abc/participants/user1/availability"},
 		{http.MethodGet, "/api/v1/events/abc/participants/user1/availability"},
+		{http.MethodDelete, "/api/v1/events/abc/participants/user1/availability"},
+		{http.MethodPut, "/api/v1/events/abc/participants/user1/rsvp"},
 		{http.MethodGet, "/api/v1/events/abc/recommendations"},
 	}
 
@@ -120,6 +167,211 @@ func TestNewRouter_AvailabilityRoutes(t *testing.T) {
 	}
 }
 
+func TestNewRouter_GuestRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/respond/tok"},
+		{http.MethodPost, "/api/v1/respond/tok"},
+		{http.MethodPut, "/api/v1/respond/tok"},
+		{http.MethodPut, "/api/v1/respond/tok/rsvp"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_APIKeyRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/api-keys"},
+		{http.MethodGet, "/api/v1/api-keys"},
+		{http.MethodDelete, "/api/v1/api-keys/key_1"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_GroupRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/groups"},
+		{http.MethodGet, "/api/v1/groups"},
+		{http.MethodGet, "/api/v1/groups/grp_1"},
+		{http.MethodPut, "/api/v1/groups/grp_1"},
+		{http.MethodDelete, "/api/v1/groups/grp_1"},
+		{http.MethodGet, "/api/v1/groups/grp_1/members"},
+		{http.MethodPost, "/api/v1/groups/grp_1/members"},
+		{http.MethodDelete, "/api/v1/groups/grp_1/members/u1"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_ImportRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/users/import"},
+		{http.MethodPost, "/api/v1/events/evt_1/participants/import"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_RetentionRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/users/usr_1:restore"},
+		{http.MethodPost, "/api/v1/events/evt_1:restore"},
+		{http.MethodPost, "/api/v1/admin/purge"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_PrivacyRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/users/usr_1/export"},
+		{http.MethodPost, "/api/v1/users/usr_1/erase"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_AuditRoutes(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/events/evt_1/history"},
+		{http.MethodGet, "/api/v1/audit"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			assert.True(t, routeExists(t, router, r.method, r.path),
+				"expected route to be registered")
+		})
+	}
+}
+
+func TestNewRouter_RequestID(t *testing.T) {
+	router := app.NewRouter(newTestApp())
+
+	// A usable request ID is echoed back
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, "req-42", rr.Header().Get("X-Request-ID"))
+
+	// Otherwise one is generated
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "not usable")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.NotEmpty(t, rr.Header().Get("X-Request-ID"))
+	assert.NotEqual(t, "not usable", rr.Header().Get("X-Request-ID"))
+}
+
+func TestNewRouter_LogRedactsGuestTokens(t *testing.T) {
+	a := newTestApp()
+	signer := utils.NewGuestTokenSigner([]byte("test-secret"), time.Hour)
+	a.GuestHandler = handler.NewGuestHandler(service.NewGuestService(nil, nil, nil, nil, nil, nil, signer, ""))
+	router := app.NewRouter(a)
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/respond/secret-token/rsvp?tz=UTC", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, logged.String(), "/api/v1/respond/[redacted]/rsvp?tz=UTC")
+	assert.NotContains(t, logged.String(), "secret-token")
+}
+
+func TestNewRouter_AuthRequired(t *testing.T) {
+	a := newTestApp()
+	a.AuthRequired = true
+	router := app.NewRouter(a)
+
+	// Protected routes reject requests without credentials
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+
+	// Guest respond links authenticate with their own token, so the
+	// middleware does not challenge them
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/respond/tok", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Empty(t, rr.Header().Get("WWW-Authenticate"))
+
+	// Health checks stay public
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
 func TestNewRouter_UnregisteredRoute(t *testing.T) {
 	router := app.NewRouter(newTestApp())
 
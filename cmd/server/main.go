@@ -21,6 +21,9 @@ func main() {
 	}
 	defer application.Close()
 
+	stopPurge := application.StartPurgeJob()
+	defer stopPurge()
+
 	router := app.NewRouter(application)
 	server := app.NewServer(cfg.Server.Address(), router)
 	server.Start()
@@ -14,4 +14,9 @@ $env:ENV = "development"
 $env:LOG_LEVEL = "debug"
 $env:AWS_REGION = "us-east-1"
 
+$env:GUEST_TOKEN_SECRET = "local-dev-guest-secret"
+
+$env:AUTH_REQUIRED = "false"
+$env:AUTH_JWT_HS256_SECRET = "local-dev-jwt-secret"
+
 Write-Host "Environment variables loaded for local development" -ForegroundColor Green
@@ -15,4 +15,9 @@ export ENV=development
 export LOG_LEVEL=debug
 export AWS_REGION=us-east-1
 
+export GUEST_TOKEN_SECRET=local-dev-guest-secret
+
+export AUTH_REQUIRED=false
+export AUTH_JWT_HS256_SECRET=local-dev-jwt-secret
+
 echo "Environment variables loaded for local development"
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// Authenticator verifies the credentials carried by a request. It returns
+// ErrNoCredentials when the request has none it understands, so that several
+// authenticators can be chained.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Chain tries each authenticator in turn and returns the first principal. A
+// request with credentials that fail verification is rejected without trying
+// the rest.
+type Chain []Authenticator
+
+// Authenticate implements Authenticator.
+func (c Chain) Authenticate(r *http.Request) (*Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return p, err
+	}
+	return nil, ErrNoCredentials
+}
+
+// UserStore looks up users for WithUsers.
+type UserStore interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	IsDeleted(ctx context.Context, id string) (bool, error)
+}
+
+// WithUsers checks principals against the stored users. Credentials of a
+// deleted user are rejected, and the principal's timezone is filled in from
+// the stored user when the credential did not carry one.
+func WithUsers(next Authenticator, users UserStore) Authenticator {
+	return userLookup{next: next, users: users}
+}
+
+type userLookup struct {
+	next  Authenticator
+	users UserStore
+}
+
+// Authenticate implements Authenticator.
+func (u userLookup) Authenticate(r *http.Request) (*Principal, error) {
+	p, err := u.next.Authenticate(r)
+	if err != nil || p.UserID == "" {
+		return p, err
+	}
+
+	user, err := u.users.GetByID(r.Context(), p.UserID)
+	if err == nil {
+		if p.Timezone == "" {
+			p.Timezone = user.Timezone
+		}
+		return p, nil
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return nil, err
+	}
+
+	// The subject may not be a local user, e.g. a token from a shared
+	// identity provider; that is not an authentication failure. A deleted
+	// user is.
+	deleted, err := u.users.IsDeleted(r.Context(), p.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		return nil, fmt.Errorf("%w: user %s is deleted", ErrInvalidCredentials, p.UserID)
+	}
+	return p, nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuthenticator struct {
+	principal *Principal
+	err       error
+}
+
+func (s stubAuthenticator) Authenticate(*http.Request) (*Principal, error) {
+	return s.principal, s.err
+}
+
+type stubKeyStore map[string]*models.APIKey
+
+func (s stubKeyStore) GetByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	return s[hash], nil
+}
+
+// stubUserStore maps deleted users to nil.
+type stubUserStore map[string]*models.User
+
+func (s stubUserStore) GetByID(_ context.Context, id string) (*models.User, error) {
+	if u := s[id]; u != nil {
+		return u, nil
+	}
+	return nil, utils.NotFound("user not found")
+}
+
+func (s stubUserStore) IsDeleted(_ context.Context, id string) (bool, error) {
+	u, ok := s[id]
+	return ok && u == nil, nil
+}
+
+func TestChain(t *testing.T) {
+	user := &Principal{Subject: "usr_1"}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	p, err := Chain{stubAuthenticator{err: ErrNoCredentials}, stubAuthenticator{principal: user}}.Authenticate(r)
+	assert.NoError(t, err)
+	assert.Same(t, user, p)
+
+	_, err = Chain{stubAuthenticator{err: ErrInvalidCredentials}, stubAuthenticator{principal: user}}.Authenticate(r)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = Chain{stubAuthenticator{err: ErrNoCredentials}}.Authenticate(r)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+	a := NewAPIKeyAuthenticator(stubKeyStore{
+		HashAPIKey(key): {ID: "key_1", UserID: "usr_1", IsAdmin: true},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = a.Authenticate(r)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	r.Header.Set(APIKeyHeader, key)
+	p, err := a.Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, PrincipalService, p.Kind)
+	assert.Equal(t, "apikey:key_1", p.Subject)
+	assert.Equal(t, "usr_1", p.UserID)
+	assert.True(t, p.Admin)
+
+	r.Header.Set(APIKeyHeader, "msk_unknown")
+	_, err = a.Authenticate(r)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestWithUsers(t *testing.T) {
+	users := stubUserStore{"usr_1": {ID: "usr_1", Timezone: "Asia/Tokyo"}, "usr_gone": nil}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	p, err := WithUsers(stubAuthenticator{principal: &Principal{UserID: "usr_1"}}, users).Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", p.Timezone)
+
+	p, err = WithUsers(stubAuthenticator{principal: &Principal{UserID: "usr_1", Timezone: "UTC"}}, users).Authenticate(r)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", p.Timezone)
+
+	p, err = WithUsers(stubAuthenticator{principal: &Principal{UserID: "external"}}, users).Authenticate(r)
+	require.NoError(t, err)
+	assert.Empty(t, p.Timezone)
+
+	_, err = WithUsers(stubAuthenticator{principal: &Principal{UserID: "usr_gone", Timezone: "UTC"}}, users).Authenticate(r)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = WithUsers(stubAuthenticator{principal: &Principal{Kind: PrincipalService, UserID: "usr_gone"}}, users).Authenticate(r)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestPrincipalContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	p := &Principal{Subject: "usr_1"}
+	assert.Same(t, p, FromContext(NewContext(context.Background(), p)))
+}
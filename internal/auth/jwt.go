@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTOptions configures a JWTAuthenticator. At least one key source must be
+// set. Issuer and Audience are only checked when non-empty.
+type JWTOptions struct {
+	// HS256Secret verifies HS256 tokens.
+	HS256Secret []byte
+	// RS256PublicKey verifies RS256 tokens that carry no kid, or whose kid
+	// is not in JWKS.
+	RS256PublicKey *rsa.PublicKey
+	// JWKS holds keys selected by the token's kid header.
+	JWKS     *JWKS
+	Issuer   string
+	Audience string
+}
+
+// JWTAuthenticator verifies HS256 and RS256 bearer tokens.
+type JWTAuthenticator struct {
+	opts   JWTOptions
+	parser *jwt.Parser
+}
+
+// jwtClaims are the claims read from a token. Roles containing "admin"
+// grants admin rights; zoneinfo is the OpenID Connect timezone claim.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Roles    []string `json:"roles,omitempty"`
+	Zoneinfo string   `json:"zoneinfo,omitempty"`
+}
+
+// NewJWTAuthenticator creates a JWT authenticator from opts.
+func NewJWTAuthenticator(opts JWTOptions) (*JWTAuthenticator, error) {
+	var methods []string
+	if len(opts.HS256Secret) > 0 || opts.JWKS.hasKeyType("oct") {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if opts.RS256PublicKey != nil || opts.JWKS.hasKeyType("RSA") {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no JWT verification key configured")
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &JWTAuthenticator{opts: opts, parser: jwt.NewParser(parserOpts...)}, nil
+}
+
+// Authenticate implements Authenticator for "Authorization: Bearer" headers.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, ErrNoCredentials
+	}
+
+	var claims jwtClaims
+	if _, err := a.parser.ParseWithClaims(strings.TrimSpace(token), &claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	p := &Principal{
+		Kind:     PrincipalUser,
+		Subject:  claims.Subject,
+		UserID:   claims.Subject,
+		Timezone: claims.Zoneinfo,
+	}
+	for _, role := range claims.Roles {
+		if role == "admin" {
+			p.Admin = true
+		}
+	}
+	return p, nil
+}
+
+// key selects the verification key for a token. The signing method has
+// already been checked against the configured ones by the parser.
+func (a *JWTAuthenticator) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid != "" {
+		if key, ok := a.opts.JWKS.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if len(a.opts.HS256Secret) > 0 {
+			return a.opts.HS256Secret, nil
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if a.opts.RS256PublicKey != nil {
+			return a.opts.RS256PublicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("no key for kid %q", kid)
+}
+
+// LoadRSAPublicKeyFile reads a PEM-encoded RSA public key.
+func LoadRSAPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return key, nil
+}
+
+// JWKS is a set of verification keys indexed by kid. RSA keys verify RS256
+// tokens and symmetric ("oct") keys verify HS256 tokens.
+type JWKS struct {
+	keys map[string]interface{}
+}
+
+// jsonWebKey holds the JWK members used here.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// LoadJWKSFile reads a JSON Web Key Set from a local file.
+func LoadJWKSFile(path string) (*JWKS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses a JSON Web Key Set. Keys without a kid or of an
+// unsupported type are rejected; keys not meant for signatures are skipped.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	jwks := &JWKS{keys: make(map[string]interface{}, len(set.Keys))}
+	for i, k := range set.Keys {
+		if k.Kid == "" {
+			return nil, fmt.Errorf("JWKS key %d has no kid", i)
+		}
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %q: %w", k.Kid, err)
+		}
+		jwks.keys[k.Kid] = key
+	}
+	return jwks, nil
+}
+
+// publicKey decodes the key material of a JWK.
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key: %w", err)
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// lookup returns the key for kid. It is safe to call on a nil set.
+func (s *JWKS) lookup(kid string) (interface{}, bool) {
+	if s == nil {
+		return nil, false
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// hasKeyType reports whether the set holds a key of the given JWK type. It
+// is safe to call on a nil set.
+func (s *JWKS) hasKeyType(kty string) bool {
+	if s == nil {
+		return false
+	}
+	for _, key := range s.keys {
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if kty == "RSA" {
+				return true
+			}
+		case []byte:
+			if kty == "oct" {
+				return true
+			}
+		}
+	}
+	return false
+}
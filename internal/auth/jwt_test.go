@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hsSecret = []byte("test-secret")
+
+func signHS256(t *testing.T, claims jwt.MapClaims, secret []byte) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func signRS256(t *testing.T, claims jwt.MapClaims, key *rsa.PrivateKey, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func bearerRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "usr_1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestJWTAuthenticator_HS256(t *testing.T) {
+	a, err := NewJWTAuthenticator(JWTOptions{HS256Secret: hsSecret})
+	require.NoError(t, err)
+
+	claims := validClaims()
+	claims["roles"] = []string{"admin"}
+	claims["zoneinfo"] = "Europe/Paris"
+
+	p, err := a.Authenticate(bearerRequest(signHS256(t, claims, hsSecret)))
+
+	require.NoError(t, err)
+	assert.Equal(t, PrincipalUser, p.Kind)
+	assert.Equal(t, "usr_1", p.UserID)
+	assert.True(t, p.Admin)
+	assert.Equal(t, "Europe/Paris", p.Timezone)
+}
+
+func TestJWTAuthenticator_NoCredentials(t *testing.T) {
+	a, err := NewJWTAuthenticator(JWTOptions{HS256Secret: hsSecret})
+	require.NoError(t, err)
+
+	_, err = a.Authenticate(bearerRequest(""))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	r := bearerRequest("")
+	r.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	_, err = a.Authenticate(r)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestJWTAuthenticator_Rejects(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	a, err := NewJWTAuthenticator(JWTOptions{HS256Secret: hsSecret, Issuer: "https://idp.example.com"})
+	require.NoError(t, err)
+
+	withIssuer := func(c jwt.MapClaims) jwt.MapClaims {
+		c["iss"] = "https://idp.example.com"
+		return c
+	}
+
+	expired := withIssuer(validClaims())
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	noExpiry := withIssuer(validClaims())
+	delete(noExpiry, "exp")
+	noSubject := withIssuer(validClaims())
+	delete(noSubject, "sub")
+
+	tests := map[string]string{
+		"wrong secret":     signHS256(t, withIssuer(validClaims()), []byte("other")),
+		"expired":          signHS256(t, expired, hsSecret),
+		"no expiry":        signHS256(t, noExpiry, hsSecret),
+		"no subject":       signHS256(t, noSubject, hsSecret),
+		"wrong issuer":     signHS256(t, validClaims(), hsSecret),
+		"unconfigured alg": signRS256(t, withIssuer(validClaims()), rsaKey, ""),
+		"garbage":          "not.a.token",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(bearerRequest(token))
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestJWTAuthenticator_RS256StaticKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	a, err := NewJWTAuthenticator(JWTOptions{RS256PublicKey: &rsaKey.PublicKey, Audience: "meeting-slots"})
+	require.NoError(t, err)
+
+	claims := validClaims()
+	claims["aud"] = "meeting-slots"
+	p, err := a.Authenticate(bearerRequest(signRS256(t, claims, rsaKey, "")))
+	require.NoError(t, err)
+	assert.Equal(t, "usr_1", p.Subject)
+
+	// An HS256 token signed with the public key must not be accepted.
+	_, err = a.Authenticate(bearerRequest(signHS256(t, claims, rsaKey.PublicKey.N.Bytes())))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestJWTAuthenticator_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	set := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "oct",
+				"kid": "hs-1",
+				"k":   base64.RawURLEncoding.EncodeToString(hsSecret),
+			},
+			{
+				"kty": "RSA",
+				"kid": "enc-1",
+				"use": "enc",
+			},
+		},
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	jwks, err := ParseJWKS(data)
+	require.NoError(t, err)
+
+	a, err := NewJWTAuthenticator(JWTOptions{JWKS: jwks})
+	require.NoError(t, err)
+
+	_, err = a.Authenticate(bearerRequest(signRS256(t, validClaims(), rsaKey, "rsa-1")))
+	assert.NoError(t, err)
+
+	hsToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hsToken.Header["kid"] = "hs-1"
+	signed, err := hsToken.SignedString(hsSecret)
+	require.NoError(t, err)
+	_, err = a.Authenticate(bearerRequest(signed))
+	assert.NoError(t, err)
+
+	_, err = a.Authenticate(bearerRequest(signRS256(t, validClaims(), rsaKey, "unknown")))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	_, err := ParseJWKS([]byte(`{"keys":[{"kty":"RSA","n":"AQAB","e":"AQAB"}]}`))
+	assert.ErrorContains(t, err, "has no kid")
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"EC","kid":"ec-1"}]}`))
+	assert.ErrorContains(t, err, "unsupported key type")
+}
+
+func TestNewJWTAuthenticator_NoKeys(t *testing.T) {
+	_, err := NewJWTAuthenticator(JWTOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Package auth authenticates API requests and carries the authenticated
+// principal through the request context.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"meeting-slot-service/internal/utils"
+)
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request
+	// carries no credentials it understands.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned when credentials are present but
+	// cannot be verified.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrForbidden is returned when the principal may not perform an action.
+	// It is the shared utils.ErrForbidden kind.
+	ErrForbidden = utils.ErrForbidden
+)
+
+// PrincipalKind distinguishes people from services.
+type PrincipalKind string
+
+// PrincipalKind constants
+const (
+	PrincipalUser    PrincipalKind = "user"
+	PrincipalService PrincipalKind = "service"
+	// PrincipalGuest is a guest acting through a signed respond link.
+	PrincipalGuest PrincipalKind = "guest"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Kind PrincipalKind
+	// Subject identifies the credential: the JWT subject, "apikey:<id>" or
+	// "guest:<user id>".
+	Subject string
+	// UserID is the user the principal acts as. It is empty for service
+	// keys that are not bound to a user.
+	UserID string
+	// Admin principals may manage API keys and act on any resource.
+	Admin bool
+	// Timezone is the principal's preferred IANA zone, if known.
+	Timezone string
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying p.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, or nil when the request
+// is unauthenticated.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(contextKey{}).(*Principal)
+	return p
+}
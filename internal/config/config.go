@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the application.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	AWS      AWSConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	AWS       AWSConfig
+	Guest     GuestConfig
+	Auth      AuthConfig
+	Retention RetentionConfig
 }
 
 // ServerConfig holds HTTP server configuration.
@@ -36,6 +41,53 @@ type AWSConfig struct {
 	Region string
 }
 
+// GuestConfig holds configuration for guest respond links.
+type GuestConfig struct {
+	// TokenSecret signs guest tokens, and must be the same on every
+	// instance. It is required when authentication is; otherwise a random
+	// secret is generated at startup when it is empty, so links stop working
+	// when the process restarts.
+	TokenSecret string
+	// TokenTTL is how long a guest link stays valid.
+	TokenTTL time.Duration
+	// PublicBaseURL is prefixed to respond links, e.g. https://slots.example.com.
+	// When empty the links are returned as paths.
+	PublicBaseURL string
+}
+
+// AuthConfig holds API authentication configuration. Any combination of
+// JWT key sources may be set; API keys are always accepted.
+type AuthConfig struct {
+	// Required rejects requests without credentials. When false, such
+	// requests are served unauthenticated.
+	Required bool
+	// JWTHS256Secret verifies HS256 bearer tokens.
+	JWTHS256Secret string
+	// JWTPublicKeyFile is a PEM RSA public key verifying RS256 bearer tokens.
+	JWTPublicKeyFile string
+	// JWKSFile is a local JSON Web Key Set, selected by the token's kid.
+	JWKSFile string
+	// JWTIssuer and JWTAudience are checked against the iss and aud claims
+	// when set.
+	JWTIssuer   string
+	JWTAudience string
+}
+
+// HasJWTKey reports whether any JWT verification key is configured.
+func (c *AuthConfig) HasJWTKey() bool {
+	return c.JWTHS256Secret != "" || c.JWTPublicKeyFile != "" || c.JWKSFile != ""
+}
+
+// RetentionConfig holds configuration for deleted users and events.
+type RetentionConfig struct {
+	// Period is how long a deleted user or event can be restored. After it
+	// the purge job deletes them for good.
+	Period time.Duration
+	// PurgeInterval is how often the server runs the purge job. Zero
+	// disables it, leaving purges to POST /admin/purge.
+	PurgeInterval time.Duration
+}
+
 // Load reads configuration from environment variables and validates required
 // fields. Returns an error if any required value is missing or malformed.
 func Load() (*Config, error) {
@@ -49,6 +101,26 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid DB_PORT: %w", err)
 	}
 
+	guestTTLHours, err := getEnvAsInt("GUEST_TOKEN_TTL_HOURS", 168)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GUEST_TOKEN_TTL_HOURS: %w", err)
+	}
+
+	retentionDays, err := getEnvAsInt("RETENTION_DAYS", 30)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RETENTION_DAYS: %w", err)
+	}
+
+	purgeIntervalHours, err := getEnvAsInt("PURGE_INTERVAL_HOURS", 24)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PURGE_INTERVAL_HOURS: %w", err)
+	}
+
+	authRequired, err := getEnvAsBool("AUTH_REQUIRED", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_REQUIRED: %w", err)
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
@@ -65,6 +137,23 @@ func Load() (*Config, error) {
 		AWS: AWSConfig{
 			Region: getEnv("AWS_REGION", "us-east-1"),
 		},
+		Guest: GuestConfig{
+			TokenSecret:   getEnv("GUEST_TOKEN_SECRET", ""),
+			TokenTTL:      time.Duration(guestTTLHours) * time.Hour,
+			PublicBaseURL: strings.TrimRight(getEnv("PUBLIC_BASE_URL", ""), "/"),
+		},
+		Auth: AuthConfig{
+			Required:         authRequired,
+			JWTHS256Secret:   getEnv("AUTH_JWT_HS256_SECRET", ""),
+			JWTPublicKeyFile: getEnv("AUTH_JWT_RS256_PUBLIC_KEY_FILE", ""),
+			JWKSFile:         getEnv("AUTH_JWKS_FILE", ""),
+			JWTIssuer:        getEnv("AUTH_JWT_ISSUER", ""),
+			JWTAudience:      getEnv("AUTH_JWT_AUDIENCE", ""),
+		},
+		Retention: RetentionConfig{
+			Period:        time.Duration(retentionDays) * 24 * time.Hour,
+			PurgeInterval: time.Duration(purgeIntervalHours) * time.Hour,
+		},
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -81,6 +170,25 @@ func (c *Config) validate() error {
 	if c.Database.SecretARN == "" && c.Database.User == "" {
 		return fmt.Errorf("DB_USER must be set (or provide DB_SECRET_ARN for AWS Secrets Manager)")
 	}
+	if c.Guest.TokenTTL <= 0 {
+		return fmt.Errorf("GUEST_TOKEN_TTL_HOURS must be greater than 0")
+	}
+	if c.Retention.Period <= 0 {
+		return fmt.Errorf("RETENTION_DAYS must be greater than 0")
+	}
+	if c.Retention.PurgeInterval < 0 {
+		return fmt.Errorf("PURGE_INTERVAL_HOURS must not be negative")
+	}
+	// API keys can only be issued by an admin, and the first admin has to
+	// come from a JWT.
+	if c.Auth.Required && !c.Auth.HasJWTKey() {
+		return fmt.Errorf("AUTH_REQUIRED is set but no JWT key is configured: set AUTH_JWT_HS256_SECRET, AUTH_JWT_RS256_PUBLIC_KEY_FILE or AUTH_JWKS_FILE, or set AUTH_REQUIRED=false")
+	}
+	// A generated secret differs between instances and restarts, so guest
+	// links would fail at random in any real deployment.
+	if c.Auth.Required && c.Guest.TokenSecret == "" {
+		return fmt.Errorf("AUTH_REQUIRED is set but GUEST_TOKEN_SECRET is not: set a secret shared by all instances, or set AUTH_REQUIRED=false")
+	}
 	return nil
 }
 
@@ -106,6 +214,20 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsBool parses key as a boolean. Returns defaultValue when the
+// variable is unset, and an error when it is set but not a valid boolean.
+func getEnvAsBool(key string, defaultValue bool) (bool, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("environment variable %s=%q is not a valid boolean", key, s)
+	}
+	return v, nil
+}
+
 // getEnvAsInt parses key as an integer. Returns defaultValue when the variable
 // is unset, and an error when it is set but not a valid integer.
 func getEnvAsInt(key string, defaultValue int) (int, error) {
@@ -146,9 +146,16 @@ func (d *Database) RunMigrations() error {
 			`CREATE TABLE IF NOT EXISTS users (
 			id VARCHAR(50) PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
-			email VARCHAR(255) NOT NULL UNIQUE,
+			email VARCHAR(255) NOT NULL,
+			timezone VARCHAR(50) NOT NULL DEFAULT 'UTC',
+			is_guest BOOLEAN NOT NULL DEFAULT FALSE,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			deleted_at TIMESTAMP NULL,
+			live_email VARCHAR(255) AS (IF(deleted_at IS NULL, email, NULL)) STORED,
+			UNIQUE INDEX uq_users_live_email (live_email),
+			INDEX idx_users_email (email),
+			INDEX idx_users_deleted (deleted_at)
 		)`,
 			`CREATE TABLE IF NOT EXISTS events (
 			id VARCHAR(50) PRIMARY KEY,
@@ -157,6 +164,8 @@ func (d *Database) RunMigrations() error {
 			organizer_id VARCHAR(50) NOT NULL,
 			duration_minutes INT NOT NULL,
 			status VARCHAR(20) DEFAULT 'pending',
+			version INT NOT NULL DEFAULT 1,
+			response_deadline TIMESTAMP NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			deleted_at TIMESTAMP NULL,
@@ -179,11 +188,15 @@ func (d *Database) RunMigrations() error {
 			event_id VARCHAR(50) NOT NULL,
 			user_id VARCHAR(50) NOT NULL,
 			status VARCHAR(20) DEFAULT 'invited',
+			role VARCHAR(20) NOT NULL DEFAULT 'attendee',
+			response_reason VARCHAR(500) NULL,
+			group_id VARCHAR(50) NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			UNIQUE INDEX idx_event_user (event_id, user_id),
 			INDEX idx_participants_event (event_id),
 			INDEX idx_participants_user (user_id),
+			INDEX idx_participants_group (group_id),
 			FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		)`,
@@ -199,6 +212,61 @@ func (d *Database) RunMigrations() error {
 			INDEX idx_availability_event_user (event_id, user_id),
 			FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+			`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			scope VARCHAR(150) NOT NULL,
+			idempotency_key VARCHAR(255) NOT NULL,
+			user_id VARCHAR(50) NULL,
+			request_hash CHAR(64) NOT NULL,
+			response JSON NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (scope, idempotency_key),
+			INDEX idx_idempotency_user (user_id),
+			INDEX idx_idempotency_created (created_at)
+		)`,
+			`CREATE TABLE IF NOT EXISTS api_keys (
+			id VARCHAR(50) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			key_hash CHAR(64) NOT NULL UNIQUE,
+			user_id VARCHAR(50) NULL,
+			is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			revoked_at TIMESTAMP NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+			`CREATE TABLE IF NOT EXISTS user_groups (
+			id VARCHAR(50) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			owner_id VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			INDEX idx_user_groups_owner (owner_id),
+			FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+			`CREATE TABLE IF NOT EXISTS group_members (
+			group_id VARCHAR(50) NOT NULL,
+			user_id VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (group_id, user_id),
+			INDEX idx_group_members_user (user_id),
+			FOREIGN KEY (group_id) REFERENCES user_groups(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+			// The audit log outlives what it describes, so it has no foreign keys
+			`CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			actor VARCHAR(255) NOT NULL DEFAULT '',
+			action VARCHAR(50) NOT NULL,
+			entity_type VARCHAR(30) NOT NULL,
+			entity_id VARCHAR(50) NOT NULL,
+			event_id VARCHAR(50) NULL,
+			before_data JSON NULL,
+			after_data JSON NULL,
+			request_id VARCHAR(100) NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			INDEX idx_audit_entity (entity_type, entity_id),
+			INDEX idx_audit_event (event_id),
+			INDEX idx_audit_created (created_at)
 		)`,
 		}
 
@@ -209,12 +277,95 @@ func (d *Database) RunMigrations() error {
 			}
 		}
 
+		// Columns added after the initial schema. CREATE TABLE IF NOT EXISTS
+		// leaves existing tables untouched, so these bring older databases up
+		// to date.
+		columns := []columnMigration{
+			{table: "users", column: "timezone", definition: "VARCHAR(50) NOT NULL DEFAULT 'UTC' AFTER email"},
+			{table: "users", column: "is_guest", definition: "BOOLEAN NOT NULL DEFAULT FALSE AFTER timezone"},
+			{table: "users", column: "deleted_at", definition: "TIMESTAMP NULL AFTER updated_at"},
+			// Only users who are not deleted hold their email, so a deleted
+			// user's email can be used again
+			{table: "users", column: "live_email", definition: "VARCHAR(255) AS (IF(deleted_at IS NULL, email, NULL)) STORED AFTER deleted_at, " +
+				"ADD UNIQUE INDEX uq_users_live_email (live_email), ADD INDEX idx_users_email (email)"},
+			{table: "event_participants", column: "response_reason", definition: "VARCHAR(500) NULL AFTER status"},
+			{table: "event_participants", column: "role", definition: "VARCHAR(20) NOT NULL DEFAULT 'attendee' AFTER status"},
+			{table: "event_participants", column: "group_id", definition: "VARCHAR(50) NULL AFTER response_reason"},
+			{table: "events", column: "version", definition: "INT NOT NULL DEFAULT 1 AFTER status"},
+			{table: "events", column: "response_deadline", definition: "TIMESTAMP NULL AFTER version"},
+			// The index is added with the column, so it is only created once
+			{table: "audit_log", column: "event_id", definition: "VARCHAR(50) NULL AFTER entity_id, ADD INDEX idx_audit_event (event_id)"},
+			{table: "audit_log", column: "before_data", definition: "JSON NULL AFTER event_id"},
+			{table: "audit_log", column: "after_data", definition: "JSON NULL AFTER before_data"},
+			{table: "audit_log", column: "request_id", definition: "VARCHAR(100) NOT NULL DEFAULT '' AFTER after_data"},
+			{table: "idempotency_keys", column: "user_id", definition: "VARCHAR(50) NULL AFTER idempotency_key, ADD INDEX idx_idempotency_user (user_id)"},
+		}
+
+		for _, c := range columns {
+			if err := addColumnIfMissing(db, c); err != nil {
+				d.migrationErr = fmt.Errorf("failed to run migration: %w", err)
+				return
+			}
+		}
+
+		// Indexes the schema no longer has. The unique key on users.email
+		// was replaced by the one on users.live_email.
+		if err := dropIndexIfExists(db, "users", "email"); err != nil {
+			d.migrationErr = fmt.Errorf("failed to run migration: %w", err)
+			return
+		}
+
 		log.Println("Database migrations completed successfully")
 	})
 
 	return d.migrationErr
 }
 
+// columnMigration describes a column to add to an existing table.
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+}
+
+// addColumnIfMissing adds the column described by c unless the table already
+// has it. MySQL has no ADD COLUMN IF NOT EXISTS, so the information schema is
+// consulted first.
+func addColumnIfMissing(db *sql.DB, c columnMigration) error {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.COLUMNS
+			  WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	if err := db.QueryRow(query, c.table, c.column).Scan(&count); err != nil {
+		return fmt.Errorf("failed to inspect %s.%s: %w", c.table, c.column, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.definition)); err != nil {
+		return fmt.Errorf("failed to add %s.%s: %w", c.table, c.column, err)
+	}
+	return nil
+}
+
+// dropIndexIfExists drops an index unless the table no longer has it.
+func dropIndexIfExists(db *sql.DB, table, index string) error {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.STATISTICS
+			  WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`
+	if err := db.QueryRow(query, table, index).Scan(&count); err != nil {
+		return fmt.Errorf("failed to inspect index %s.%s: %w", table, index, err)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP INDEX %s", table, index)); err != nil {
+		return fmt.Errorf("failed to drop index %s.%s: %w", table, index, err)
+	}
+	return nil
+}
+
 // Close closes the database connection
 func (d *Database) Close() error {
 	if d.db != nil {
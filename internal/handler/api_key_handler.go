@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// APIKeyHandler handles API key management requests
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAPIKeyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(r.Context(), req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusCreated, key)
+}
+
+// ListAPIKeys handles GET /api/v1/api-keys
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	// Return empty array instead of null when there are no keys
+	if keys == nil {
+		keys = []*models.APIKey{}
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, keys)
+}
+
+// RevokeAPIKey handles DELETE /api/v1/api-keys/{id}
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.apiKeyService.RevokeAPIKey(r.Context(), id); err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
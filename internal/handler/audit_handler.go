@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// AuditHandler handles reading the audit log
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// EventHistory handles GET /api/v1/events/{id}/history
+func (h *AuditHandler) EventHistory(w http.ResponseWriter, r *http.Request) {
+	eventID := mux.Vars(r)["id"]
+
+	h.writeAuditList(w, r, func(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+		return h.auditService.EventHistory(ctx, eventID, filter)
+	})
+}
+
+// ListAudit handles GET /api/v1/audit
+func (h *AuditHandler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	h.writeAuditList(w, r, func(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+		filter.EventID = r.URL.Query().Get("event_id")
+		return h.auditService.ListAudit(ctx, filter)
+	})
+}
+
+// writeAuditList reads the filters shared by every audit log listing, lists
+// the entries with list and writes the page in the requested shape.
+func (h *AuditHandler) writeAuditList(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(context.Context, models.AuditFilter) (*models.AuditPage, error),
+) {
+	query := r.URL.Query()
+
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	shape, err := utils.ParseShape(query)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	filter := models.AuditFilter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		RequestID:  query.Get("request_id"),
+		Cursor:     query.Get("cursor"),
+	}
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	// Ranges are given as RFC 3339 timestamps, or as local times in the
+	// response timezone
+	timezone := "UTC"
+	if loc != nil {
+		timezone = loc.String()
+	}
+	for name, bound := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		if *bound, err = utils.ParseSlotTime(value, timezone); err != nil {
+			utils.WriteErrorFrom(w, utils.InvalidField(name, "invalid %s: %v", name, err))
+			return
+		}
+	}
+
+	page, err := list(r.Context(), filter)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	if loc != nil {
+		for _, entry := range page.Entries {
+			entry.CreatedAt = entry.CreatedAt.In(loc)
+		}
+	}
+
+	utils.WriteCursorResponse(w, page.Entries, shape, page.Limit, page.NextCursor)
+}
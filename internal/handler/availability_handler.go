@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/service"
 	"meeting-slot-service/internal/utils"
@@ -33,22 +32,19 @@ func (h *AvailabilityHandler) SubmitAvailability(w http.ResponseWriter, r *http.
 	eventID := vars["id"]
 	userID := vars["user_id"]
 
-	var req struct {
-		AvailableSlots []models.AvailabilitySlot `json:"available_slots"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	var req models.AvailabilityRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
 
-	if err := h.availabilityService.SubmitAvailability(r.Context(), eventID, userID, req.AvailableSlots); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+	result, err := h.availabilityService.SubmitAvailability(r.Context(), eventID, userID, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, map[string]string{
-		"message": "Availability submitted successfully",
-	})
+	utils.WriteSuccess(w, http.StatusOK, result)
 }
 
 // UpdateAvailability handles PUT /api/v1/events/{id}/participants/{user_id}/availability
@@ -57,22 +53,68 @@ func (h *AvailabilityHandler) UpdateAvailability(w http.ResponseWriter, r *http.
 	eventID := vars["id"]
 	userID := vars["user_id"]
 
-	var req struct {
-		AvailableSlots []models.AvailabilitySlot `json:"available_slots"`
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AvailabilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	result, err := h.availabilityService.UpdateAvailability(r.Context(), eventID, userID, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
+// WithdrawAvailability handles DELETE /api/v1/events/{id}/participants/{user_id}/availability
+func (h *AvailabilityHandler) WithdrawAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+	userID := vars["user_id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.availabilityService.WithdrawAvailability(r.Context(), eventID, userID); err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RespondToInvitation handles PUT /api/v1/events/{id}/participants/{user_id}/rsvp
+func (h *AvailabilityHandler) RespondToInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+	userID := vars["user_id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+
+	var req models.RSVPRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if err := h.availabilityService.UpdateAvailability(r.Context(), eventID, userID, req.AvailableSlots); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+	participant, err := h.availabilityService.RespondToInvitation(r.Context(), eventID, userID, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, map[string]string{
-		"message": "Availability updated successfully",
-	})
+	utils.WriteSuccess(w, http.StatusOK, participant)
 }
 
 // GetAvailability handles GET /api/v1/events/{id}/participants/{user_id}/availability
@@ -81,18 +123,40 @@ func (h *AvailabilityHandler) GetAvailability(w http.ResponseWriter, r *http.Req
 	eventID := vars["id"]
 	userID := vars["user_id"]
 
-	slots, err := h.availabilityService.GetAvailability(r.Context(), eventID, userID)
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	shape, err := utils.ParseShape(r.URL.Query())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	slots, version, err := h.availabilityService.GetAvailability(r.Context(), eventID, userID)
 	if err != nil {
-		utils.WriteInternalError(w, "Failed to get availability")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
+	if notModified(w, r, version, loc, shape) {
+		return
+	}
+
+	if loc != nil {
+		for i := range slots {
+			slots[i].ConvertTimesTo(loc)
+		}
+	}
+
 	// Return empty array instead of null when no availability
 	if slots == nil {
 		slots = []models.AvailabilitySlot{}
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, slots)
+	utils.WriteShaped(w, http.StatusOK, slots, shape)
 }
 
 // GetRecommendations handles GET /api/v1/events/{id}/recommendations
@@ -100,11 +164,21 @@ func (h *AvailabilityHandler) GetRecommendations(w http.ResponseWriter, r *http.
 	vars := mux.Vars(r)
 	eventID := vars["id"]
 
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
 	recommendations, err := h.recommendationService.GetRecommendations(r.Context(), eventID)
 	if err != nil {
-		utils.WriteInternalError(w, err.Error())
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
+	if loc != nil {
+		recommendations.ConvertTimesTo(loc)
+	}
+
 	utils.WriteSuccess(w, http.StatusOK, recommendations)
 }
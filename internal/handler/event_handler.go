@@ -1,12 +1,15 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/service"
 	"meeting-slot-service/internal/utils"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -14,25 +17,37 @@ import (
 // EventHandler handles event-related HTTP requests
 type EventHandler struct {
 	eventService *service.EventService
+	guestService *service.GuestService
+	groupService *service.GroupService
 }
 
 // NewEventHandler creates a new event handler
-func NewEventHandler(eventService *service.EventService) *EventHandler {
+func NewEventHandler(eventService *service.EventService, guestService *service.GuestService, groupService *service.GroupService) *EventHandler {
 	return &EventHandler{
 		eventService: eventService,
+		guestService: guestService,
+		groupService: groupService,
 	}
 }
 
+// Optional members of event responses, embedded with ?expand=
+const (
+	expandParticipantUser = "participants.user"
+	expandAvailability    = "availability_summary"
+)
+
+// eventExpansions are the members event responses can embed on request.
+var eventExpansions = []string{expandParticipantUser, expandAvailability}
+
 // CreateEvent handles POST /api/v1/events
 func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	var event models.Event
-	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	if !decodeJSON(w, r, &event) {
 		return
 	}
 
 	if err := h.eventService.CreateEvent(r.Context(), &event); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -42,25 +57,141 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 // GetEventList handles GET /api/v1/events
 func (h *EventHandler) GetEventList(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
+	filter := models.EventFilter{
+		OrganizerID:   query.Get("organizer_id"),
+		Status:        query.Get("status"),
+		ParticipantID: query.Get("participant_id"),
+		Title:         query.Get("title"),
+	}
+	h.writeEventList(w, r, filter, h.eventService.ListEvents)
+}
+
+// GetUserEvents handles GET /api/v1/users/{id}/events
+func (h *EventHandler) GetUserEvents(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	query := r.URL.Query()
+	filter := models.EventFilter{
+		ParticipantStatus: query.Get("status"),
+		Title:             query.Get("title"),
+	}
+	h.writeEventList(w, r, filter, func(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+		return h.eventService.ListUserEvents(ctx, userID, query.Get("role"), filter)
+	})
+}
+
+// GetUserInbox handles GET /api/v1/users/{id}/inbox
+func (h *EventHandler) GetUserInbox(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	h.writeEventList(w, r, models.EventFilter{}, func(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+		return h.eventService.ListUserInbox(ctx, userID, filter)
+	})
+}
+
+// writeEventList completes filter with the listing parameters shared by
+// every event listing (pagination, sort, ranges and relations), lists the
+// events with list and writes the page in the requested shape.
+func (h *EventHandler) writeEventList(
+	w http.ResponseWriter,
+	r *http.Request,
+	filter models.EventFilter,
+	list func(context.Context, models.EventFilter) (*models.EventPage, error),
+) {
+	query := r.URL.Query()
+
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	shape, err := utils.ParseShape(query, eventExpansions...)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
 
 	// Parse query parameters
-	page, _ := strconv.Atoi(query.Get("page"))
-	limit, _ := strconv.Atoi(query.Get("limit"))
+	filter.Page, _ = strconv.Atoi(query.Get("page"))
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+	filter.Sort = query.Get("sort")
+	filter.Cursor = query.Get("cursor")
+
+	// Without ?include every relation is loaded; an empty one loads none
+	if values, ok := query["include"]; ok {
+		filter.Include = []string{}
+		for _, value := range values {
+			for _, relation := range strings.Split(value, ",") {
+				if relation = strings.TrimSpace(relation); relation != "" {
+					filter.Include = append(filter.Include, relation)
+				}
+			}
+		}
+	}
 
-	filter := models.EventFilter{
-		OrganizerID: query.Get("organizer_id"),
-		Status:      query.Get("status"),
-		Page:        page,
-		Limit:       limit,
+	// The availability summary is counted from the participants, so load
+	// them for it even when they are not wanted in the response
+	dropParticipants := false
+	if shape.Expands(expandAvailability) && filter.Include != nil && !includesParticipants(filter.Include) {
+		filter.Include = append(filter.Include, models.EventIncludeParticipants)
+		dropParticipants = true
 	}
 
-	events, total, err := h.eventService.ListEvents(r.Context(), filter)
+	// Ranges are given as RFC 3339 timestamps, or as local times in the
+	// response timezone
+	timezone := "UTC"
+	if loc != nil {
+		timezone = loc.String()
+	}
+	for name, bound := range map[string]*time.Time{
+		"slots_from":   &filter.SlotsFrom,
+		"slots_to":     &filter.SlotsTo,
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		if *bound, err = utils.ParseSlotTime(value, timezone); err != nil {
+			utils.WriteErrorFrom(w, utils.InvalidField(name, "invalid %s: %v", name, err))
+			return
+		}
+	}
+
+	result, err := list(r.Context(), filter)
 	if err != nil {
-		utils.WriteInternalError(w, "Failed to list events")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
-	utils.WritePaginatedResponse(w, events, filter.Page, filter.Limit, total)
+	for _, event := range result.Events {
+		if loc != nil {
+			event.ConvertTimesTo(loc)
+		}
+		if shape.Expands(expandAvailability) {
+			event.SummarizeAvailability()
+			if dropParticipants {
+				event.Participants = nil
+			}
+		}
+	}
+
+	if filter.Cursor != "" {
+		utils.WriteCursorResponse(w, result.Events, shape, result.Limit, result.NextCursor)
+		return
+	}
+	utils.WritePaginatedResponse(w, result.Events, shape, result.Page, result.Limit, result.Total, result.NextCursor)
+}
+
+// includesParticipants reports whether include names the participants
+// relation.
+func includesParticipants(include []string) bool {
+	for _, relation := range include {
+		if relation == models.EventIncludeParticipants {
+			return true
+		}
+	}
+	return false
 }
 
 // GetEvent handles GET /api/v1/events/{id}
@@ -68,13 +199,36 @@ func (h *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID := vars["id"]
 
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	shape, err := utils.ParseShape(r.URL.Query(), eventExpansions...)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
 	event, err := h.eventService.GetEvent(r.Context(), eventID)
 	if err != nil {
-		utils.WriteNotFound(w, "Event not found")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, event)
+	if notModified(w, r, event.Version, loc, shape) {
+		return
+	}
+
+	if loc != nil {
+		event.ConvertTimesTo(loc)
+	}
+	if shape.Expands(expandAvailability) {
+		event.SummarizeAvailability()
+	}
+
+	utils.WriteShaped(w, http.StatusOK, event, shape)
 }
 
 // UpdateEvent handles PUT /api/v1/events/{id}
@@ -82,18 +236,48 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID := vars["id"]
 
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var event models.Event
-	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	if !decodeJSON(w, r, &event) {
 		return
 	}
 
 	event.ID = eventID
 	if err := h.eventService.UpdateEvent(r.Context(), &event); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
+	w.Header().Set("ETag", etag(event.Version))
+	utils.WriteSuccess(w, http.StatusOK, event)
+}
+
+// PatchEvent handles PATCH /api/v1/events/{id}
+func (h *EventHandler) PatchEvent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch(w, r)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventService.PatchEvent(r.Context(), eventID, patch)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(event.Version))
 	utils.WriteSuccess(w, http.StatusOK, event)
 }
 
@@ -102,8 +286,13 @@ func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID := vars["id"]
 
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	if err := h.eventService.DeleteEvent(r.Context(), eventID); err != nil {
-		utils.WriteNotFound(w, "Event not found")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -116,50 +305,130 @@ func (h *EventHandler) AddParticipant(w http.ResponseWriter, r *http.Request) {
 	eventID := vars["id"]
 
 	var req struct {
-		UserIDs []string `json:"user_ids"`
+		UserIDs []string             `json:"user_ids" validate:"dive,required"`
+		Guests  []models.GuestInvite `json:"guests" validate:"dive"`
+		// GroupIDs expand to the groups' members
+		GroupIDs []string `json:"group_ids" validate:"dive,required"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if len(req.UserIDs) == 0 {
-		utils.WriteBadRequest(w, "user_ids array is required and cannot be empty")
+	if len(req.UserIDs) == 0 && len(req.Guests) == 0 && len(req.GroupIDs) == 0 {
+		utils.WriteErrorFrom(w, utils.Invalid("user_ids, guests or group_ids is required and cannot be empty"))
 		return
 	}
 
 	// Track results
 	var added []string
 	var failed []map[string]string
+	invitations := []*models.GuestInvitation{}
 
 	for _, userID := range req.UserIDs {
 		if err := h.eventService.AddParticipant(r.Context(), eventID, userID); err != nil {
+			// Every item is checked against the same event, so a caller
+			// who may not add one may not add any
+			if errors.Is(err, utils.ErrForbidden) {
+				utils.WriteErrorFrom(w, err)
+				return
+			}
+			_, info := utils.ErrorStatus(err)
 			failed = append(failed, map[string]string{
 				"user_id": userID,
-				"error":   err.Error(),
+				"code":    info.Code,
+				"error":   info.Message,
 			})
 		} else {
 			added = append(added, userID)
 		}
 	}
 
+	for _, guest := range req.Guests {
+		invitation, err := h.guestService.InviteByEmail(r.Context(), eventID, guest)
+		if err != nil {
+			if errors.Is(err, utils.ErrForbidden) {
+				utils.WriteErrorFrom(w, err)
+				return
+			}
+			_, info := utils.ErrorStatus(err)
+			failed = append(failed, map[string]string{
+				"email": guest.Email,
+				"code":  info.Code,
+				"error": info.Message,
+			})
+			continue
+		}
+		added = append(added, invitation.UserID)
+		invitations = append(invitations, invitation)
+	}
+
+	for _, groupID := range req.GroupIDs {
+		userIDs, err := h.groupService.InviteGroup(r.Context(), eventID, groupID)
+		if err != nil {
+			if errors.Is(err, utils.ErrForbidden) {
+				utils.WriteErrorFrom(w, err)
+				return
+			}
+			_, info := utils.ErrorStatus(err)
+			failed = append(failed, map[string]string{
+				"group_id": groupID,
+				"code":     info.Code,
+				"error":    info.Message,
+			})
+			continue
+		}
+		added = append(added, userIDs...)
+	}
+
 	utils.WriteSuccess(w, http.StatusCreated, map[string]interface{}{
-		"message":         "Participants processed",
-		"added_count":     len(added),
-		"failed_count":    len(failed),
-		"added_user_ids":  added,
-		"failed_user_ids": failed,
+		"message":           "Participants processed",
+		"added_count":       len(added),
+		"failed_count":      len(failed),
+		"added_user_ids":    added,
+		"failed_user_ids":   failed,
+		"guest_invitations": invitations,
 	})
 }
 
+// BatchParticipants handles POST /api/v1/events/{id}/participants:batch
+func (h *EventHandler) BatchParticipants(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ParticipantBatchRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	result, err := h.eventService.BatchParticipants(r.Context(), eventID, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(result.Version))
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
 // GetParticipants handles GET /api/v1/events/{id}/participants
 func (h *EventHandler) GetParticipants(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventID := vars["id"]
 
+	shape, err := utils.ParseShape(r.URL.Query(), "user")
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
 	participants, err := h.eventService.GetEventParticipants(r.Context(), eventID)
 	if err != nil {
-		utils.WriteInternalError(w, "Failed to get participants")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -168,7 +437,51 @@ func (h *EventHandler) GetParticipants(w http.ResponseWriter, r *http.Request) {
 		participants = []models.EventParticipant{}
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, participants)
+	utils.WriteShaped(w, http.StatusOK, participants, shape)
+}
+
+// SetParticipantRole handles PUT /api/v1/events/{id}/participants/{user_id}/role
+func (h *EventHandler) SetParticipantRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+	userID := vars["user_id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.RoleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	participant, err := h.eventService.SetParticipantRole(r.Context(), eventID, userID, req.Role)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, participant)
+}
+
+// TransferOwnership handles POST /api/v1/events/{id}/transfer
+func (h *EventHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	var req models.TransferOwnershipRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	event, err := h.eventService.TransferOwnership(r.Context(), eventID, req.OrganizerID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, event)
 }
 
 // RemoveParticipant handles DELETE /api/v1/events/{id}/participants/{user_id}
@@ -177,8 +490,13 @@ func (h *EventHandler) RemoveParticipant(w http.ResponseWriter, r *http.Request)
 	eventID := vars["id"]
 	userID := vars["user_id"]
 
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	if err := h.eventService.RemoveParticipant(r.Context(), eventID, userID); err != nil {
-		utils.WriteNotFound(w, "Participant not found")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GroupHandler handles group-related HTTP requests
+type GroupHandler struct {
+	groupService *service.GroupService
+}
+
+// NewGroupHandler creates a new group handler
+func NewGroupHandler(groupService *service.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// CreateGroup handles POST /api/v1/groups
+func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateGroupRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(r.Context(), req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusCreated, group)
+}
+
+// ListGroups handles GET /api/v1/groups
+func (h *GroupHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	shape, err := utils.ParseShape(r.URL.Query())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	groups, err := h.groupService.ListGroups(r.Context())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteShaped(w, http.StatusOK, groups, shape)
+}
+
+// GetGroup handles GET /api/v1/groups/{id}
+func (h *GroupHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["id"]
+
+	shape, err := utils.ParseShape(r.URL.Query())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	group, err := h.groupService.GetGroup(r.Context(), groupID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteShaped(w, http.StatusOK, group, shape)
+}
+
+// UpdateGroup handles PUT /api/v1/groups/{id}
+func (h *GroupHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["id"]
+
+	var req models.UpdateGroupRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	group, err := h.groupService.UpdateGroup(r.Context(), groupID, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, group)
+}
+
+// DeleteGroup handles DELETE /api/v1/groups/{id}
+func (h *GroupHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["id"]
+
+	if err := h.groupService.DeleteGroup(r.Context(), groupID); err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMembers handles GET /api/v1/groups/{id}/members
+func (h *GroupHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["id"]
+
+	shape, err := utils.ParseShape(r.URL.Query())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	group, err := h.groupService.GetGroup(r.Context(), groupID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	members := group.Members
+	if members == nil {
+		members = []models.User{}
+	}
+	utils.WriteShaped(w, http.StatusOK, members, shape)
+}
+
+// AddMembers handles POST /api/v1/groups/{id}/members
+func (h *GroupHandler) AddMembers(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["id"]
+
+	var req models.GroupMembersRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	group, err := h.groupService.AddMembers(r.Context(), groupID, req.UserIDs)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, group)
+}
+
+// RemoveMember handles DELETE /api/v1/groups/{id}/members/{user_id}
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.groupService.RemoveMember(r.Context(), vars["id"], vars["user_id"]); err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GuestHandler handles requests made through a guest's respond link. These
+// routes are authenticated by the signed token in the path alone.
+type GuestHandler struct {
+	guestService *service.GuestService
+}
+
+// NewGuestHandler creates a new guest handler
+func NewGuestHandler(guestService *service.GuestService) *GuestHandler {
+	return &GuestHandler{
+		guestService: guestService,
+	}
+}
+
+// GetInvitation handles GET /api/v1/respond/{token}
+func (h *GuestHandler) GetInvitation(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	view, err := h.guestService.GetInvitation(r.Context(), token)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	if loc != nil {
+		view.ConvertTimesTo(loc)
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, view)
+}
+
+// SubmitAvailability handles POST /api/v1/respond/{token}
+func (h *GuestHandler) SubmitAvailability(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	var req models.AvailabilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	result, err := h.guestService.SubmitAvailability(r.Context(), token, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
+// UpdateAvailability handles PUT /api/v1/respond/{token}
+func (h *GuestHandler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	var req models.AvailabilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	result, err := h.guestService.UpdateAvailability(r.Context(), token, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
+// RespondToInvitation handles PUT /api/v1/respond/{token}/rsvp
+func (h *GuestHandler) RespondToInvitation(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	var req models.RSVPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	participant, err := h.guestService.RespondToInvitation(r.Context(), token, req)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, participant)
+}
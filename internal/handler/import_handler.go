@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"io"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// maxImportBytes bounds the size of an uploaded import file.
+const maxImportBytes = 5 << 20
+
+// ImportHandler handles CSV imports of users and event participants
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportUsers handles POST /api/v1/users/import
+func (h *ImportHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	file, dryRun, ok := readImport(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.importService.ImportUsers(r.Context(), file, dryRun)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
+// ImportParticipants handles POST /api/v1/events/{id}/participants/import
+func (h *ImportHandler) ImportParticipants(w http.ResponseWriter, r *http.Request) {
+	eventID := mux.Vars(r)["id"]
+
+	file, dryRun, ok := readImport(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.importService.ImportParticipants(r.Context(), eventID, file, dryRun)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	if result.Version > 0 {
+		w.Header().Set("ETag", etag(result.Version))
+	}
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
+
+// readImport returns the CSV file of an import request and whether it is a
+// dry run. The file is either the request body or, in a multipart form, the
+// part named "file". On failure it writes an error response and returns
+// false.
+func readImport(w http.ResponseWriter, r *http.Request) (io.Reader, bool, bool) {
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			utils.WriteErrorFrom(w, utils.InvalidField("dry_run", "dry_run must be true or false"))
+			return nil, false, false
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, dryRun, true
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteBadRequest(w, "Invalid request body: expected a CSV file in the \"file\" field")
+		return nil, false, false
+	}
+	return file, dryRun, true
+}
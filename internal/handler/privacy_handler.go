@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PrivacyHandler handles exporting and erasing a user's personal data
+type PrivacyHandler struct {
+	privacyService *service.PrivacyService
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(privacyService *service.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+	}
+}
+
+// ExportUser handles GET /api/v1/users/{id}/export
+func (h *PrivacyHandler) ExportUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	export, err := h.privacyService.ExportUser(r.Context(), userID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", userID+"-export.json"))
+	utils.WriteSuccess(w, http.StatusOK, export)
+}
+
+// EraseUser handles POST /api/v1/users/{id}/erase
+func (h *PrivacyHandler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	user, err := h.privacyService.EraseUser(r.Context(), userID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, user)
+}
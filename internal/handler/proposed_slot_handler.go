@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ProposedSlotHandler handles HTTP requests for individual proposed slots
+type ProposedSlotHandler struct {
+	proposedSlotService *service.ProposedSlotService
+}
+
+// NewProposedSlotHandler creates a new proposed slot handler
+func NewProposedSlotHandler(proposedSlotService *service.ProposedSlotService) *ProposedSlotHandler {
+	return &ProposedSlotHandler{
+		proposedSlotService: proposedSlotService,
+	}
+}
+
+// ListProposedSlots handles GET /api/v1/events/{id}/proposed-slots
+func (h *ProposedSlotHandler) ListProposedSlots(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	slots, version, err := h.proposedSlotService.ListProposedSlots(r.Context(), eventID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	if notModified(w, r, version, loc, utils.Shape{}) {
+		return
+	}
+
+	if loc != nil {
+		for i := range slots {
+			slots[i].ConvertTimesTo(loc)
+		}
+	}
+
+	if slots == nil {
+		slots = []models.ProposedSlot{}
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, slots)
+}
+
+// GetProposedSlot handles GET /api/v1/events/{id}/proposed-slots/{slot_id}
+func (h *ProposedSlotHandler) GetProposedSlot(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	slotID, ok := slotIDVar(w, r)
+	if !ok {
+		return
+	}
+
+	loc, err := responseLocation(r)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	slot, version, err := h.proposedSlotService.GetProposedSlot(r.Context(), eventID, slotID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	if notModified(w, r, version, loc, utils.Shape{}) {
+		return
+	}
+
+	if loc != nil {
+		slot.ConvertTimesTo(loc)
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, slot)
+}
+
+// AddProposedSlot handles POST /api/v1/events/{id}/proposed-slots
+func (h *ProposedSlotHandler) AddProposedSlot(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	r, ok := withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var slot models.ProposedSlot
+	if !decodeJSON(w, r, &slot) {
+		return
+	}
+
+	change, err := h.proposedSlotService.AddProposedSlot(r.Context(), eventID, &slot)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	writeSlotChange(w, http.StatusCreated, change)
+}
+
+// UpdateProposedSlot handles PUT /api/v1/events/{id}/proposed-slots/{slot_id}
+func (h *ProposedSlotHandler) UpdateProposedSlot(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	slotID, ok := slotIDVar(w, r)
+	if !ok {
+		return
+	}
+
+	r, ok = withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var slot models.ProposedSlot
+	if !decodeJSON(w, r, &slot) {
+		return
+	}
+
+	change, err := h.proposedSlotService.UpdateProposedSlot(r.Context(), eventID, slotID, &slot)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	writeSlotChange(w, http.StatusOK, change)
+}
+
+// PatchProposedSlot handles PATCH /api/v1/events/{id}/proposed-slots/{slot_id}
+func (h *ProposedSlotHandler) PatchProposedSlot(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	slotID, ok := slotIDVar(w, r)
+	if !ok {
+		return
+	}
+
+	r, ok = withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch(w, r)
+	if !ok {
+		return
+	}
+
+	change, err := h.proposedSlotService.PatchProposedSlot(r.Context(), eventID, slotID, patch)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	writeSlotChange(w, http.StatusOK, change)
+}
+
+// RemoveProposedSlot handles DELETE /api/v1/events/{id}/proposed-slots/{slot_id}
+func (h *ProposedSlotHandler) RemoveProposedSlot(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID := vars["id"]
+
+	slotID, ok := slotIDVar(w, r)
+	if !ok {
+		return
+	}
+
+	r, ok = withIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	change, err := h.proposedSlotService.RemoveProposedSlot(r.Context(), eventID, slotID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	// The body reports whose availability the removal stranded, so this
+	// is a 200 rather than a 204
+	writeSlotChange(w, http.StatusOK, change)
+}
+
+// slotIDVar parses the {slot_id} path variable. An ID that is not a number
+// names no slot, so it gets a 404 and slotIDVar returns false.
+func slotIDVar(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	value := mux.Vars(r)["slot_id"]
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 {
+		utils.WriteErrorFrom(w, utils.NotFound("proposed slot %s not found", value))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeSlotChange writes the outcome of a proposed slot change with the
+// event's new ETag.
+func writeSlotChange(w http.ResponseWriter, status int, change *models.ProposedSlotChange) {
+	w.Header().Set("ETag", etag(change.Version))
+	utils.WriteSuccess(w, status, change)
+}
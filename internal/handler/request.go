@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+)
+
+// decodeJSON decodes the request body into v and checks its `validate`
+// tags. On failure it writes an error response and returns false. A body
+// that cannot be decoded is a 400; rejected values, including timezone and
+// wall-clock errors raised while decoding slot times, are a 422 that names
+// the fields.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		err = utils.ValidateStruct(v)
+	}
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, utils.ErrValidation) {
+		utils.WriteErrorFrom(w, err)
+		return false
+	}
+
+	utils.WriteBadRequest(w, "Invalid request body")
+	return false
+}
+
+// readPatch reads a JSON merge patch (RFC 7396) from the request body. A
+// body that is not a JSON object gets a 400 and readPatch returns false.
+func readPatch(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		var doc map[string]json.RawMessage
+		if json.Unmarshal(body, &doc) == nil && doc != nil {
+			return body, true
+		}
+	}
+
+	utils.WriteBadRequest(w, "Invalid request body: expected a JSON merge patch object")
+	return nil, false
+}
+
+// timezoneHeader lets clients pick the zone responses are rendered in without
+// touching the query string.
+const timezoneHeader = "Accept-Timezone"
+
+// responseLocation resolves the zone that times in a response are rendered
+// in: the ?tz= query parameter, then the Accept-Timezone header, then the
+// authenticated principal's timezone. It returns a nil location when none is
+// known, meaning times are returned as stored.
+func responseLocation(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		name = r.Header.Get(timezoneHeader)
+	}
+	if name != "" {
+		return utils.LoadTimezone(name)
+	}
+
+	// A stored or asserted timezone that no longer loads should not fail
+	// the request; fall back to returning times as stored.
+	if p := auth.FromContext(r.Context()); p != nil && p.Timezone != "" {
+		if loc, err := utils.LoadTimezone(p.Timezone); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, nil
+}
+
+// etag formats an event version as an entity tag.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// representationTag is the entity tag of a response rendered in loc and
+// reduced to shape. Responses rendered as stored and in full are tagged
+// with the bare version; any other rendering adds a hash of it, so that
+// caches do not answer one rendering with another.
+func representationTag(version int, loc *time.Location, shape utils.Shape) string {
+	if loc == nil && len(shape.Fields) == 0 && len(shape.Expand) == 0 {
+		return etag(version)
+	}
+
+	h := fnv.New32a()
+	if loc != nil {
+		fmt.Fprintf(h, "tz=%s;", loc.String())
+	}
+	fmt.Fprintf(h, "fields=%s;expand=%s", sortedList(shape.Fields), sortedList(shape.Expand))
+	return fmt.Sprintf(`"%d-%08x"`, version, h.Sum32())
+}
+
+// sortedList joins a copy of list in sorted order.
+func sortedList(list []string) string {
+	sorted := append([]string(nil), list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// tagVersion returns the event version an entity tag names, ignoring the
+// rendering hash added by representationTag.
+func tagVersion(tag string) (int, bool) {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+	value := tag[1 : len(tag)-1]
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		value = value[:i]
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 || strconv.Itoa(version) != value {
+		return 0, false
+	}
+	return version, true
+}
+
+// withIfMatch makes the changes a request causes conditional on the event
+// version named by its If-Match header. Any rendering of that version
+// matches. Without the header, or with "*", they are unconditional. A
+// header that names no version gets a 412 and withIfMatch returns false.
+func withIfMatch(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return r, true
+	}
+
+	version, ok := tagVersion(value)
+	if !ok {
+		utils.WriteErrorFrom(w, utils.PreconditionFailed("If-Match must name a single event version, e.g. %s", etag(1)))
+		return nil, false
+	}
+	return r.WithContext(service.WithIfMatch(r.Context(), version)), true
+}
+
+// notModified sets the ETag header for an event version rendered in loc and
+// reduced to shape. When If-None-Match already names it, it writes 304 Not
+// Modified and returns true.
+func notModified(w http.ResponseWriter, r *http.Request, version int, loc *time.Location, shape utils.Shape) bool {
+	tag := representationTag(version, loc, shape)
+	w.Header().Set("ETag", tag)
+	w.Header().Add("Vary", timezoneHeader)
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		// If-None-Match uses weak comparison
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"meeting-slot-service/internal/service"
+	"meeting-slot-service/internal/utils"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RetentionHandler handles restoring deleted users and events, and purging
+// them
+type RetentionHandler struct {
+	retentionService *service.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retentionService *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// RestoreUser handles POST /api/v1/users/{id}:restore
+func (h *RetentionHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	user, err := h.retentionService.RestoreUser(r.Context(), userID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, user)
+}
+
+// RestoreEvent handles POST /api/v1/events/{id}:restore
+func (h *RetentionHandler) RestoreEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := mux.Vars(r)["id"]
+
+	event, err := h.retentionService.RestoreEvent(r.Context(), eventID)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(event.Version))
+	utils.WriteSuccess(w, http.StatusOK, event)
+}
+
+// Purge handles POST /api/v1/admin/purge
+func (h *RetentionHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	result, err := h.retentionService.Purge(r.Context())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, result)
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/service"
 	"meeting-slot-service/internal/utils"
@@ -26,13 +25,12 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 // CreateUser handles POST /api/v1/users
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var user models.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	if !decodeJSON(w, r, &user) {
 		return
 	}
 
 	if err := h.userService.CreateUser(r.Context(), &user); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -44,13 +42,19 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
+	shape, err := utils.ParseShape(r.URL.Query())
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
 	user, err := h.userService.GetUser(r.Context(), userID)
 	if err != nil {
-		utils.WriteNotFound(w, "User not found")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, user)
+	utils.WriteShaped(w, http.StatusOK, user, shape)
 }
 
 // UpdateUser handles PUT /api/v1/users/{id}
@@ -59,14 +63,32 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	userID := vars["id"]
 
 	var user models.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		utils.WriteBadRequest(w, "Invalid request body")
+	if !decodeJSON(w, r, &user) {
 		return
 	}
 
 	user.ID = userID
 	if err := h.userService.UpdateUser(r.Context(), &user); err != nil {
-		utils.WriteBadRequest(w, err.Error())
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
+	utils.WriteSuccess(w, http.StatusOK, user)
+}
+
+// PatchUser handles PATCH /api/v1/users/{id}
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	patch, ok := readPatch(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.PatchUser(r.Context(), userID, patch)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -79,7 +101,7 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	userID := vars["id"]
 
 	if err := h.userService.DeleteUser(r.Context(), userID); err != nil {
-		utils.WriteNotFound(w, "User not found")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -90,6 +112,12 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 
+	shape, err := utils.ParseShape(query)
+	if err != nil {
+		utils.WriteErrorFrom(w, err)
+		return
+	}
+
 	// Parse pagination parameters
 	page := 1
 	limit := 20
@@ -107,7 +135,7 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 
 	users, err := h.userService.ListUsers(r.Context(), page, limit)
 	if err != nil {
-		utils.WriteInternalError(w, "Failed to list users")
+		utils.WriteErrorFrom(w, err)
 		return
 	}
 
@@ -116,5 +144,5 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		users = []*models.User{}
 	}
 
-	utils.WriteSuccess(w, http.StatusOK, users)
+	utils.WriteShaped(w, http.StatusOK, users, shape)
 }
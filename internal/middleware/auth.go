@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/utils"
+)
+
+// Authenticate verifies request credentials with authn and stores the
+// principal in the request context. Requests with invalid credentials are
+// always rejected. Requests without credentials are rejected when required
+// is set, and otherwise passed on unauthenticated. A nil authn treats every
+// request as carrying no credentials.
+func Authenticate(authn auth.Authenticator, required bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := auth.ErrNoCredentials
+			var principal *auth.Principal
+			if authn != nil {
+				principal, err = authn.Authenticate(r)
+			}
+
+			switch {
+			case err == nil:
+				next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+			case errors.Is(err, auth.ErrNoCredentials) && !required:
+				next.ServeHTTP(w, r)
+			case errors.Is(err, auth.ErrNoCredentials):
+				w.Header().Set("WWW-Authenticate", `Bearer realm="meeting-slot-service"`)
+				utils.WriteUnauthorized(w, "Authentication required")
+			case errors.Is(err, auth.ErrInvalidCredentials):
+				log.Printf("Rejected credentials for %s %s: %v", r.Method, r.URL.Path, err)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="meeting-slot-service", error="invalid_token"`)
+				utils.WriteUnauthorized(w, "Invalid credentials")
+			default:
+				log.Printf("Authentication failed for %s %s: %v", r.Method, r.URL.Path, err)
+				utils.WriteInternalError(w, "Authentication failed")
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package middleware
 
 import (
 	"log"
+	"meeting-slot-service/internal/utils"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// guestTokenSegment precedes the guest token in respond link paths. The token
+// is a credential, so it is never logged.
+const guestTokenSegment = "/respond/"
+
 // responseWriter wraps http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -34,11 +40,30 @@ func Logger(next http.Handler) http.Handler {
 		// Log request
 		duration := time.Since(start)
 		log.Printf(
-			"%s %s %d %s",
+			"%s %s %s %d %s",
+			utils.RequestIDFromContext(r.Context()),
 			r.Method,
-			r.RequestURI,
+			loggedURI(r),
 			wrapped.statusCode,
 			duration,
 		)
 	})
 }
+
+// loggedURI returns the request path and query with any guest token
+// redacted.
+func loggedURI(r *http.Request) string {
+	uri := r.URL.EscapedPath()
+	if i := strings.Index(uri, guestTokenSegment); i >= 0 {
+		start := i + len(guestTokenSegment)
+		end := len(uri)
+		if j := strings.IndexByte(uri[start:], '/'); j >= 0 {
+			end = start + j
+		}
+		uri = uri[:start] + "[redacted]" + uri[end:]
+	}
+	if r.URL.RawQuery != "" {
+		uri += "?" + r.URL.RawQuery
+	}
+	return uri
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"meeting-slot-service/internal/utils"
+	"net/http"
+)
+
+// RequestID middleware names each request with the client's X-Request-ID,
+// or a new ID when it sends none or an unusable one, echoes it in the
+// response and puts it in the request context
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(utils.RequestIDHeader)
+		if !utils.ValidRequestID(id) {
+			id = utils.GenerateRequestID()
+		}
+
+		w.Header().Set(utils.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(utils.WithRequestID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKey is a credential for service-to-service calls. Only a hash of the
+// key is stored.
+type APIKey struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	KeyHash string `json:"-"`
+	// UserID is the user the key acts as, if any.
+	UserID    string     `json:"user_id,omitempty"`
+	IsAdmin   bool       `json:"is_admin"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyRequest is the body of an API key creation request.
+type CreateAPIKeyRequest struct {
+	Name    string `json:"name" validate:"required,max=255"`
+	UserID  string `json:"user_id,omitempty"`
+	IsAdmin bool   `json:"is_admin"`
+}
+
+// CreatedAPIKey is returned once, when a key is created. Key is the only
+// copy of the plaintext secret.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
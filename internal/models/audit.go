@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited actions, named <entity type>.<verb>
+const (
+	AuditActionEventCreate   = "event.create"
+	AuditActionEventUpdate   = "event.update"
+	AuditActionEventDelete   = "event.delete"
+	AuditActionEventRestore  = "event.restore"
+	AuditActionEventTransfer = "event.transfer"
+
+	AuditActionParticipantAdd     = "participant.add"
+	AuditActionParticipantUpdate  = "participant.update"
+	AuditActionParticipantRemove  = "participant.remove"
+	AuditActionParticipantRespond = "participant.respond"
+
+	AuditActionAvailabilitySave     = "availability.save"
+	AuditActionAvailabilityWithdraw = "availability.withdraw"
+
+	AuditActionProposedSlotAdd    = "proposed_slot.add"
+	AuditActionProposedSlotUpdate = "proposed_slot.update"
+	AuditActionProposedSlotRemove = "proposed_slot.remove"
+
+	AuditActionUserCreate  = "user.create"
+	AuditActionUserUpdate  = "user.update"
+	AuditActionUserDelete  = "user.delete"
+	AuditActionUserRestore = "user.restore"
+	AuditActionUserErase   = "user.erase"
+)
+
+// Audited entity types. Participants and availability are identified by
+// the user's ID, proposed slots by the slot's ID, and all three by the event
+// they belong to.
+const (
+	AuditEntityEvent        = "event"
+	AuditEntityParticipant  = "participant"
+	AuditEntityAvailability = "availability"
+	AuditEntityProposedSlot = "proposed_slot"
+	AuditEntityUser         = "user"
+)
+
+// AuditEntry records who did what to which entity, and when. Actor is the
+// user the caller acted as, or the credential's subject when it acts as no
+// user; it is empty for internal calls. EventID is set for changes to an
+// event and everything in it. Before and After hold the entity as JSON
+// around the change, and are omitted for an entity that did not exist on
+// that side of it. RequestID names the HTTP request that made the change.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	EventID    string          `json:"event_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows an audit log listing. Empty fields match every entry.
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	EventID    string
+	RequestID  string
+	// From and To limit results to entries created in [From, To). Either
+	// may be zero.
+	From time.Time
+	To   time.Time
+	// Cursor continues a listing after the last entry of a previous page
+	Cursor string
+	Limit  int
+}
+
+// AuditPage is one page of an audit log listing, newest entry first.
+type AuditPage struct {
+	Entries []*AuditEntry
+	Limit   int
+	// NextCursor continues the listing after this page, and is empty on the
+	// last page
+	NextCursor string
+}
@@ -0,0 +1,46 @@
+package models
+
+// AvailabilityRequest is the body of an availability submission or update.
+type AvailabilityRequest struct {
+	AvailableSlots []AvailabilitySlot `json:"available_slots" validate:"dive"`
+	// Strict rejects slots that fall outside the event's proposed windows
+	// instead of clipping them.
+	Strict bool `json:"strict"`
+	// Mode is AvailabilityModeMerge or AvailabilityModeReplace. Empty means
+	// the endpoint's default.
+	Mode string `json:"mode,omitempty" validate:"omitempty,oneof=merge replace"`
+	// IdempotencyKey comes from the Idempotency-Key header, not the body.
+	IdempotencyKey string `json:"-"`
+}
+
+// AvailabilityRequest modes
+const (
+	// AvailabilityModeMerge merges the submitted slots into the participant's
+	// existing availability.
+	AvailabilityModeMerge = "merge"
+	// AvailabilityModeReplace discards existing availability first.
+	AvailabilityModeReplace = "replace"
+)
+
+// AvailabilitySubmission is the normalized availability that was stored,
+// together with warnings describing how it differs from what was sent.
+type AvailabilitySubmission struct {
+	Message        string                `json:"message"`
+	AvailableSlots []AvailabilitySlot    `json:"available_slots"`
+	Warnings       []AvailabilityWarning `json:"warnings,omitempty"`
+}
+
+// AvailabilityWarning describes one change made while normalizing a
+// submission. SlotIndex refers to the submitted slot, when applicable.
+type AvailabilityWarning struct {
+	Code      string `json:"code"`
+	SlotIndex *int   `json:"slot_index,omitempty"`
+	Message   string `json:"message"`
+}
+
+// AvailabilityWarning codes
+const (
+	AvailabilityWarningClipped = "clipped"
+	AvailabilityWarningDropped = "outside_proposed_windows"
+	AvailabilityWarningMerged  = "merged"
+)
@@ -7,17 +7,67 @@ import (
 
 // Event represents a meeting event
 type Event struct {
-	ID              string             `json:"id"`
-	Title           string             `json:"title" validate:"required"`
-	Description     string             `json:"description"`
-	OrganizerID     string             `json:"organizer_id" validate:"required"`
-	DurationMinutes int                `json:"duration_minutes" validate:"required,gt=0"`
-	Status          string             `json:"status"`
-	ProposedSlots   []ProposedSlot     `json:"proposed_slots,omitempty"`
-	Participants    []EventParticipant `json:"participants,omitempty"`
-	CreatedAt       time.Time          `json:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at"`
-	DeletedAt       sql.NullTime       `json:"-"`
+	ID               string             `json:"id"`
+	Title            string             `json:"title" validate:"required,max=255"`
+	Description      string             `json:"description"`
+	OrganizerID      string             `json:"organizer_id"`
+	DurationMinutes  int                `json:"duration_minutes" validate:"required,gt=0"`
+	Status           string             `json:"status"`
+	ResponseDeadline *time.Time         `json:"response_deadline,omitempty"`
+	ProposedSlots    []ProposedSlot     `json:"proposed_slots,omitempty" validate:"dive"`
+	Participants     []EventParticipant `json:"participants,omitempty"`
+	Version          int                `json:"version"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	DeletedAt        sql.NullTime       `json:"-"`
+
+	// ParticipantIDs lists users to invite when the event is created. It is
+	// not stored; the invitations appear in Participants.
+	ParticipantIDs []string `json:"participant_ids,omitempty" validate:"dive,required"`
+
+	// AvailabilitySummary is only set when a response asks for it; see
+	// SummarizeAvailability.
+	AvailabilitySummary *AvailabilitySummary `json:"availability_summary,omitempty"`
+}
+
+// AvailabilitySummary counts an event's participants by how they responded.
+type AvailabilitySummary struct {
+	Participants int `json:"participants"`
+	Invited      int `json:"invited"`
+	Responded    int `json:"responded"`
+	Tentative    int `json:"tentative"`
+	Declined     int `json:"declined"`
+}
+
+// SummarizeAvailability sets AvailabilitySummary from the loaded
+// participants.
+func (e *Event) SummarizeAvailability() {
+	summary := AvailabilitySummary{Participants: len(e.Participants)}
+	for _, p := range e.Participants {
+		switch p.Status {
+		case ParticipantStatusInvited:
+			summary.Invited++
+		case ParticipantStatusResponded:
+			summary.Responded++
+		case ParticipantStatusTentative:
+			summary.Tentative++
+		case ParticipantStatusDeclined:
+			summary.Declined++
+		}
+	}
+	e.AvailabilitySummary = &summary
+}
+
+// ConvertTimesTo renders the response deadline and the start and end of
+// every proposed slot in loc.
+func (e *Event) ConvertTimesTo(loc *time.Location) {
+	if e.ResponseDeadline != nil {
+		deadline := e.ResponseDeadline.In(loc)
+		e.ResponseDeadline = &deadline
+	}
+	for i := range e.ProposedSlots {
+		e.ProposedSlots[i].ConvertTimesTo(loc)
+	}
 }
 
 // EventStatusPending EventStatus constants
@@ -25,10 +75,79 @@ const (
 	EventStatusPending = "pending"
 )
 
+// TransferOwnershipRequest hands an event over to another organizer.
+type TransferOwnershipRequest struct {
+	OrganizerID string `json:"organizer_id" validate:"required"`
+}
+
+// Event sort orders. A leading "-" sorts descending.
+const (
+	EventSortCreatedAt = "created_at"
+	EventSortUpdatedAt = "updated_at"
+	EventSortTitle     = "title"
+	// EventSortResponseDeadline puts events without a deadline after those
+	// with one.
+	EventSortResponseDeadline = "response_deadline"
+
+	EventSortDefault = "-" + EventSortCreatedAt
+)
+
+// Relations that can be loaded with an event
+const (
+	EventIncludeProposedSlots = "proposed_slots"
+	EventIncludeParticipants  = "participants"
+)
+
 // EventFilter represents filters for querying events
 type EventFilter struct {
 	OrganizerID string
 	Status      string
-	Page        int
-	Limit       int
+	// MemberID limits results to events the user organizes or participates in
+	MemberID string
+	// ParticipantID limits results to events the user participates in
+	ParticipantID string
+	// ParticipantStatus narrows ParticipantID to the events where the
+	// user's participant status is this one
+	ParticipantStatus string
+	// Title matches events whose title contains it
+	Title string
+	// SlotsFrom and SlotsTo limit results to events with a proposed slot
+	// overlapping [SlotsFrom, SlotsTo). Either may be zero.
+	SlotsFrom time.Time
+	SlotsTo   time.Time
+	// CreatedFrom and CreatedTo limit results to events created in
+	// [CreatedFrom, CreatedTo). Either may be zero.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Sort is one of the EventSort fields, prefixed with "-" for descending
+	Sort string
+	// Include names the relations to load with each event. Nil loads all of
+	// them; an empty slice loads none.
+	Include []string
+	// Cursor continues a listing after the last event of a previous page.
+	// With a cursor, Page is ignored and the total is not counted.
+	Cursor string
+	Page   int
+	Limit  int
+}
+
+// Roles a user can have in an event, for listing a user's events
+const (
+	UserEventRoleOrganizer   = "organizer"
+	UserEventRoleParticipant = "participant"
+)
+
+// EventPage is one page of an event listing.
+type EventPage struct {
+	Events []*Event
+	// Page and Limit are the page number and size that were applied. Page
+	// is 0 when following a cursor.
+	Page  int
+	Limit int
+	// Total counts all matching events. It is only computed for page-based
+	// listings, not when following a cursor.
+	Total int
+	// NextCursor continues the listing after this page, and is empty on the
+	// last page
+	NextCursor string
 }
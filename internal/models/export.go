@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// UserExport is a copy of the personal data held about a user: their
+// profile, the events they were invited to and the availability they gave
+// for them, and the events they organize.
+type UserExport struct {
+	ExportedAt      time.Time           `json:"exported_at"`
+	Profile         *User               `json:"profile"`
+	Participations  []UserParticipation `json:"participations"`
+	Availability    []AvailabilitySlot  `json:"availability"`
+	OrganizedEvents []*Event            `json:"organized_events"`
+}
+
+// UserParticipation is a user's invitation to an event.
+type UserParticipation struct {
+	EventID        string    `json:"event_id"`
+	EventTitle     string    `json:"event_title"`
+	Status         string    `json:"status"`
+	Role           string    `json:"role"`
+	ResponseReason string    `json:"response_reason,omitempty"`
+	GroupID        string    `json:"group_id,omitempty"`
+	InvitedAt      time.Time `json:"invited_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
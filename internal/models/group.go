@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Group is a named set of users, such as a team, that can be invited to an
+// event as a unit.
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name" validate:"required,max=255"`
+	// OwnerID is the user who created the group and manages its members.
+	OwnerID   string    `json:"owner_id"`
+	Members   []User    `json:"members,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateGroupRequest is the body of a group creation request.
+type CreateGroupRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// OwnerID defaults to the caller.
+	OwnerID   string   `json:"owner_id,omitempty"`
+	MemberIDs []string `json:"member_ids,omitempty" validate:"max=500,dive,required"`
+}
+
+// UpdateGroupRequest renames a group.
+type UpdateGroupRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// GroupMembersRequest adds users to a group.
+type GroupMembersRequest struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=500,dive,required"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// GuestInvite identifies someone invited to an event by email. When no user
+// has that email a guest identity is created for them.
+type GuestInvite struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name,omitempty" validate:"max=255"`
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// GuestInvitation is the result of inviting someone by email. Token and
+// RespondURL are only set for guests; registered users respond through the
+// regular participant endpoints.
+type GuestInvitation struct {
+	UserID     string     `json:"user_id"`
+	Email      string     `json:"email"`
+	IsGuest    bool       `json:"is_guest"`
+	Token      string     `json:"token,omitempty"`
+	RespondURL string     `json:"respond_url,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// GuestEventView is what a guest sees through their respond link: the event
+// details needed to answer, but not the other participants.
+type GuestEventView struct {
+	EventID         string             `json:"event_id"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description,omitempty"`
+	DurationMinutes int                `json:"duration_minutes"`
+	ProposedSlots   []ProposedSlot     `json:"proposed_slots"`
+	Status          string             `json:"status"`
+	ResponseReason  string             `json:"response_reason,omitempty"`
+	AvailableSlots  []AvailabilitySlot `json:"available_slots"`
+	ExpiresAt       time.Time          `json:"expires_at"`
+}
+
+// ConvertTimesTo renders the proposed and available slots in loc.
+func (v *GuestEventView) ConvertTimesTo(loc *time.Location) {
+	for i := range v.ProposedSlots {
+		v.ProposedSlots[i].ConvertTimesTo(loc)
+	}
+	for i := range v.AvailableSlots {
+		v.AvailableSlots[i].ConvertTimesTo(loc)
+	}
+}
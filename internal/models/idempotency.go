@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord remembers the outcome of a request made with an
+// Idempotency-Key so that retries return the original response.
+type IdempotencyRecord struct {
+	Scope string
+	Key   string
+	// UserID is the user whose data the response holds, if any.
+	UserID      string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time
+}
@@ -0,0 +1,33 @@
+package models
+
+// Outcomes of an import row. A dry run reports what an import would do.
+const (
+	ImportResultCreated  = "created"
+	ImportResultExisting = "existing"
+	ImportResultError    = "error"
+)
+
+// ImportRowResult reports what happened to one row. Existing users are
+// matched by email. Participant is set by participant imports and is one of
+// the participant batch outcomes: added, already_present or failed.
+type ImportRowResult struct {
+	Row         int    `json:"row"`
+	Email       string `json:"email,omitempty"`
+	Result      string `json:"result"`
+	UserID      string `json:"user_id,omitempty"`
+	Participant string `json:"participant,omitempty"`
+	Code        string `json:"code,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ImportResult is the outcome of a user or participant import. Rows fail
+// independently of each other. Version is the event's version after a
+// participant import.
+type ImportResult struct {
+	DryRun   bool              `json:"dry_run"`
+	Results  []ImportRowResult `json:"results"`
+	Created  int               `json:"created"`
+	Existing int               `json:"existing"`
+	Failed   int               `json:"failed"`
+	Version  int               `json:"version,omitempty"`
+}
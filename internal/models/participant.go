@@ -6,10 +6,18 @@ import (
 
 // EventParticipant represents a participant in an event
 type EventParticipant struct {
-	ID        uint      `json:"-"`
-	EventID   string    `json:"-"`
-	UserID    string    `json:"-"`
-	Status    string    `json:"status"`
+	ID      uint   `json:"-"`
+	EventID string `json:"-"`
+	UserID  string `json:"user_id"`
+	Status  string `json:"status"`
+	// Role is attendee or co_organizer. Co-organizers share the organizer's
+	// rights over the event.
+	Role string `json:"role"`
+	// ResponseReason is the optional note left when declining or replying
+	// tentatively.
+	ResponseReason string `json:"response_reason,omitempty"`
+	// GroupID is the group the participant was invited through, if any.
+	GroupID   string    `json:"group_id,omitempty"`
 	User      *User     `json:"user,omitempty"`
 	CreatedAt time.Time `json:"-"`
 	UpdatedAt time.Time `json:"-"`
@@ -19,4 +27,82 @@ type EventParticipant struct {
 const (
 	ParticipantStatusInvited   = "invited"
 	ParticipantStatusResponded = "responded"
+	ParticipantStatusTentative = "tentative"
+	ParticipantStatusDeclined  = "declined"
 )
+
+// ParticipantRole constants
+const (
+	ParticipantRoleAttendee    = "attendee"
+	ParticipantRoleCoOrganizer = "co_organizer"
+)
+
+// RoleRequest changes a participant's role.
+type RoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=attendee co_organizer"`
+}
+
+// RSVPRequest is a participant's reply to an invitation without availability.
+type RSVPRequest struct {
+	Status string `json:"status" validate:"required,oneof=declined tentative"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// Batch participant operations
+const (
+	ParticipantOpAdd     = "add"
+	ParticipantOpRemove  = "remove"
+	ParticipantOpSetRole = "set_role"
+)
+
+// Batch modes. An atomic batch is applied entirely or not at all; a partial
+// batch applies the operations that can be and reports the rest.
+const (
+	BatchModeAtomic  = "atomic"
+	BatchModePartial = "partial"
+)
+
+// ParticipantBatchRequest changes several participants of an event at once.
+type ParticipantBatchRequest struct {
+	// Mode is atomic (the default) or partial
+	Mode       string                 `json:"mode,omitempty" validate:"omitempty,oneof=atomic partial"`
+	Operations []ParticipantOperation `json:"operations" validate:"required,min=1,max=100,dive"`
+}
+
+// ParticipantOperation is one change in a participant batch. Role is the
+// role to set with set_role, and optionally the role to add with.
+type ParticipantOperation struct {
+	Op     string `json:"op" validate:"required,oneof=add remove set_role"`
+	UserID string `json:"user_id" validate:"required"`
+	Role   string `json:"role,omitempty" validate:"omitempty,oneof=attendee co_organizer"`
+}
+
+// Outcomes of a participant operation
+const (
+	ParticipantResultAdded          = "added"
+	ParticipantResultRemoved        = "removed"
+	ParticipantResultUpdated        = "updated"
+	ParticipantResultAlreadyPresent = "already_present"
+	ParticipantResultFailed         = "failed"
+)
+
+// ParticipantOperationResult reports the outcome of one operation. Code and
+// Error explain a failure.
+type ParticipantOperationResult struct {
+	Op     string `json:"op"`
+	UserID string `json:"user_id"`
+	Result string `json:"result"`
+	Code   string `json:"code,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ParticipantBatchResult reports the outcome of a participant batch, one
+// result per operation in request order.
+type ParticipantBatchResult struct {
+	Mode      string                       `json:"mode"`
+	Results   []ParticipantOperationResult `json:"results"`
+	Succeeded int                          `json:"succeeded"`
+	Failed    int                          `json:"failed"`
+	// Version is the event's version after the batch
+	Version int `json:"version"`
+}
@@ -22,9 +22,25 @@ type TimeSlot struct {
 
 // RecommendationResponse represents the API response for recommendations
 type RecommendationResponse struct {
-	EventID            string          `json:"event_id"`
-	DurationMinutes    int             `json:"duration_minutes"`
-	TotalParticipants  int             `json:"total_participants"`
-	BestRecommendation *Recommendation `json:"best_recommendation"`
-	Message            string          `json:"message"`
+	EventID           string `json:"event_id"`
+	DurationMinutes   int    `json:"duration_minutes"`
+	TotalParticipants int    `json:"total_participants"`
+	// DeclinedParticipants counts participants left out of TotalParticipants
+	// because they declined the invitation.
+	DeclinedParticipants int             `json:"declined_participants"`
+	DeclinedUsers        []string        `json:"declined_users"`
+	BestRecommendation   *Recommendation `json:"best_recommendation"`
+	Message              string          `json:"message"`
+}
+
+// ConvertTimesTo renders the recommended slot in loc and labels it with that
+// zone.
+func (r *RecommendationResponse) ConvertTimesTo(loc *time.Location) {
+	if r.BestRecommendation == nil {
+		return
+	}
+	slot := &r.BestRecommendation.Slot
+	slot.StartTime = slot.StartTime.In(loc)
+	slot.EndTime = slot.EndTime.In(loc)
+	slot.Timezone = loc.String()
 }
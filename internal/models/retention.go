@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// PurgeResult reports how many users and events a purge deleted for good.
+// Only those deleted at or before DeletedBefore are purged.
+type PurgeResult struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Users         int64     `json:"users"`
+	Events        int64     `json:"events"`
+}
@@ -1,19 +1,49 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
+
+	"meeting-slot-service/internal/utils"
 )
 
-// ProposedSlot represents a time slot proposed by the organizer
+// ProposedSlot represents a time slot proposed by the organizer. Its ID is
+// stable across updates of the event, so a slot can be edited on its own.
 type ProposedSlot struct {
-	ID        uint      `json:"-"`
+	ID        uint      `json:"id,omitempty"`
 	EventID   string    `json:"-"`
 	StartTime time.Time `json:"start_time" validate:"required"`
 	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
-	Timezone  string    `json:"timezone" validate:"required"`
+	Timezone  string    `json:"timezone" validate:"required,timezone"`
 	CreatedAt time.Time `json:"-"`
 }
 
+// UnmarshalJSON accepts start_time/end_time either as RFC 3339 timestamps or
+// as local wall-clock times (e.g. "2026-03-29T09:30") in the slot's timezone.
+func (s *ProposedSlot) UnmarshalJSON(data []byte) error {
+	type alias ProposedSlot
+	aux := struct {
+		*alias
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	s.StartTime, s.EndTime, err = parseSlotBounds(aux.StartTime, aux.EndTime, s.Timezone)
+	return err
+}
+
+// ConvertTimesTo renders the slot's start and end in loc. The slot's own
+// Timezone is left unchanged: it records the zone the slot was defined in.
+func (s *ProposedSlot) ConvertTimesTo(loc *time.Location) {
+	s.StartTime = s.StartTime.In(loc)
+	s.EndTime = s.EndTime.In(loc)
+}
+
 // AvailabilitySlot represents a participant's available time slot
 type AvailabilitySlot struct {
 	ID        uint      `json:"id"`
@@ -21,7 +51,61 @@ type AvailabilitySlot struct {
 	UserID    string    `json:"user_id"`
 	StartTime time.Time `json:"start_time" validate:"required"`
 	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
-	Timezone  string    `json:"timezone" validate:"required"`
+	Timezone  string    `json:"timezone" validate:"required,timezone"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// UnmarshalJSON accepts start_time/end_time either as RFC 3339 timestamps or
+// as local wall-clock times (e.g. "2026-03-29T09:30") in the slot's timezone.
+func (s *AvailabilitySlot) UnmarshalJSON(data []byte) error {
+	type alias AvailabilitySlot
+	aux := struct {
+		*alias
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	s.StartTime, s.EndTime, err = parseSlotBounds(aux.StartTime, aux.EndTime, s.Timezone)
+	return err
+}
+
+// ConvertTimesTo renders the slot's start and end in loc. The slot's own
+// Timezone is left unchanged: it records the zone the slot was defined in.
+func (s *AvailabilitySlot) ConvertTimesTo(loc *time.Location) {
+	s.StartTime = s.StartTime.In(loc)
+	s.EndTime = s.EndTime.In(loc)
+}
+
+// parseSlotBounds parses both ends of a slot. Missing values are left as the
+// zero time so that validation can report them.
+func parseSlotBounds(start, end, timezone string) (time.Time, time.Time, error) {
+	var startTime, endTime time.Time
+	var err error
+	if start != "" {
+		if startTime, err = utils.ParseSlotTime(start, timezone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start_time: %w", err)
+		}
+	}
+	if end != "" {
+		if endTime, err = utils.ParseSlotTime(end, timezone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_time: %w", err)
+		}
+	}
+	return startTime, endTime, nil
+}
+
+// ProposedSlotChange reports the outcome of adding, editing or removing a
+// single proposed slot.
+type ProposedSlotChange struct {
+	Slot *ProposedSlot `json:"slot,omitempty"`
+	// StrandedParticipantIDs lists the participants with availability that
+	// fell inside the proposed windows before the change but no longer does.
+	StrandedParticipantIDs []string `json:"stranded_participant_ids"`
+	// Version is the event's version after the change.
+	Version int `json:"version"`
+}
@@ -6,9 +6,13 @@ import (
 
 // User represents a user/participant in the system
 type User struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name" validate:"required"`
-	Email     string    `json:"email" validate:"required,email"`
+	ID       string `json:"id"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	// IsGuest marks a lightweight identity created when someone without an
+	// account is invited to an event by email.
+	IsGuest   bool      `json:"is_guest"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// apiKeyColumns is the column list scanned by scanAPIKey.
+const apiKeyColumns = `id, name, key_hash, user_id, is_admin, created_at, revoked_at`
+
+type apiKeyRepository struct {
+	db *database.Database
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *database.Database) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	key.CreatedAt = time.Now().UTC()
+
+	query := `INSERT INTO api_keys (id, name, key_hash, user_id, is_admin, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = db.ExecContext(ctx, query, key.ID, key.Name, key.KeyHash,
+		sql.NullString{String: key.UserID, Valid: key.UserID != ""}, key.IsAdmin, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// GetByHash returns the unrevoked key with the given hash, or nil when there
+// is none.
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = ? AND revoked_at IS NULL`
+	key, err := scanAPIKey(db.QueryRowContext(ctx, query, hash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = ? AND revoked_at IS NULL`
+	result, err := db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("API key not found")
+	}
+	return nil
+}
+
+// scanAPIKey scans a row selected with apiKeyColumns.
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var userID sql.NullString
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.ID, &key.Name, &key.KeyHash, &userID, &key.IsAdmin,
+		&key.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	key.UserID = userID.String
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAPIKeyRepoTest(t *testing.T) (*apiKeyRepository, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+
+	db := &database.Database{}
+	db.SetDB(mockDB)
+
+	repo := &apiKeyRepository{db: db}
+
+	cleanup := func() {
+		mockDB.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+var apiKeyRowColumns = []string{"id", "name", "key_hash", "user_id", "is_admin", "created_at", "revoked_at"}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		key := &models.APIKey{ID: "key_1", Name: "billing", KeyHash: "abc", IsAdmin: true}
+
+		mock.ExpectExec("INSERT INTO api_keys").
+			WithArgs("key_1", "billing", "abc", nil, true, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(context.Background(), key)
+		assert.NoError(t, err)
+		assert.False(t, key.CreatedAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("INSERT INTO api_keys").
+			WithArgs("key_1", "billing", "abc", "user-1", false, sqlmock.AnyArg()).
+			WillReturnError(errors.New("duplicate"))
+
+		err := repo.Create(context.Background(), &models.APIKey{ID: "key_1", Name: "billing", KeyHash: "abc", UserID: "user-1"})
+		assert.ErrorContains(t, err, "failed to create API key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAPIKeyRepository_GetByHash(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows(apiKeyRowColumns).
+			AddRow("key_1", "billing", "abc", "user-1", false, now, nil)
+
+		mock.ExpectQuery("SELECT .+ FROM api_keys WHERE key_hash = \\? AND revoked_at IS NULL").
+			WithArgs("abc").
+			WillReturnRows(rows)
+
+		key, err := repo.GetByHash(context.Background(), "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, "key_1", key.ID)
+		assert.Equal(t, "user-1", key.UserID)
+		assert.Nil(t, key.RevokedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM api_keys").
+			WithArgs("abc").
+			WillReturnError(sql.ErrNoRows)
+
+		key, err := repo.GetByHash(context.Background(), "abc")
+		assert.NoError(t, err)
+		assert.Nil(t, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM api_keys").
+			WithArgs("abc").
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.GetByHash(context.Background(), "abc")
+		assert.ErrorContains(t, err, "failed to get API key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAPIKeyRepository_List(t *testing.T) {
+	repo, mock, cleanup := setupAPIKeyRepoTest(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows(apiKeyRowColumns).
+		AddRow("key_2", "reporting", "def", nil, false, now, now).
+		AddRow("key_1", "billing", "abc", "user-1", true, now, nil)
+
+	mock.ExpectQuery("SELECT .+ FROM api_keys ORDER BY created_at DESC").
+		WillReturnRows(rows)
+
+	keys, err := repo.List(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.NotNil(t, keys[0].RevokedAt)
+	assert.True(t, keys[1].IsAdmin)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("UPDATE api_keys SET revoked_at = NOW\\(\\) WHERE id = \\? AND revoked_at IS NULL").
+			WithArgs("key_1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Revoke(context.Background(), "key_1")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		repo, mock, cleanup := setupAPIKeyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("UPDATE api_keys").
+			WithArgs("key_1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Revoke(context.Background(), "key_1")
+		assert.EqualError(t, err, "API key not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
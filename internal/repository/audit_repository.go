@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+)
+
+type auditRepository struct {
+	db *database.Database
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *database.Database) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const auditColumns = `id, actor, action, entity_type, entity_id, event_id, before_data, after_data, request_id, created_at`
+
+func (r *auditRepository) Record(ctx context.Context, entry *models.AuditEntry) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	entry.CreatedAt = time.Now().UTC()
+
+	query := `INSERT INTO audit_log (actor, action, entity_type, entity_id, event_id, before_data, after_data, request_id, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := db.ExecContext(ctx, query, entry.Actor, entry.Action, entry.EntityType, entry.EntityID,
+		sql.NullString{String: entry.EventID, Valid: entry.EventID != ""},
+		nullJSON(entry.Before), nullJSON(entry.After), entry.RequestID, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get audit entry ID: %w", err)
+	}
+	entry.ID = id
+	return nil
+}
+
+func (r *auditRepository) List(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	for _, cond := range []struct{ column, value string }{
+		{"actor", filter.Actor},
+		{"action", filter.Action},
+		{"entity_type", filter.EntityType},
+		{"entity_id", filter.EntityID},
+		{"event_id", filter.EventID},
+		{"request_id", filter.RequestID},
+	} {
+		if cond.value != "" {
+			where += " AND " + cond.column + " = ?"
+			args = append(args, cond.value)
+		}
+	}
+	if !filter.From.IsZero() {
+		where += " AND created_at >= ?"
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		where += " AND created_at < ?"
+		args = append(args, filter.To)
+	}
+	if filter.Cursor != "" {
+		id, err := decodeAuditCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		where += " AND id < ?"
+		args = append(args, id)
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	// Entries are appended in ID order, so the ID orders them by time too.
+	// One extra row tells whether there is a next page.
+	query := `SELECT ` + auditColumns + ` FROM audit_log` + where + ` ORDER BY id DESC LIMIT ?`
+	rows, err := db.QueryContext(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.AuditEntry, 0)
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		var eventID sql.NullString
+		var before, after []byte
+		if err := rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.EntityType, &entry.EntityID,
+			&eventID, &before, &after, &entry.RequestID, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entry.EventID = eventID.String
+		if len(before) > 0 {
+			entry.Before = json.RawMessage(before)
+		}
+		if len(after) > 0 {
+			entry.After = json.RawMessage(after)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	page := &models.AuditPage{Limit: limit}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		page.NextCursor = encodeAuditCursor(entries[limit-1].ID)
+	}
+	page.Entries = entries
+	return page, nil
+}
+
+func (r *auditRepository) RedactUser(ctx context.Context, userID string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `UPDATE audit_log SET before_data = NULL, after_data = NULL
+			  WHERE entity_id = ? AND entity_type IN (?, ?, ?)`
+	_, err = db.ExecContext(ctx, query, userID,
+		models.AuditEntityUser, models.AuditEntityParticipant, models.AuditEntityAvailability)
+	if err != nil {
+		return fmt.Errorf("failed to redact audit entries: %w", err)
+	}
+	return nil
+}
+
+// nullJSON stores an empty JSON document as NULL.
+func nullJSON(doc json.RawMessage) sql.NullString {
+	return sql.NullString{String: string(doc), Valid: len(doc) > 0}
+}
+
+// encodeAuditCursor returns the cursor that continues a listing after the
+// entry with id.
+func encodeAuditCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeAuditCursor(token string) (int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, invalidCursor()
+	}
+	id, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, invalidCursor()
+	}
+	return id, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAuditRepoTest(t *testing.T) (*auditRepository, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+
+	db := &database.Database{}
+	db.SetDB(mockDB)
+
+	return &auditRepository{db: db}, mock, func() { mockDB.Close() }
+}
+
+func TestAuditRepository_Record(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupAuditRepoTest(t)
+		defer cleanup()
+
+		entry := &models.AuditEntry{
+			Actor:      "admin",
+			Action:     models.AuditActionParticipantRemove,
+			EntityType: models.AuditEntityParticipant,
+			EntityID:   "user-1",
+			EventID:    "event-1",
+			Before:     json.RawMessage(`{"user_id":"user-1"}`),
+			RequestID:  "req-1",
+		}
+		mock.ExpectExec("INSERT INTO audit_log").
+			WithArgs("admin", "participant.remove", "participant", "user-1",
+				sql.NullString{String: "event-1", Valid: true},
+				sql.NullString{String: `{"user_id":"user-1"}`, Valid: true},
+				sql.NullString{},
+				"req-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		err := repo.Record(context.Background(), entry)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), entry.ID)
+		assert.NotZero(t, entry.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		repo, mock, cleanup := setupAuditRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("INSERT INTO audit_log").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.Record(context.Background(), &models.AuditEntry{Action: models.AuditActionUserErase})
+		assert.ErrorContains(t, err, "failed to record audit entry")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuditRepository_List(t *testing.T) {
+	columns := []string{"id", "actor", "action", "entity_type", "entity_id", "event_id", "before_data", "after_data", "request_id", "created_at"}
+	now := time.Now().UTC()
+
+	t.Run("Filters and next page", func(t *testing.T) {
+		repo, mock, cleanup := setupAuditRepoTest(t)
+		defer cleanup()
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows(columns).
+			AddRow(9, "org", "participant.remove", "participant", "p1", "e1", []byte(`{"user_id":"p1"}`), nil, "req-1", now).
+			AddRow(8, "org", "participant.add", "participant", "p2", "e1", nil, []byte(`{"user_id":"p2"}`), "", now).
+			AddRow(5, "org", "event.create", "event", "e1", "e1", nil, []byte(`{"id":"e1"}`), "", now)
+		mock.ExpectQuery("SELECT .+ FROM audit_log WHERE 1=1 AND actor = \\? AND event_id = \\? AND created_at >= \\? AND id < \\? ORDER BY id DESC LIMIT \\?").
+			WithArgs("org", "e1", from, int64(10), 3).
+			WillReturnRows(rows)
+
+		page, err := repo.List(context.Background(), models.AuditFilter{
+			Actor:   "org",
+			EventID: "e1",
+			From:    from,
+			Cursor:  encodeAuditCursor(10),
+			Limit:   2,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, page.Entries, 2)
+		assert.Equal(t, "e1", page.Entries[0].EventID)
+		assert.JSONEq(t, `{"user_id":"p1"}`, string(page.Entries[0].Before))
+		assert.Nil(t, page.Entries[0].After)
+		assert.Equal(t, encodeAuditCursor(8), page.NextCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		repo, mock, cleanup := setupAuditRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM audit_log WHERE 1=1 ORDER BY id DESC LIMIT \\?").
+			WithArgs(21).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "", "user.create", "user", "u1", nil, nil, nil, "", now))
+
+		page, err := repo.List(context.Background(), models.AuditFilter{})
+
+		require.NoError(t, err)
+		require.Len(t, page.Entries, 1)
+		assert.Empty(t, page.Entries[0].EventID)
+		assert.Empty(t, page.NextCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		repo, _, cleanup := setupAuditRepoTest(t)
+		defer cleanup()
+
+		_, err := repo.List(context.Background(), models.AuditFilter{Cursor: "not-a-cursor"})
+
+		assert.ErrorIs(t, err, utils.ErrValidation)
+	})
+}
+
+func TestAuditRepository_RedactUser(t *testing.T) {
+	repo, mock, cleanup := setupAuditRepoTest(t)
+	defer cleanup()
+
+	mock.ExpectExec("UPDATE audit_log SET before_data = NULL, after_data = NULL WHERE entity_id = \\? AND entity_type IN").
+		WithArgs("user-1", "user", "participant", "availability").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	err := repo.RedactUser(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
@@ -17,30 +18,8 @@ func NewAvailabilityRepository(db *database.Database) AvailabilityRepository {
 	return &availabilityRepository{db: db}
 }
 
-func (r *availabilityRepository) CreateSlots(ctx context.Context, slots []models.AvailabilitySlot) error {
-	if len(slots) == 0 {
-		return nil
-	}
-
-	db, err := r.db.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get database connection: %w", err)
-	}
-
-	query := `INSERT INTO availability_slots (event_id, user_id, start_time, end_time, timezone, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?, NOW(), NOW())`
-
-	for _, slot := range slots {
-		_, err := db.ExecContext(ctx, query, slot.EventID, slot.UserID, slot.StartTime, slot.EndTime, slot.Timezone)
-		if err != nil {
-			return fmt.Errorf("failed to create availability slot: %w", err)
-		}
-	}
-	return nil
-}
-
 func (r *availabilityRepository) GetByEventAndUser(ctx context.Context, eventID, userID string) ([]models.AvailabilitySlot, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -72,13 +51,15 @@ func (r *availabilityRepository) GetByEventAndUser(ctx context.Context, eventID,
 }
 
 func (r *availabilityRepository) GetByEvent(ctx context.Context, eventID string) ([]models.AvailabilitySlot, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
 	query := `SELECT id, event_id, user_id, start_time, end_time, timezone, created_at, updated_at 
-			  FROM availability_slots WHERE event_id = ? ORDER BY user_id ASC, start_time ASC`
+			  FROM availability_slots WHERE event_id = ?
+			  AND user_id NOT IN (SELECT id FROM users WHERE deleted_at IS NOT NULL)
+			  ORDER BY user_id ASC, start_time ASC`
 
 	rows, err := db.QueryContext(ctx, query, eventID)
 	if err != nil {
@@ -103,46 +84,50 @@ func (r *availabilityRepository) GetByEvent(ctx context.Context, eventID string)
 	return slots, nil
 }
 
-func (r *availabilityRepository) UpdateUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot) error {
-	db, err := r.db.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get database connection: %w", err)
-	}
-
-	// Use transaction to delete old slots and create new ones
-	tx, err := db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
+// SaveUserSlots replaces a participant's availability and sets their
+// participant status in a single transaction, so a failure part-way leaves
+// both untouched. Callers check that the participant exists first.
+func (r *availabilityRepository) SaveUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot, participantStatus string) error {
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		return saveUserSlots(ctx, tx, eventID, userID, slots, participantStatus)
+	})
+}
 
-	// Delete existing slots
+// saveUserSlots does the work of SaveUserSlots within tx.
+func saveUserSlots(ctx context.Context, tx DBTX, eventID, userID string, slots []models.AvailabilitySlot, participantStatus string) error {
 	deleteQuery := `DELETE FROM availability_slots WHERE event_id = ? AND user_id = ?`
 	if _, err := tx.ExecContext(ctx, deleteQuery, eventID, userID); err != nil {
 		return fmt.Errorf("failed to delete old slots: %w", err)
 	}
 
-	// Create new slots
 	if len(slots) > 0 {
-		insertQuery := `INSERT INTO availability_slots (event_id, user_id, start_time, end_time, timezone, created_at, updated_at) 
-					    VALUES (?, ?, ?, ?, ?, NOW(), NOW())`
+		// Insert all slots with a single multi-row statement
+		placeholders := make([]string, 0, len(slots))
+		args := make([]interface{}, 0, len(slots)*5)
 		for _, slot := range slots {
-			if _, err := tx.ExecContext(ctx, insertQuery, slot.EventID, slot.UserID,
-				slot.StartTime, slot.EndTime, slot.Timezone); err != nil {
-				return fmt.Errorf("failed to create new slot: %w", err)
-			}
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, NOW(), NOW())")
+			args = append(args, eventID, userID, slot.StartTime, slot.EndTime, slot.Timezone)
+		}
+		insertQuery := `INSERT INTO availability_slots (event_id, user_id, start_time, end_time, timezone, created_at, updated_at) 
+					    VALUES ` + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, insertQuery, args...); err != nil {
+			return fmt.Errorf("failed to create new slots: %w", err)
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	statusQuery := `UPDATE event_participants SET status = ?, response_reason = NULL, updated_at = NOW()
+					WHERE event_id = ? AND user_id = ?`
+	// A participant whose status does not change affects no rows, so rows
+	// affected cannot tell whether they exist
+	if _, err := tx.ExecContext(ctx, statusQuery, participantStatus, eventID, userID); err != nil {
+		return fmt.Errorf("failed to update participant status: %w", err)
 	}
 
 	return nil
 }
 
 func (r *availabilityRepository) DeleteUserSlots(ctx context.Context, eventID, userID string) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -154,3 +139,38 @@ func (r *availabilityRepository) DeleteUserSlots(ctx context.Context, eventID, u
 	}
 	return nil
 }
+
+func (r *availabilityRepository) GetByUser(ctx context.Context, userID string) ([]models.AvailabilitySlot, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT a.id, a.event_id, a.user_id, a.start_time, a.end_time, a.timezone, a.created_at, a.updated_at
+			  FROM availability_slots a
+			  JOIN events e ON e.id = a.event_id
+			  WHERE a.user_id = ? AND e.deleted_at IS NULL
+			  ORDER BY a.event_id ASC, a.start_time ASC`
+
+	rows, err := db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := make([]models.AvailabilitySlot, 0)
+	for rows.Next() {
+		var slot models.AvailabilitySlot
+		if err := rows.Scan(&slot.ID, &slot.EventID, &slot.UserID, &slot.StartTime,
+			&slot.EndTime, &slot.Timezone, &slot.CreatedAt, &slot.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan availability slot: %w", err)
+		}
+		slots = append(slots, slot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return slots, nil
+}
@@ -29,76 +29,6 @@ func setupAvailabilityRepoTest(t *testing.T) (*availabilityRepository, sqlmock.S
 	return repo, mock, cleanup
 }
 
-func TestAvailabilityRepository_CreateSlots(t *testing.T) {
-	t.Run("Success with multiple slots", func(t *testing.T) {
-		repo, mock, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		now := time.Now().UTC()
-		slots := []models.AvailabilitySlot{
-			{
-				EventID:   "event-1",
-				UserID:    "user-1",
-				StartTime: now,
-				EndTime:   now.Add(1 * time.Hour),
-				Timezone:  "UTC",
-			},
-			{
-				EventID:   "event-1",
-				UserID:    "user-1",
-				StartTime: now.Add(2 * time.Hour),
-				EndTime:   now.Add(3 * time.Hour),
-				Timezone:  "UTC",
-			},
-		}
-
-		for _, slot := range slots {
-			mock.ExpectExec("INSERT INTO availability_slots").
-				WithArgs(slot.EventID, slot.UserID, slot.StartTime, slot.EndTime, slot.Timezone).
-				WillReturnResult(sqlmock.NewResult(1, 1))
-		}
-
-		err := repo.CreateSlots(context.Background(), slots)
-		assert.NoError(t, err)
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Empty slots array", func(t *testing.T) {
-		repo, _, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		var slots []models.AvailabilitySlot
-
-		err := repo.CreateSlots(context.Background(), slots)
-		assert.NoError(t, err)
-	})
-
-	t.Run("Database error", func(t *testing.T) {
-		repo, mock, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		now := time.Now().UTC()
-		slots := []models.AvailabilitySlot{
-			{
-				EventID:   "event-1",
-				UserID:    "user-1",
-				StartTime: now,
-				EndTime:   now.Add(1 * time.Hour),
-				Timezone:  "UTC",
-			},
-		}
-
-		mock.ExpectExec("INSERT INTO availability_slots").
-			WithArgs(slots[0].EventID, slots[0].UserID, slots[0].StartTime, slots[0].EndTime, slots[0].Timezone).
-			WillReturnError(errors.New("database error"))
-
-		err := repo.CreateSlots(context.Background(), slots)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to create availability slot")
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-}
-
 func TestAvailabilityRepository_GetByEventAndUser(t *testing.T) {
 	t.Run("Success with multiple slots", func(t *testing.T) {
 		repo, mock, cleanup := setupAvailabilityRepoTest(t)
@@ -222,7 +152,7 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 			AddRow(2, eventID, "user-1", now.Add(2*time.Hour), now.Add(3*time.Hour), "UTC", now, now).
 			AddRow(3, eventID, "user-2", now, now.Add(1*time.Hour), "America/New_York", now, now)
 
-		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? ORDER BY user_id ASC, start_time ASC").
+		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? AND user_id NOT IN \\(SELECT id FROM users WHERE deleted_at IS NOT NULL\\) ORDER BY user_id ASC, start_time ASC").
 			WithArgs(eventID).
 			WillReturnRows(rows)
 
@@ -242,7 +172,7 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "start_time", "end_time", "timezone", "created_at", "updated_at"})
 
-		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? ORDER BY user_id ASC, start_time ASC").
+		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? AND user_id NOT IN \\(SELECT id FROM users WHERE deleted_at IS NOT NULL\\) ORDER BY user_id ASC, start_time ASC").
 			WithArgs(eventID).
 			WillReturnRows(rows)
 
@@ -258,7 +188,7 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 
 		eventID := "event-1"
 
-		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? ORDER BY user_id ASC, start_time ASC").
+		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? AND user_id NOT IN \\(SELECT id FROM users WHERE deleted_at IS NOT NULL\\) ORDER BY user_id ASC, start_time ASC").
 			WithArgs(eventID).
 			WillReturnError(errors.New("database error"))
 
@@ -279,7 +209,7 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "start_time", "end_time", "timezone", "created_at", "updated_at"}).
 			AddRow(1, eventID, "user-1", "invalid-time", now.Add(1*time.Hour), "UTC", now, now) // Invalid time type
 
-		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? ORDER BY user_id ASC, start_time ASC").
+		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? AND user_id NOT IN \\(SELECT id FROM users WHERE deleted_at IS NOT NULL\\) ORDER BY user_id ASC, start_time ASC").
 			WithArgs(eventID).
 			WillReturnRows(rows)
 
@@ -301,7 +231,7 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 			AddRow(1, eventID, "user-1", now, now.Add(1*time.Hour), "UTC", now, now).
 			RowError(0, errors.New("iteration error"))
 
-		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? ORDER BY user_id ASC, start_time ASC").
+		mock.ExpectQuery("SELECT .+ FROM availability_slots WHERE event_id = \\? AND user_id NOT IN \\(SELECT id FROM users WHERE deleted_at IS NOT NULL\\) ORDER BY user_id ASC, start_time ASC").
 			WithArgs(eventID).
 			WillReturnRows(rows)
 
@@ -313,155 +243,73 @@ func TestAvailabilityRepository_GetByEvent(t *testing.T) {
 	})
 }
 
-func TestAvailabilityRepository_UpdateUserSlots(t *testing.T) {
-	t.Run("Success with new slots", func(t *testing.T) {
+func TestAvailabilityRepository_SaveUserSlots(t *testing.T) {
+	t.Run("Success inserts all slots in one statement", func(t *testing.T) {
 		repo, mock, cleanup := setupAvailabilityRepoTest(t)
 		defer cleanup()
 
-		eventID := "event-1"
-		userID := "user-1"
 		now := time.Now().UTC()
 		slots := []models.AvailabilitySlot{
-			{
-				EventID:   eventID,
-				UserID:    userID,
-				StartTime: now,
-				EndTime:   now.Add(1 * time.Hour),
-				Timezone:  "UTC",
-			},
-			{
-				EventID:   eventID,
-				UserID:    userID,
-				StartTime: now.Add(2 * time.Hour),
-				EndTime:   now.Add(3 * time.Hour),
-				Timezone:  "UTC",
-			},
+			{StartTime: now, EndTime: now.Add(1 * time.Hour), Timezone: "UTC"},
+			{StartTime: now.Add(2 * time.Hour), EndTime: now.Add(3 * time.Hour), Timezone: "UTC"},
 		}
 
 		mock.ExpectBegin()
 		mock.ExpectExec("DELETE FROM availability_slots WHERE event_id = \\? AND user_id = \\?").
-			WithArgs(eventID, userID).
-			WillReturnResult(sqlmock.NewResult(0, 2))
-
-		for _, slot := range slots {
-			mock.ExpectExec("INSERT INTO availability_slots").
-				WithArgs(slot.EventID, slot.UserID, slot.StartTime, slot.EndTime, slot.Timezone).
-				WillReturnResult(sqlmock.NewResult(1, 1))
-		}
-
+			WithArgs("event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO availability_slots .+ VALUES \\(.+\\), \\(.+\\)").
+			WithArgs("event-1", "user-1", slots[0].StartTime, slots[0].EndTime, "UTC",
+				"event-1", "user-1", slots[1].StartTime, slots[1].EndTime, "UTC").
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectExec("UPDATE event_participants SET status = \\?").
+			WithArgs(models.ParticipantStatusResponded, "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectCommit()
 
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
+		err := repo.SaveUserSlots(context.Background(), "event-1", "user-1", slots, models.ParticipantStatusResponded)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("Success with empty slots (delete all)", func(t *testing.T) {
+	t.Run("Insert error rolls back", func(t *testing.T) {
 		repo, mock, cleanup := setupAvailabilityRepoTest(t)
 		defer cleanup()
 
-		eventID := "event-1"
-		userID := "user-1"
-		var slots []models.AvailabilitySlot
-
-		mock.ExpectBegin()
-		mock.ExpectExec("DELETE FROM availability_slots WHERE event_id = \\? AND user_id = \\?").
-			WithArgs(eventID, userID).
-			WillReturnResult(sqlmock.NewResult(0, 2))
-		mock.ExpectCommit()
-
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
-		assert.NoError(t, err)
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Error beginning transaction", func(t *testing.T) {
-		repo, mock, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		eventID := "event-1"
-		userID := "user-1"
-		var slots []models.AvailabilitySlot
-
-		mock.ExpectBegin().WillReturnError(errors.New("begin tx error"))
-
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to begin transaction")
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Error deleting old slots", func(t *testing.T) {
-		repo, mock, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		eventID := "event-1"
-		userID := "user-1"
-		var slots []models.AvailabilitySlot
-
-		mock.ExpectBegin()
-		mock.ExpectExec("DELETE FROM availability_slots WHERE event_id = \\? AND user_id = \\?").
-			WithArgs(eventID, userID).
-			WillReturnError(errors.New("delete error"))
-		mock.ExpectRollback()
-
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to delete old slots")
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Error creating new slot", func(t *testing.T) {
-		repo, mock, cleanup := setupAvailabilityRepoTest(t)
-		defer cleanup()
-
-		eventID := "event-1"
-		userID := "user-1"
 		now := time.Now().UTC()
 		slots := []models.AvailabilitySlot{
-			{
-				EventID:   eventID,
-				UserID:    userID,
-				StartTime: now,
-				EndTime:   now.Add(1 * time.Hour),
-				Timezone:  "UTC",
-			},
+			{StartTime: now, EndTime: now.Add(1 * time.Hour), Timezone: "UTC"},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec("DELETE FROM availability_slots WHERE event_id = \\? AND user_id = \\?").
-			WithArgs(eventID, userID).
-			WillReturnResult(sqlmock.NewResult(0, 0))
-
+		mock.ExpectExec("DELETE FROM availability_slots").
+			WithArgs("event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectExec("INSERT INTO availability_slots").
-			WithArgs(slots[0].EventID, slots[0].UserID, slots[0].StartTime, slots[0].EndTime, slots[0].Timezone).
 			WillReturnError(errors.New("insert error"))
-
 		mock.ExpectRollback()
 
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
+		err := repo.SaveUserSlots(context.Background(), "event-1", "user-1", slots, models.ParticipantStatusResponded)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to create new slot")
+		assert.Contains(t, err.Error(), "failed to create new slots")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("Error committing transaction", func(t *testing.T) {
+	t.Run("Unchanged status succeeds", func(t *testing.T) {
 		repo, mock, cleanup := setupAvailabilityRepoTest(t)
 		defer cleanup()
 
-		eventID := "event-1"
-		userID := "user-1"
-		var slots []models.AvailabilitySlot
-
 		mock.ExpectBegin()
-		mock.ExpectExec("DELETE FROM availability_slots WHERE event_id = \\? AND user_id = \\?").
-			WithArgs(eventID, userID).
+		mock.ExpectExec("DELETE FROM availability_slots").
+			WithArgs("event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE event_participants SET status = \\?").
+			WithArgs(models.ParticipantStatusResponded, "event-1", "user-1").
 			WillReturnResult(sqlmock.NewResult(0, 0))
-		mock.ExpectCommit().WillReturnError(errors.New("commit error"))
+		mock.ExpectCommit()
 
-		err := repo.UpdateUserSlots(context.Background(), eventID, userID, slots)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to commit transaction")
+		err := repo.SaveUserSlots(context.Background(), "event-1", "user-1", nil, models.ParticipantStatusResponded)
+		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -516,3 +364,23 @@ func TestAvailabilityRepository_DeleteUserSlots(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestAvailabilityRepository_GetByUser(t *testing.T) {
+	repo, mock, cleanup := setupAvailabilityRepoTest(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "start_time", "end_time", "timezone", "created_at", "updated_at"}).
+		AddRow(1, "event-1", "user-1", now, now.Add(time.Hour), "UTC", now, now).
+		AddRow(2, "event-2", "user-1", now, now.Add(time.Hour), "UTC", now, now)
+
+	mock.ExpectQuery("SELECT .+ FROM availability_slots a JOIN events e ON e.id = a.event_id WHERE a.user_id = \\? AND e.deleted_at IS NULL").
+		WithArgs("user-1").
+		WillReturnRows(rows)
+
+	slots, err := repo.GetByUser(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Len(t, slots, 2)
+	assert.Equal(t, "event-2", slots[1].EventID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// noDeadline stands in for a missing response deadline when sorting, so
+// events without one sort after every event with one.
+var noDeadline = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+// sortColumn is the expression an event sort field orders by.
+type sortColumn struct {
+	expr   string
+	isTime bool
+}
+
+// eventSortColumns maps the event sort fields to their columns.
+var eventSortColumns = map[string]sortColumn{
+	models.EventSortCreatedAt: {expr: "created_at", isTime: true},
+	models.EventSortUpdatedAt: {expr: "updated_at", isTime: true},
+	models.EventSortTitle:     {expr: "title"},
+	models.EventSortResponseDeadline: {
+		expr:   "COALESCE(response_deadline, '" + noDeadline.Format("2006-01-02 15:04:05") + "')",
+		isTime: true,
+	},
+}
+
+// eventSort is a parsed event sort order. Ties are broken by id in the same
+// direction, so every order is total and can be resumed from a cursor.
+type eventSort struct {
+	field string
+	desc  bool
+	sortColumn
+}
+
+// parseEventSort parses a sort such as "-updated_at". An empty sort is the
+// default order, newest first.
+func parseEventSort(sort string) (eventSort, error) {
+	if sort == "" {
+		sort = models.EventSortDefault
+	}
+	field := strings.TrimPrefix(sort, "-")
+	column, ok := eventSortColumns[field]
+	if !ok {
+		return eventSort{}, utils.InvalidField("sort", "invalid sort %q: must be %s, %s, %s or %s, optionally prefixed with -",
+			sort, models.EventSortCreatedAt, models.EventSortUpdatedAt, models.EventSortTitle, models.EventSortResponseDeadline)
+	}
+	return eventSort{field: field, desc: field != sort, sortColumn: column}, nil
+}
+
+func (s eventSort) String() string {
+	if s.desc {
+		return "-" + s.field
+	}
+	return s.field
+}
+
+// orderBy returns the ORDER BY clause for the sort.
+func (s eventSort) orderBy() string {
+	dir := " ASC"
+	if s.desc {
+		dir = " DESC"
+	}
+	return " ORDER BY " + s.expr + dir + ", id" + dir
+}
+
+// after returns the condition selecting the events that follow c in this
+// order, and its arguments.
+func (s eventSort) after(c eventCursor) (string, []interface{}, error) {
+	op := " > ?"
+	if s.desc {
+		op = " < ?"
+	}
+
+	var value interface{} = c.Value
+	if s.isTime {
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return "", nil, invalidCursor()
+		}
+		value = t
+	}
+	cond := " AND (" + s.expr + op + " OR (" + s.expr + " = ? AND id" + op + "))"
+	return cond, []interface{}{value, value, c.ID}, nil
+}
+
+// eventCursor is the position of an event in a listing. It is handed to
+// clients as an opaque token.
+type eventCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// cursorFor returns the cursor positioned at event.
+func (s eventSort) cursorFor(event *models.Event) string {
+	c := eventCursor{Sort: s.String(), ID: event.ID}
+	switch s.field {
+	case models.EventSortCreatedAt:
+		c.Value = event.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case models.EventSortUpdatedAt:
+		c.Value = event.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case models.EventSortTitle:
+		c.Value = event.Title
+	case models.EventSortResponseDeadline:
+		deadline := noDeadline
+		if event.ResponseDeadline != nil {
+			deadline = *event.ResponseDeadline
+		}
+		c.Value = deadline.UTC().Format(time.RFC3339Nano)
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor issued for the same sort.
+func (s eventSort) decodeCursor(token string) (eventCursor, error) {
+	var c eventCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || json.Unmarshal(data, &c) != nil || c.ID == "" {
+		return eventCursor{}, invalidCursor()
+	}
+	if c.Sort != s.String() {
+		return eventCursor{}, utils.InvalidField("cursor", "cursor was issued for sort %q, not %q", c.Sort, s.String())
+	}
+	return c, nil
+}
+
+func invalidCursor() error {
+	return utils.InvalidField("cursor", "invalid cursor")
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 )
 
+// eventColumns is the column list scanned by scanEvent.
+const eventColumns = `id, title, description, organizer_id, duration_minutes, status, version, 
+	created_at, updated_at, response_deadline`
+
 type eventRepository struct {
 	db *database.Database
 }
@@ -19,17 +25,19 @@ func NewEventRepository(db *database.Database) EventRepository {
 	return &eventRepository{db: db}
 }
 
+// Create inserts the event and its proposed slots in one transaction.
 func (r *eventRepository) Create(ctx context.Context, event *models.Event) error {
-	db, err := r.db.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get database connection: %w", err)
-	}
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		return r.create(ctx, tx, event)
+	})
+}
 
+func (r *eventRepository) create(ctx context.Context, db DBTX, event *models.Event) error {
 	now := time.Now()
-	query := `INSERT INTO events (id, title, description, organizer_id, duration_minutes, status, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = db.ExecContext(ctx, query, event.ID, event.Title, event.Description,
-		event.OrganizerID, event.DurationMinutes, event.Status, now, now)
+	query := `INSERT INTO events (id, title, description, organizer_id, duration_minutes, status, response_deadline, created_at, updated_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := db.ExecContext(ctx, query, event.ID, event.Title, event.Description,
+		event.OrganizerID, event.DurationMinutes, event.Status, event.ResponseDeadline, now, now)
 	if err != nil {
 		return fmt.Errorf("failed to create event: %w", err)
 	}
@@ -37,6 +45,7 @@ func (r *eventRepository) Create(ctx context.Context, event *models.Event) error
 	// Set timestamps on the event object
 	event.CreatedAt = now
 	event.UpdatedAt = now
+	event.Version = 1
 
 	// Insert proposed slots
 	if len(event.ProposedSlots) > 0 {
@@ -64,75 +73,257 @@ func (r *eventRepository) Create(ctx context.Context, event *models.Event) error
 }
 
 func (r *eventRepository) GetByID(ctx context.Context, id string) (*models.Event, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
-	var event models.Event
-	query := `SELECT id, title, description, organizer_id, duration_minutes, status, created_at, updated_at 
-			  FROM events WHERE id = ? AND deleted_at IS NULL`
-	err = db.QueryRowContext(ctx, query, id).Scan(
-		&event.ID, &event.Title, &event.Description, &event.OrganizerID,
-		&event.DurationMinutes, &event.Status, &event.CreatedAt, &event.UpdatedAt,
-	)
+	query := `SELECT ` + eventColumns + ` FROM events WHERE id = ? AND deleted_at IS NULL`
+	event, err := scanEvent(db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("event not found")
+			return nil, utils.NotFound("event not found")
 		}
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	if err := r.loadRelated(ctx, db, &event); err != nil {
+	if err := r.loadRelated(ctx, db, []*models.Event{event}, nil); err != nil {
 		return nil, err
 	}
 
+	return event, nil
+}
+
+// scanEvent scans a row selected with eventColumns.
+func scanEvent(row rowScanner) (*models.Event, error) {
+	var event models.Event
+	var deadline sql.NullTime
+	if err := row.Scan(&event.ID, &event.Title, &event.Description, &event.OrganizerID,
+		&event.DurationMinutes, &event.Status, &event.Version, &event.CreatedAt, &event.UpdatedAt,
+		&deadline); err != nil {
+		return nil, err
+	}
+	if deadline.Valid {
+		event.ResponseDeadline = &deadline.Time
+	}
 	return &event, nil
 }
 
+// Update changes the event and, when slots are given, replaces its proposed
+// slots, in one transaction. Slots that carry an ID are updated in place and
+// keep it; slots without one are added; the event's other slots are removed.
+// Callers lock the event with Touch first, which also proves it exists.
 func (r *eventRepository) Update(ctx context.Context, event *models.Event) error {
-	db, err := r.db.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get database connection: %w", err)
-	}
-	query := `UPDATE events SET title = ?, description = ?, duration_minutes = ?, status = ?, updated_at = NOW() 
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		return r.update(ctx, tx, event)
+	})
+}
+
+func (r *eventRepository) update(ctx context.Context, db DBTX, event *models.Event) error {
+	query := `UPDATE events SET title = ?, description = ?, duration_minutes = ?, status = ?, response_deadline = ?, updated_at = NOW() 
 			  WHERE id = ? AND deleted_at IS NULL`
-	result, err := db.ExecContext(ctx, query, event.Title, event.Description,
-		event.DurationMinutes, event.Status, event.ID)
+	// Rows affected counts only changed rows, so an update that changes
+	// nothing affects none and is not an error
+	_, err := db.ExecContext(ctx, query, event.Title, event.Description,
+		event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update event: %w", err)
 	}
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("event not found")
-	}
 
 	// Update proposed slots if provided
 	if len(event.ProposedSlots) > 0 {
-		// Delete existing proposed slots
-		_, err = db.ExecContext(ctx, `DELETE FROM proposed_slots WHERE event_id = ?`, event.ID)
+		// Delete the slots that are not kept
+		deleteQuery := `DELETE FROM proposed_slots WHERE event_id = ?`
+		args := []interface{}{event.ID}
+		var kept []string
+		for _, slot := range event.ProposedSlots {
+			if slot.ID != 0 {
+				kept = append(kept, "?")
+				args = append(args, slot.ID)
+			}
+		}
+		if len(kept) > 0 {
+			deleteQuery += " AND id NOT IN (" + strings.Join(kept, ", ") + ")"
+		}
+		_, err = db.ExecContext(ctx, deleteQuery, args...)
 		if err != nil {
 			return fmt.Errorf("failed to delete existing proposed slots: %w", err)
 		}
 
-		// Insert new proposed slots
-		slotQuery := `INSERT INTO proposed_slots (event_id, start_time, end_time, timezone, created_at) 
+		// Update kept slots and insert new ones
+		updateQuery := `UPDATE proposed_slots SET start_time = ?, end_time = ?, timezone = ? 
+						WHERE id = ? AND event_id = ?`
+		insertQuery := `INSERT INTO proposed_slots (event_id, start_time, end_time, timezone, created_at) 
 					  VALUES (?, ?, ?, ?, NOW())`
-		for _, slot := range event.ProposedSlots {
-			_, err = db.ExecContext(ctx, slotQuery, event.ID, slot.StartTime, slot.EndTime, slot.Timezone)
+		for i := range event.ProposedSlots {
+			slot := &event.ProposedSlots[i]
+			slot.EventID = event.ID
+			if slot.ID != 0 {
+				_, err = db.ExecContext(ctx, updateQuery, slot.StartTime, slot.EndTime, slot.Timezone, slot.ID, event.ID)
+				if err != nil {
+					return fmt.Errorf("failed to update proposed slot: %w", err)
+				}
+				continue
+			}
+
+			result, err := db.ExecContext(ctx, insertQuery, event.ID, slot.StartTime, slot.EndTime, slot.Timezone)
 			if err != nil {
 				return fmt.Errorf("failed to create proposed slot: %w", err)
 			}
+			slotID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get slot ID: %w", err)
+			}
+			slot.ID = uint(slotID)
 		}
 	}
 
 	return nil
 }
 
+// UpdateOrganizer hands an event over to another organizer.
+func (r *eventRepository) UpdateOrganizer(ctx context.Context, eventID, organizerID string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `UPDATE events SET organizer_id = ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+	result, err := db.ExecContext(ctx, query, organizerID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update organizer: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("event not found")
+	}
+	return nil
+}
+
+// AddProposedSlot inserts a proposed slot for slot.EventID and sets its ID.
+func (r *eventRepository) AddProposedSlot(ctx context.Context, slot *models.ProposedSlot) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	now := time.Now()
+	query := `INSERT INTO proposed_slots (event_id, start_time, end_time, timezone, created_at) 
+			  VALUES (?, ?, ?, ?, ?)`
+	result, err := db.ExecContext(ctx, query, slot.EventID, slot.StartTime, slot.EndTime, slot.Timezone, now)
+	if err != nil {
+		return fmt.Errorf("failed to create proposed slot: %w", err)
+	}
+	slotID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get slot ID: %w", err)
+	}
+	slot.ID = uint(slotID)
+	slot.CreatedAt = now
+	return nil
+}
+
+// UpdateProposedSlot changes the times of an existing proposed slot, keeping
+// its ID.
+func (r *eventRepository) UpdateProposedSlot(ctx context.Context, slot *models.ProposedSlot) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	// Rows affected is 0 when nothing changed, so it cannot tell a missing
+	// slot apart; callers look the slot up first
+	query := `UPDATE proposed_slots SET start_time = ?, end_time = ?, timezone = ? 
+			  WHERE id = ? AND event_id = ?`
+	_, err = db.ExecContext(ctx, query, slot.StartTime, slot.EndTime, slot.Timezone, slot.ID, slot.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to update proposed slot: %w", err)
+	}
+	return nil
+}
+
+// DeleteProposedSlot removes a proposed slot from an event.
+func (r *eventRepository) DeleteProposedSlot(ctx context.Context, eventID string, slotID uint) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `DELETE FROM proposed_slots WHERE id = ? AND event_id = ?`
+	result, err := db.ExecContext(ctx, query, slotID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete proposed slot: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("proposed slot not found")
+	}
+	return nil
+}
+
+// Touch bumps the event's version and returns the new one. When version is
+// not 0 the event must still be at that version, otherwise Touch fails with
+// ErrPreconditionFailed.
+func (r *eventRepository) Touch(ctx context.Context, id string, version int) (int, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	// LAST_INSERT_ID(expr) hands the new version back in the result
+	query := `UPDATE events SET version = LAST_INSERT_ID(version + 1), updated_at = NOW() 
+			  WHERE id = ? AND deleted_at IS NULL`
+	args := []interface{}{id}
+	if version != 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update event version: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		if version != 0 {
+			return 0, utils.PreconditionFailed("event is no longer at version %d", version)
+		}
+		return 0, utils.NotFound("event not found")
+	}
+	newVersion, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event version: %w", err)
+	}
+	return int(newVersion), nil
+}
+
+// GetDeleted returns an event deleted after deletedAfter, with its
+// participants.
+func (r *eventRepository) GetDeleted(ctx context.Context, id string, deletedAfter time.Time) (*models.Event, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `SELECT ` + eventColumns + ` FROM events WHERE id = ? AND deleted_at > ?`
+	event, err := scanEvent(db.QueryRowContext(ctx, query, id, deletedAfter))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NotFound("deleted event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := r.loadRelated(ctx, db, []*models.Event{event}, nil); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
 func (r *eventRepository) Delete(ctx context.Context, id string) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -147,132 +338,306 @@ func (r *eventRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rows == 0 {
-		return fmt.Errorf("event not found")
+		return utils.NotFound("event not found")
 	}
 	return nil
 }
 
-func (r *eventRepository) List(ctx context.Context, filter models.EventFilter) ([]*models.Event, int, error) {
-	db, err := r.db.DB()
+// Restore undoes the deletion of an event deleted after deletedAfter.
+func (r *eventRepository) Restore(ctx context.Context, id string, deletedAfter time.Time) error {
+	db, err := conn(ctx, r.db)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get database connection: %w", err)
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `UPDATE events SET deleted_at = NULL WHERE id = ? AND deleted_at > ?`
+	result, err := db.ExecContext(ctx, query, id, deletedAfter)
+	if err != nil {
+		return fmt.Errorf("failed to restore event: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("deleted event not found")
+	}
+	return nil
+}
+
+// GetIDsByDeletedOrganizer returns the events that are not deleted and whose
+// organizer was deleted at or before deletedBefore.
+func (r *eventRepository) GetIDsByDeletedOrganizer(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
-	// Build the query with filters
-	countQuery := `SELECT COUNT(*) FROM events WHERE deleted_at IS NULL`
-	query := `SELECT id, title, description, organizer_id, duration_minutes, status, created_at, updated_at 
-			  FROM events WHERE deleted_at IS NULL`
 
+	query := `SELECT e.id FROM events e
+			  JOIN users u ON u.id = e.organizer_id
+			  WHERE e.deleted_at IS NULL AND u.deleted_at <= ?
+			  ORDER BY e.id`
+	rows, err := db.QueryContext(ctx, query, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events of deleted organizers: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event id: %w", err)
+		}
+		eventIDs = append(eventIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return eventIDs, nil
+}
+
+// Purge hard-deletes the events deleted at or before deletedBefore, and the
+// events whose organizer was, with their slots, participants and
+// availability, and returns how many there were.
+func (r *eventRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	// events.organizer_id has no foreign key, so the events of purged
+	// organizers are not removed with them
+	query := `DELETE FROM events WHERE deleted_at <= ?
+			  OR organizer_id IN (SELECT id FROM users WHERE deleted_at <= ?)`
+	result, err := db.ExecContext(ctx, query, deletedBefore, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge events: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
+
+// List returns a page of events matching filter. Without a cursor the page
+// is found by offset and the matching events are counted; with one, listing
+// resumes after the cursor's event and nothing is counted.
+func (r *eventRepository) List(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	sort, err := parseEventSort(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the query with filters
+	where := " WHERE deleted_at IS NULL"
 	var args []interface{}
 
 	if filter.OrganizerID != "" {
-		countQuery += " AND organizer_id = ?"
-		query += " AND organizer_id = ?"
+		where += " AND organizer_id = ?"
 		args = append(args, filter.OrganizerID)
 	}
 	if filter.Status != "" {
-		countQuery += " AND status = ?"
-		query += " AND status = ?"
+		where += " AND status = ?"
 		args = append(args, filter.Status)
 	}
+	if filter.MemberID != "" {
+		where += " AND (organizer_id = ? OR id IN (SELECT event_id FROM event_participants WHERE user_id = ?))"
+		args = append(args, filter.MemberID, filter.MemberID)
+	}
+	if filter.ParticipantID != "" {
+		if filter.ParticipantStatus != "" {
+			where += " AND id IN (SELECT event_id FROM event_participants WHERE user_id = ? AND status = ?)"
+			args = append(args, filter.ParticipantID, filter.ParticipantStatus)
+		} else {
+			where += " AND id IN (SELECT event_id FROM event_participants WHERE user_id = ?)"
+			args = append(args, filter.ParticipantID)
+		}
+	}
+	if filter.Title != "" {
+		where += " AND title LIKE ?"
+		args = append(args, "%"+likeEscaper.Replace(filter.Title)+"%")
+	}
 
-	// Get total count
-	var total int
-	countArgs := make([]interface{}, len(args))
-	copy(countArgs, args)
-	if err := db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("failed to count events: %w", err)
+	// Events with a proposed slot overlapping the range
+	var slotConds []string
+	if !filter.SlotsFrom.IsZero() {
+		slotConds = append(slotConds, "end_time > ?")
+		args = append(args, filter.SlotsFrom)
+	}
+	if !filter.SlotsTo.IsZero() {
+		slotConds = append(slotConds, "start_time < ?")
+		args = append(args, filter.SlotsTo)
+	}
+	if len(slotConds) > 0 {
+		where += " AND id IN (SELECT event_id FROM proposed_slots WHERE " + strings.Join(slotConds, " AND ") + ")"
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where += " AND created_at >= ?"
+		args = append(args, filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		where += " AND created_at < ?"
+		args = append(args, filter.CreatedTo)
 	}
 
-	// Apply pagination
 	limit := filter.Limit
 	if limit == 0 {
 		limit = 20
 	}
-	offset := (filter.Page - 1) * limit
-	if offset < 0 {
-		offset = 0
-	}
 
-	query += " ORDER BY created_at DESC LIMIT ? OFFSET ?"
-	args = append(args, limit, offset)
+	page := &models.EventPage{}
+	query := `SELECT ` + eventColumns + ` FROM events` + where
+	var pageArgs []interface{}
+
+	if filter.Cursor != "" {
+		cursor, err := sort.decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cond, condArgs, err := sort.after(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query += cond + sort.orderBy() + " LIMIT ?"
+		pageArgs = append(append(pageArgs, condArgs...), limit+1)
+	} else {
+		// Get total count
+		countQuery := `SELECT COUNT(*) FROM events` + where
+		if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+			return nil, fmt.Errorf("failed to count events: %w", err)
+		}
+
+		offset := (filter.Page - 1) * limit
+		if offset < 0 {
+			offset = 0
+		}
+		query += sort.orderBy() + " LIMIT ? OFFSET ?"
+		pageArgs = append(pageArgs, limit+1, offset)
+	}
 
-	rows, err := db.QueryContext(ctx, query, args...)
+	// One extra row tells whether there is a next page
+	rows, err := db.QueryContext(ctx, query, append(args, pageArgs...)...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list events: %w", err)
+		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
 	defer rows.Close()
 
 	events := make([]*models.Event, 0)
 	for rows.Next() {
-		var event models.Event
-		if err := rows.Scan(&event.ID, &event.Title, &event.Description, &event.OrganizerID,
-			&event.DurationMinutes, &event.Status, &event.CreatedAt, &event.UpdatedAt); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan event: %w", err)
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
-		events = append(events, &event)
+		events = append(events, event)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Enrich each event with its proposed slots and participants
-	for _, event := range events {
-		if err := r.loadRelated(ctx, db, event); err != nil {
-			return nil, 0, err
-		}
+	if len(events) > limit {
+		events = events[:limit]
+		page.NextCursor = sort.cursorFor(events[limit-1])
+	}
+
+	// Enrich the page with the requested relations
+	if err := r.loadRelated(ctx, db, events, filter.Include); err != nil {
+		return nil, err
 	}
 
-	return events, total, nil
+	page.Events = events
+	return page, nil
 }
 
-// loadRelated fetches proposed slots and participants (with user info) for a
-// single event and attaches them to the event struct.
-func (r *eventRepository) loadRelated(ctx context.Context, db interface {
-	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
-}, event *models.Event) error {
-	// Proposed slots
-	slotsQuery := `SELECT id, event_id, start_time, end_time, timezone, created_at
-				   FROM proposed_slots WHERE event_id = ?`
-	sRows, err := db.QueryContext(ctx, slotsQuery, event.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get proposed slots: %w", err)
+// likeEscaper escapes the LIKE wildcards in a search term with MySQL's
+// default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// loadRelated fetches the proposed slots and participants (with user info)
+// of events in one query per relation and attaches them. include names the
+// relations to load; nil loads all of them.
+func (r *eventRepository) loadRelated(ctx context.Context, db DBTX, events []*models.Event, include []string) error {
+	if len(events) == 0 {
+		return nil
 	}
-	defer sRows.Close()
 
-	for sRows.Next() {
-		var slot models.ProposedSlot
-		if err := sRows.Scan(&slot.ID, &slot.EventID, &slot.StartTime, &slot.EndTime,
-			&slot.Timezone, &slot.CreatedAt); err != nil {
-			return fmt.Errorf("failed to scan proposed slot: %w", err)
+	byID := make(map[string]*models.Event, len(events))
+	placeholders := make([]string, 0, len(events))
+	args := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		byID[event.ID] = event
+		placeholders = append(placeholders, "?")
+		args = append(args, event.ID)
+	}
+	in := "(" + strings.Join(placeholders, ", ") + ")"
+
+	// Proposed slots
+	if includes(include, models.EventIncludeProposedSlots) {
+		slotsQuery := `SELECT id, event_id, start_time, end_time, timezone, created_at
+					   FROM proposed_slots WHERE event_id IN ` + in + ` ORDER BY id`
+		sRows, err := db.QueryContext(ctx, slotsQuery, args...)
+		if err != nil {
+			return fmt.Errorf("failed to get proposed slots: %w", err)
+		}
+		defer sRows.Close()
+
+		for sRows.Next() {
+			var slot models.ProposedSlot
+			if err := sRows.Scan(&slot.ID, &slot.EventID, &slot.StartTime, &slot.EndTime,
+				&slot.Timezone, &slot.CreatedAt); err != nil {
+				return fmt.Errorf("failed to scan proposed slot: %w", err)
+			}
+			if event := byID[slot.EventID]; event != nil {
+				event.ProposedSlots = append(event.ProposedSlots, slot)
+			}
+		}
+		if err := sRows.Err(); err != nil {
+			return fmt.Errorf("error iterating proposed slots: %w", err)
 		}
-		event.ProposedSlots = append(event.ProposedSlots, slot)
 	}
 
 	// Participants with user info
-	participantsQuery := `SELECT ep.id, ep.event_id, ep.user_id, ep.status,
-						  ep.created_at, ep.updated_at,
-						  u.id, u.name, u.email, u.created_at, u.updated_at
-						  FROM event_participants ep
-						  LEFT JOIN users u ON ep.user_id = u.id
-						  WHERE ep.event_id = ?`
-	pRows, err := db.QueryContext(ctx, participantsQuery, event.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
-	}
-	defer pRows.Close()
-
-	for pRows.Next() {
-		var p models.EventParticipant
-		var user models.User
-		if err := pRows.Scan(&p.ID, &p.EventID, &p.UserID, &p.Status,
-			&p.CreatedAt, &p.UpdatedAt,
-			&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
-			return fmt.Errorf("failed to scan participant: %w", err)
+	if includes(include, models.EventIncludeParticipants) {
+		participantsQuery := `SELECT ` + participantColumns + `
+							  FROM event_participants ep
+							  LEFT JOIN users u ON ep.user_id = u.id
+							  WHERE ep.event_id IN ` + in + ` AND u.deleted_at IS NULL ORDER BY ep.id`
+		pRows, err := db.QueryContext(ctx, participantsQuery, args...)
+		if err != nil {
+			return fmt.Errorf("failed to get participants: %w", err)
+		}
+		defer pRows.Close()
+
+		for pRows.Next() {
+			p, err := scanParticipant(pRows)
+			if err != nil {
+				return fmt.Errorf("failed to scan participant: %w", err)
+			}
+			if event := byID[p.EventID]; event != nil {
+				event.Participants = append(event.Participants, *p)
+			}
+		}
+		if err := pRows.Err(); err != nil {
+			return fmt.Errorf("error iterating participants: %w", err)
 		}
-		p.User = &user
-		event.Participants = append(event.Participants, p)
 	}
 
 	return nil
 }
+
+// includes reports whether relation is in include, where nil includes all.
+func includes(include []string, relation string) bool {
+	if include == nil {
+		return true
+	}
+	for _, name := range include {
+		if name == relation {
+			return true
+		}
+	}
+	return false
+}
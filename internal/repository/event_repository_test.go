@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -44,11 +47,14 @@ func TestEventRepository_Create(t *testing.T) {
 			Status:          "draft",
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO events").
 			WithArgs(event.ID, event.Title, event.Description, event.OrganizerID,
-				event.DurationMinutes, event.Status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				event.DurationMinutes, event.Status, event.ResponseDeadline, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		mock.ExpectCommit()
+
 		err := repo.Create(context.Background(), event)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -82,9 +88,10 @@ func TestEventRepository_Create(t *testing.T) {
 			},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO events").
 			WithArgs(event.ID, event.Title, event.Description, event.OrganizerID,
-				event.DurationMinutes, event.Status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				event.DurationMinutes, event.Status, event.ResponseDeadline, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		for _, slot := range event.ProposedSlots {
@@ -93,6 +100,8 @@ func TestEventRepository_Create(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(1, 1))
 		}
 
+		mock.ExpectCommit()
+
 		err := repo.Create(context.Background(), event)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -111,11 +120,14 @@ func TestEventRepository_Create(t *testing.T) {
 			Status:          "draft",
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO events").
 			WithArgs(event.ID, event.Title, event.Description, event.OrganizerID,
-				event.DurationMinutes, event.Status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				event.DurationMinutes, event.Status, event.ResponseDeadline, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(errors.New("database error"))
 
+		mock.ExpectRollback()
+
 		err := repo.Create(context.Background(), event)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create event")
@@ -144,15 +156,18 @@ func TestEventRepository_Create(t *testing.T) {
 			},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO events").
 			WithArgs(event.ID, event.Title, event.Description, event.OrganizerID,
-				event.DurationMinutes, event.Status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				event.DurationMinutes, event.Status, event.ResponseDeadline, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		mock.ExpectExec("INSERT INTO proposed_slots").
 			WithArgs(event.ID, event.ProposedSlots[0].StartTime, event.ProposedSlots[0].EndTime, event.ProposedSlots[0].Timezone, sqlmock.AnyArg()).
 			WillReturnError(errors.New("slot creation error"))
 
+		mock.ExpectRollback()
+
 		err := repo.Create(context.Background(), event)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create proposed slot")
@@ -169,8 +184,8 @@ func TestEventRepository_GetByID(t *testing.T) {
 		now := time.Now().UTC()
 
 		// Event rows
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", 1, now, now, nil)
 
 		// Proposed slots rows
 		slotRows := sqlmock.NewRows([]string{"id", "event_id", "start_time", "end_time", "timezone", "created_at"}).
@@ -178,19 +193,19 @@ func TestEventRepository_GetByID(t *testing.T) {
 			AddRow(2, eventID, now.Add(2*time.Hour), now.Add(3*time.Hour), "UTC", now)
 
 		// Participants rows
-		participantRows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "created_at", "updated_at", "id", "name", "email", "created_at", "updated_at"}).
-			AddRow(1, eventID, "user-2", "pending", now, now, "user-2", "John Doe", "john@example.com", now, now).
-			AddRow(2, eventID, "user-3", "accepted", now, now, "user-3", "Jane Smith", "jane@example.com", now, now)
+		participantRows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at", "id", "name", "email", "is_guest", "created_at", "updated_at"}).
+			AddRow(1, eventID, "user-2", "pending", "attendee", nil, nil, now, now, "user-2", "John Doe", "john@example.com", false, now, now).
+			AddRow(2, eventID, "user-3", "accepted", "attendee", nil, nil, now, now, "user-3", "Jane Smith", "jane@example.com", false, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM events WHERE id = (.+) AND deleted_at IS NULL").
 			WithArgs(eventID).
 			WillReturnRows(eventRows)
 
-		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id = \\?").
+		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id IN \\(\\?\\)").
 			WithArgs(eventID).
 			WillReturnRows(slotRows)
 
-		mock.ExpectQuery("SELECT .+ FROM event_participants (.+) WHERE ep.event_id = \\?").
+		mock.ExpectQuery("SELECT .+ FROM event_participants (.+) WHERE ep.event_id IN \\(\\?\\)").
 			WithArgs(eventID).
 			WillReturnRows(participantRows)
 
@@ -246,14 +261,14 @@ func TestEventRepository_GetByID(t *testing.T) {
 		eventID := "event-1"
 		now := time.Now().UTC()
 
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", 1, now, now, nil)
 
 		mock.ExpectQuery("SELECT .+ FROM events WHERE id = (.+) AND deleted_at IS NULL").
 			WithArgs(eventID).
 			WillReturnRows(eventRows)
 
-		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id = \\?").
+		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id IN \\(\\?\\)").
 			WithArgs(eventID).
 			WillReturnError(errors.New("slot fetch error"))
 
@@ -271,8 +286,8 @@ func TestEventRepository_GetByID(t *testing.T) {
 		eventID := "event-1"
 		now := time.Now().UTC()
 
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow(eventID, "Team Meeting", "Weekly sync", "user-1", 60, "draft", 1, now, now, nil)
 
 		slotRows := sqlmock.NewRows([]string{"id", "event_id", "start_time", "end_time", "timezone", "created_at"}).
 			AddRow(1, eventID, "invalid-time", now.Add(1*time.Hour), "UTC", now)
@@ -281,7 +296,7 @@ func TestEventRepository_GetByID(t *testing.T) {
 			WithArgs(eventID).
 			WillReturnRows(eventRows)
 
-		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id = \\?").
+		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id IN \\(\\?\\)").
 			WithArgs(eventID).
 			WillReturnRows(slotRows)
 
@@ -306,10 +321,13 @@ func TestEventRepository_Update(t *testing.T) {
 			Status:          "active",
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE events SET").
-			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ID).
+			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		mock.ExpectCommit()
+
 		err := repo.Update(context.Background(), event)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -336,8 +354,9 @@ func TestEventRepository_Update(t *testing.T) {
 			},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE events SET").
-			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ID).
+			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		mock.ExpectExec("DELETE FROM proposed_slots WHERE event_id = \\?").
@@ -348,30 +367,87 @@ func TestEventRepository_Update(t *testing.T) {
 			WithArgs(event.ID, event.ProposedSlots[0].StartTime, event.ProposedSlots[0].EndTime, event.ProposedSlots[0].Timezone).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		mock.ExpectCommit()
+
 		err := repo.Update(context.Background(), event)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("Event not found", func(t *testing.T) {
+	t.Run("Success keeping slot IDs", func(t *testing.T) {
 		repo, mock, cleanup := setupEventRepoTest(t)
 		defer cleanup()
 
+		now := time.Now().UTC()
 		event := &models.Event{
 			ID:              "event-1",
 			Title:           "Updated Meeting",
-			Description:     "Updated description",
 			DurationMinutes: 90,
 			Status:          "active",
+			ProposedSlots: []models.ProposedSlot{
+				{ID: 7, StartTime: now, EndTime: now.Add(time.Hour), Timezone: "UTC"},
+				{StartTime: now.Add(2 * time.Hour), EndTime: now.Add(3 * time.Hour), Timezone: "UTC"},
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE events SET").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec("DELETE FROM proposed_slots WHERE event_id = \\? AND id NOT IN \\(\\?\\)").
+			WithArgs(event.ID, uint(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		mock.ExpectExec("UPDATE proposed_slots SET start_time = \\?, end_time = \\?, timezone = \\? WHERE id = \\? AND event_id = \\?").
+			WithArgs(now, now.Add(time.Hour), "UTC", uint(7), event.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec("INSERT INTO proposed_slots").
+			WithArgs(event.ID, now.Add(2*time.Hour), now.Add(3*time.Hour), "UTC").
+			WillReturnResult(sqlmock.NewResult(9, 1))
+
+		mock.ExpectCommit()
+
+		err := repo.Update(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), event.ProposedSlots[0].ID)
+		assert.Equal(t, uint(9), event.ProposedSlots[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Only proposed slots changed", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		event := &models.Event{
+			ID:              "event-1",
+			Title:           "Meeting",
+			DurationMinutes: 60,
+			Status:          "active",
+			ProposedSlots: []models.ProposedSlot{
+				{StartTime: now, EndTime: now.Add(time.Hour), Timezone: "UTC"},
+			},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE events SET").
-			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ID).
+			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
+		mock.ExpectExec("DELETE FROM proposed_slots WHERE event_id = \\?").
+			WithArgs(event.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec("INSERT INTO proposed_slots").
+			WithArgs(event.ID, now, now.Add(time.Hour), "UTC").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		mock.ExpectCommit()
+
 		err := repo.Update(context.Background(), event)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "event not found")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), event.ProposedSlots[0].ID)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -387,10 +463,13 @@ func TestEventRepository_Update(t *testing.T) {
 			Status:          "active",
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE events SET").
-			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ID).
+			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID).
 			WillReturnError(errors.New("database error"))
 
+		mock.ExpectRollback()
+
 		err := repo.Update(context.Background(), event)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to update event")
@@ -418,14 +497,17 @@ func TestEventRepository_Update(t *testing.T) {
 			},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE events SET").
-			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ID).
+			WithArgs(event.Title, event.Description, event.DurationMinutes, event.Status, event.ResponseDeadline, event.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		mock.ExpectExec("DELETE FROM proposed_slots WHERE event_id = \\?").
 			WithArgs(event.ID).
 			WillReturnError(errors.New("delete error"))
 
+		mock.ExpectRollback()
+
 		err := repo.Update(context.Background(), event)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to delete existing proposed slots")
@@ -433,6 +515,155 @@ func TestEventRepository_Update(t *testing.T) {
 	})
 }
 
+func TestEventRepository_UpdateOrganizer(t *testing.T) {
+	const query = "UPDATE events SET organizer_id = \\?, updated_at = NOW\\(\\) WHERE id = \\? AND deleted_at IS NULL"
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("user-2", "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateOrganizer(context.Background(), "event-1", "user-2")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Event not found", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("user-2", "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateOrganizer(context.Background(), "event-1", "user-2")
+		assert.EqualError(t, err, "event not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEventRepository_ProposedSlots(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	t.Run("Add sets the slot ID", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("INSERT INTO proposed_slots").
+			WithArgs("event-1", start, end, "UTC", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+
+		slot := &models.ProposedSlot{EventID: "event-1", StartTime: start, EndTime: end, Timezone: "UTC"}
+		err := repo.AddProposedSlot(context.Background(), slot)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), slot.ID)
+		assert.False(t, slot.CreatedAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update keeps the slot ID", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("UPDATE proposed_slots SET start_time = \\?, end_time = \\?, timezone = \\?").
+			WithArgs(start, end, "UTC", uint(7), "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		slot := &models.ProposedSlot{ID: 7, EventID: "event-1", StartTime: start, EndTime: end, Timezone: "UTC"}
+		err := repo.UpdateProposedSlot(context.Background(), slot)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM proposed_slots WHERE id = \\? AND event_id = \\?").
+			WithArgs(uint(7), "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.DeleteProposedSlot(context.Background(), "event-1", 7)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delete slot not found", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM proposed_slots").
+			WithArgs(uint(7), "event-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteProposedSlot(context.Background(), "event-1", 7)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEventRepository_Touch(t *testing.T) {
+	query := "UPDATE events SET version = LAST_INSERT_ID\\(version \\+ 1\\), updated_at = NOW\\(\\) WHERE id = \\? AND deleted_at IS NULL"
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query + "$").
+			WithArgs("event-1").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		version, err := repo.Touch(context.Background(), "event-1", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success at expected version", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query+" AND version = \\?").
+			WithArgs("event-1", 3).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+
+		version, err := repo.Touch(context.Background(), "event-1", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query+" AND version = \\?").
+			WithArgs("event-1", 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := repo.Touch(context.Background(), "event-1", 2)
+		assert.ErrorIs(t, err, utils.ErrPreconditionFailed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Event not found", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("event-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := repo.Touch(context.Background(), "event-1", 0)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestEventRepository_Delete(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, mock, cleanup := setupEventRepoTest(t)
@@ -482,6 +713,139 @@ func TestEventRepository_Delete(t *testing.T) {
 	})
 }
 
+// expectLoadRelated expects the batched queries that load the proposed slots
+// and participants of a page of events, and returns none.
+func expectLoadRelated(mock sqlmock.Sqlmock, eventIDs ...string) {
+	placeholders := strings.TrimSuffix(strings.Repeat("\\?, ", len(eventIDs)), ", ")
+	args := make([]driver.Value, len(eventIDs))
+	for i, id := range eventIDs {
+		args[i] = id
+	}
+	mock.ExpectQuery("FROM proposed_slots WHERE event_id IN \\(" + placeholders + "\\) ORDER BY id").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "start_time", "end_time", "timezone", "created_at"}))
+	mock.ExpectQuery("FROM event_participants ep (.+) WHERE ep.event_id IN \\(" + placeholders + "\\) AND u.deleted_at IS NULL ORDER BY ep.id").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+}
+
+func TestEventRepository_GetDeleted(t *testing.T) {
+	t.Run("Deleted within retention", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		cutoff := now.Add(-30 * 24 * time.Hour)
+
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow("event-1", "Team Meeting", "Weekly sync", "user-1", 60, "pending", 3, now, now, nil)
+
+		mock.ExpectQuery("SELECT .+ FROM events WHERE id = \\? AND deleted_at > \\?").
+			WithArgs("event-1", cutoff).
+			WillReturnRows(eventRows)
+		mock.ExpectQuery("SELECT .+ FROM proposed_slots WHERE event_id IN \\(\\?\\)").
+			WithArgs("event-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "start_time", "end_time", "timezone", "created_at"}))
+		mock.ExpectQuery("SELECT .+ FROM event_participants (.+) WHERE ep.event_id IN \\(\\?\\)").
+			WithArgs("event-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at", "id", "name", "email", "is_guest", "created_at", "updated_at"}))
+
+		event, err := repo.GetDeleted(context.Background(), "event-1", cutoff)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, event.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not deleted or past retention", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectQuery("SELECT .+ FROM events WHERE id = \\? AND deleted_at > \\?").
+			WithArgs("event-1", cutoff).
+			WillReturnError(sql.ErrNoRows)
+
+		event, err := repo.GetDeleted(context.Background(), "event-1", cutoff)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.Nil(t, event)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEventRepository_Restore(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectExec("UPDATE events SET deleted_at = NULL WHERE id = \\? AND deleted_at > \\?").
+			WithArgs("event-1", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.Restore(context.Background(), "event-1", cutoff))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to restore", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectExec("UPDATE events SET deleted_at = NULL").
+			WithArgs("event-1", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Restore(context.Background(), "event-1", cutoff)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEventRepository_Purge(t *testing.T) {
+	repo, mock, cleanup := setupEventRepoTest(t)
+	defer cleanup()
+
+	cutoff := time.Now().UTC()
+	mock.ExpectExec("DELETE FROM events WHERE deleted_at <= \\?\\s+OR organizer_id IN \\(SELECT id FROM users WHERE deleted_at <= \\?\\)").
+		WithArgs(cutoff, cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := repo.Purge(context.Background(), cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventRepository_GetIDsByDeletedOrganizer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectQuery("SELECT e.id FROM events e\\s+JOIN users u ON u.id = e.organizer_id\\s+WHERE e.deleted_at IS NULL AND u.deleted_at <= \\?").
+			WithArgs(cutoff).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("event-1").AddRow("event-2"))
+
+		eventIDs, err := repo.GetIDsByDeletedOrganizer(context.Background(), cutoff)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"event-1", "event-2"}, eventIDs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectQuery("SELECT e.id FROM events e").
+			WithArgs(cutoff).
+			WillReturnError(errors.New("database error"))
+
+		eventIDs, err := repo.GetIDsByDeletedOrganizer(context.Background(), cutoff)
+		assert.Nil(t, eventIDs)
+		assert.Contains(t, err.Error(), "failed to get events of deleted organizers")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestEventRepository_List(t *testing.T) {
 	t.Run("Success with filters", func(t *testing.T) {
 		repo, mock, cleanup := setupEventRepoTest(t)
@@ -503,20 +867,21 @@ func TestEventRepository_List(t *testing.T) {
 			WillReturnRows(countRows)
 
 		// List query
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow("event-1", "Meeting 1", "Description 1", "user-1", 60, "active", now, now).
-			AddRow("event-2", "Meeting 2", "Description 2", "user-1", 90, "active", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow("event-1", "Meeting 1", "Description 1", "user-1", 60, "active", 1, now, now, nil).
+			AddRow("event-2", "Meeting 2", "Description 2", "user-1", 90, "active", 1, now, now, nil)
 
-		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL AND organizer_id = \\? AND status = \\? ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
-			WithArgs(filter.OrganizerID, filter.Status, filter.Limit, 0).
+		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL AND organizer_id = \\? AND status = \\? ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(filter.OrganizerID, filter.Status, filter.Limit+1, 0).
 			WillReturnRows(eventRows)
+		expectLoadRelated(mock, "event-1", "event-2")
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.NoError(t, err)
-		assert.Len(t, events, 2)
-		assert.Equal(t, 2, total)
-		assert.Equal(t, "event-1", events[0].ID)
-		assert.Equal(t, "event-2", events[1].ID)
+		assert.Len(t, page.Events, 2)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, "event-1", page.Events[0].ID)
+		assert.Equal(t, "event-2", page.Events[1].ID)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -537,17 +902,18 @@ func TestEventRepository_List(t *testing.T) {
 			WillReturnRows(countRows)
 
 		// List query
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow("event-1", "Meeting 1", "Description 1", "user-1", 60, "active", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow("event-1", "Meeting 1", "Description 1", "user-1", 60, "active", 1, now, now, nil)
 
-		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
-			WithArgs(20, 0).
+		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(21, 0).
 			WillReturnRows(eventRows)
+		expectLoadRelated(mock, "event-1")
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.NoError(t, err)
-		assert.Len(t, events, 1)
-		assert.Equal(t, 1, total)
+		assert.Len(t, page.Events, 1)
+		assert.Equal(t, 1, page.Total)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -566,16 +932,16 @@ func TestEventRepository_List(t *testing.T) {
 			WillReturnRows(countRows)
 
 		// List query
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"})
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"})
 
-		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
-			WithArgs(filter.Limit, 0).
+		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(filter.Limit+1, 0).
 			WillReturnRows(eventRows)
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.NoError(t, err)
-		assert.Empty(t, events)
-		assert.Equal(t, 0, total)
+		assert.Empty(t, page.Events)
+		assert.Equal(t, 0, page.Total)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -591,10 +957,9 @@ func TestEventRepository_List(t *testing.T) {
 		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events WHERE deleted_at IS NULL").
 			WillReturnError(errors.New("count error"))
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.Error(t, err)
-		assert.Nil(t, events)
-		assert.Equal(t, 0, total)
+		assert.Nil(t, page)
 		assert.Contains(t, err.Error(), "failed to count events")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -613,14 +978,13 @@ func TestEventRepository_List(t *testing.T) {
 		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events WHERE deleted_at IS NULL").
 			WillReturnRows(countRows)
 
-		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
-			WithArgs(filter.Limit, 0).
+		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(filter.Limit+1, 0).
 			WillReturnError(errors.New("list error"))
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.Error(t, err)
-		assert.Nil(t, events)
-		assert.Equal(t, 0, total)
+		assert.Nil(t, page)
 		assert.Contains(t, err.Error(), "failed to list events")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -642,18 +1006,236 @@ func TestEventRepository_List(t *testing.T) {
 			WillReturnRows(countRows)
 
 		// List query with invalid data
-		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "created_at", "updated_at"}).
-			AddRow("event-1", "Meeting 1", "Description 1", "user-1", "invalid-number", "active", now, now)
+		eventRows := sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}).
+			AddRow("event-1", "Meeting 1", "Description 1", "user-1", "invalid-number", "active", 1, now, now, nil)
 
-		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
-			WithArgs(filter.Limit, 0).
+		mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+			WithArgs(filter.Limit+1, 0).
 			WillReturnRows(eventRows)
 
-		events, total, err := repo.List(context.Background(), filter)
+		page, err := repo.List(context.Background(), filter)
 		assert.Error(t, err)
-		assert.Nil(t, events)
-		assert.Equal(t, 0, total)
+		assert.Nil(t, page)
 		assert.Contains(t, err.Error(), "failed to scan event")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestEventRepository_List_MemberFilter(t *testing.T) {
+	repo, mock, cleanup := setupEventRepoTest(t)
+	defer cleanup()
+
+	filter := models.EventFilter{MemberID: "user-1", Page: 1, Limit: 10}
+	memberCond := " AND \\(organizer_id = \\? OR id IN \\(SELECT event_id FROM event_participants WHERE user_id = \\?\\)\\)"
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events WHERE deleted_at IS NULL"+memberCond).
+		WithArgs("user-1", "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL"+memberCond+" ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs("user-1", "user-1", 11, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}))
+
+	page, err := repo.List(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Events)
+	assert.Equal(t, 0, page.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventRepository_List_Filters(t *testing.T) {
+	repo, mock, cleanup := setupEventRepoTest(t)
+	defer cleanup()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	filter := models.EventFilter{
+		ParticipantID: "user-2",
+		Title:         "50%_off",
+		SlotsFrom:     from,
+		SlotsTo:       to,
+		CreatedFrom:   from,
+		Sort:          "title",
+		Page:          2,
+		Limit:         10,
+	}
+	where := "WHERE deleted_at IS NULL" +
+		" AND id IN \\(SELECT event_id FROM event_participants WHERE user_id = \\?\\)" +
+		" AND title LIKE \\?" +
+		" AND id IN \\(SELECT event_id FROM proposed_slots WHERE end_time > \\? AND start_time < \\?\\)" +
+		" AND created_at >= \\?"
+	args := []driver.Value{"user-2", "%50\\%\\_off%", from, to, from}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events " + where + "$").
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT .+ FROM events " + where + " ORDER BY title ASC, id ASC LIMIT \\? OFFSET \\?").
+		WithArgs(append(args, 11, 10)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}))
+
+	page, err := repo.List(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Events)
+	assert.Empty(t, page.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventRepository_List_Cursor(t *testing.T) {
+	columns := []string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	repo, mock, cleanup := setupEventRepoTest(t)
+	defer cleanup()
+
+	// The first page is counted and hands out a cursor for the next one
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events WHERE deleted_at IS NULL$").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs(3, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("event-3", "C", "", "user-1", 30, "pending", 1, created.Add(2*time.Hour), created, nil).
+			AddRow("event-2", "B", "", "user-1", 30, "pending", 1, created.Add(time.Hour), created, nil).
+			AddRow("event-1", "A", "", "user-1", 30, "pending", 1, created, created, nil))
+	expectLoadRelated(mock, "event-3", "event-2")
+
+	first, err := repo.List(context.Background(), models.EventFilter{Page: 1, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, first.Events, 2)
+	assert.Equal(t, 3, first.Total)
+	assert.NotEmpty(t, first.NextCursor)
+
+	// Following the cursor resumes after event-2 without counting
+	mock.ExpectQuery("SELECT .+ FROM events WHERE deleted_at IS NULL"+
+		" AND \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\?$").
+		WithArgs(created.Add(time.Hour), created.Add(time.Hour), "event-2", 3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("event-1", "A", "", "user-1", 30, "pending", 1, created, created, nil))
+	expectLoadRelated(mock, "event-1")
+
+	next, err := repo.List(context.Background(), models.EventFilter{Cursor: first.NextCursor, Limit: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, next.Events, 1) {
+		assert.Equal(t, "event-1", next.Events[0].ID)
+	}
+	assert.Empty(t, next.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	t.Run("Cursor for another sort", func(t *testing.T) {
+		_, err := repo.List(context.Background(), models.EventFilter{Cursor: first.NextCursor, Sort: "title", Limit: 2})
+		assert.ErrorIs(t, err, utils.ErrValidation)
+	})
+
+	t.Run("Malformed cursor", func(t *testing.T) {
+		_, err := repo.List(context.Background(), models.EventFilter{Cursor: "not a cursor", Limit: 2})
+		assert.ErrorIs(t, err, utils.ErrValidation)
+	})
+
+	t.Run("Unknown sort", func(t *testing.T) {
+		_, err := repo.List(context.Background(), models.EventFilter{Sort: "-organizer_id", Limit: 2})
+		assert.ErrorIs(t, err, utils.ErrValidation)
+	})
+}
+
+func TestEventRepository_List_ResponseDeadline(t *testing.T) {
+	columns := []string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	deadline := created.AddDate(0, 0, 3)
+	order := " ORDER BY COALESCE\\(response_deadline, '9999-12-31 23:59:59'\\) ASC, id ASC"
+	where := "WHERE deleted_at IS NULL AND status = \\?" +
+		" AND id IN \\(SELECT event_id FROM event_participants WHERE user_id = \\? AND status = \\?\\)"
+	filter := models.EventFilter{
+		Status:            models.EventStatusPending,
+		ParticipantID:     "user-2",
+		ParticipantStatus: models.ParticipantStatusInvited,
+		Sort:              models.EventSortResponseDeadline,
+		Limit:             1,
+	}
+
+	repo, mock, cleanup := setupEventRepoTest(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events "+where+"$").
+		WithArgs("pending", "user-2", "invited").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT .+ FROM events "+where+order+" LIMIT \\? OFFSET \\?").
+		WithArgs("pending", "user-2", "invited", 2, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("event-2", "B", "", "user-1", 30, "pending", 1, created, created, deadline).
+			AddRow("event-1", "A", "", "user-1", 30, "pending", 1, created, created, nil))
+	expectLoadRelated(mock, "event-2")
+
+	first, err := repo.List(context.Background(), filter)
+	assert.NoError(t, err)
+	if assert.Len(t, first.Events, 1) && assert.NotNil(t, first.Events[0].ResponseDeadline) {
+		assert.True(t, deadline.Equal(*first.Events[0].ResponseDeadline))
+	}
+
+	// Events without a deadline come last, after the sentinel
+	mock.ExpectQuery("SELECT .+ FROM events "+where+
+		" AND \\(COALESCE.+ > \\? OR \\(COALESCE.+ = \\? AND id > \\?\\)\\)"+order+" LIMIT \\?$").
+		WithArgs("pending", "user-2", "invited", deadline, deadline, "event-2", 2).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("event-1", "A", "", "user-1", 30, "pending", 1, created, created, nil))
+	expectLoadRelated(mock, "event-1")
+
+	filter.Cursor = first.NextCursor
+	next, err := repo.List(context.Background(), filter)
+	assert.NoError(t, err)
+	if assert.Len(t, next.Events, 1) {
+		assert.Nil(t, next.Events[0].ResponseDeadline)
+	}
+	assert.Empty(t, next.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventRepository_List_Include(t *testing.T) {
+	columns := []string{"id", "title", "description", "organizer_id", "duration_minutes", "status", "version", "created_at", "updated_at", "response_deadline"}
+	now := time.Now().UTC()
+	listQuery := "SELECT .+ FROM events WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?"
+	expectPage := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM events").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(listQuery).
+			WithArgs(11, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("event-1", "A", "", "user-1", 30, "pending", 1, now, now, nil).
+				AddRow("event-2", "B", "", "user-1", 30, "pending", 1, now, now, nil))
+	}
+
+	t.Run("Slots for the whole page in one query", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		expectPage(mock)
+		mock.ExpectQuery("FROM proposed_slots WHERE event_id IN \\(\\?, \\?\\)").
+			WithArgs("event-1", "event-2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "start_time", "end_time", "timezone", "created_at"}).
+				AddRow(1, "event-2", now, now.Add(time.Hour), "UTC", now).
+				AddRow(2, "event-1", now, now.Add(time.Hour), "UTC", now).
+				AddRow(3, "event-2", now, now.Add(time.Hour), "UTC", now))
+
+		filter := models.EventFilter{Page: 1, Limit: 10, Include: []string{models.EventIncludeProposedSlots}}
+		page, err := repo.List(context.Background(), filter)
+		assert.NoError(t, err)
+		if assert.Len(t, page.Events, 2) {
+			assert.Len(t, page.Events[0].ProposedSlots, 1)
+			assert.Len(t, page.Events[1].ProposedSlots, 2)
+			assert.Equal(t, uint(3), page.Events[1].ProposedSlots[1].ID)
+			assert.Empty(t, page.Events[0].Participants)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No relations", func(t *testing.T) {
+		repo, mock, cleanup := setupEventRepoTest(t)
+		defer cleanup()
+
+		expectPage(mock)
+
+		filter := models.EventFilter{Page: 1, Limit: 10, Include: []string{}}
+		page, err := repo.List(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
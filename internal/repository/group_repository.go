@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// groupColumns is the column list scanned by scanGroup.
+const groupColumns = `id, name, owner_id, created_at, updated_at`
+
+type groupRepository struct {
+	db *database.Database
+}
+
+// NewGroupRepository creates a new group repository
+func NewGroupRepository(db *database.Database) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+func (r *groupRepository) Create(ctx context.Context, group *models.Group) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	query := `INSERT INTO user_groups (id, name, owner_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`
+	if _, err := db.ExecContext(ctx, query, group.ID, group.Name, group.OwnerID, group.CreatedAt, group.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create group: %w", err)
+	}
+	return nil
+}
+
+func (r *groupRepository) GetByID(ctx context.Context, id string) (*models.Group, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT ` + groupColumns + ` FROM user_groups WHERE id = ?`
+	group, err := scanGroup(db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NotFound("group not found")
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	if group.Members, err = r.members(ctx, db, id); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// List returns the groups userID owns or belongs to, or every group when
+// userID is empty. Members are not loaded.
+func (r *groupRepository) List(ctx context.Context, userID string) ([]*models.Group, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT ` + groupColumns + ` FROM user_groups`
+	var args []interface{}
+	if userID != "" {
+		query += ` WHERE owner_id = ? OR id IN (SELECT group_id FROM group_members WHERE user_id = ?)`
+		args = append(args, userID, userID)
+	}
+	query += ` ORDER BY name, id`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make([]*models.Group, 0)
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return groups, nil
+}
+
+func (r *groupRepository) Update(ctx context.Context, group *models.Group) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `UPDATE user_groups SET name = ?, updated_at = NOW() WHERE id = ?`
+	result, err := db.ExecContext(ctx, query, group.Name, group.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("group not found")
+	}
+	return nil
+}
+
+// Delete deletes a group and its memberships. Participants invited through
+// the group stay on their events.
+func (r *groupRepository) Delete(ctx context.Context, id string) error {
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE event_participants SET group_id = NULL WHERE group_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to detach group participants: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM user_groups WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete group: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return utils.NotFound("group not found")
+		}
+		return nil
+	})
+}
+
+// AddMembers adds users to a group in one insert. Users who are already
+// members are left as they are.
+func (r *groupRepository) AddMembers(ctx context.Context, groupID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	rows := make([]string, 0, len(userIDs))
+	args := make([]interface{}, 0, 2*len(userIDs))
+	for _, userID := range userIDs {
+		rows = append(rows, "(?, ?, NOW())")
+		args = append(args, groupID, userID)
+	}
+
+	query := `INSERT IGNORE INTO group_members (group_id, user_id, created_at) VALUES ` + strings.Join(rows, ", ")
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+	return nil
+}
+
+func (r *groupRepository) RemoveMember(ctx context.Context, groupID, userID string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `DELETE FROM group_members WHERE group_id = ? AND user_id = ?`, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("group member not found")
+	}
+	return nil
+}
+
+// members returns the users in a group, ordered by name.
+func (r *groupRepository) members(ctx context.Context, db DBTX, groupID string) ([]models.User, error) {
+	query := `SELECT u.id, u.name, u.email, u.timezone, u.is_guest, u.created_at, u.updated_at
+			  FROM group_members gm
+			  JOIN users u ON u.id = gm.user_id
+			  WHERE gm.group_id = ? AND u.deleted_at IS NULL
+			  ORDER BY u.name, u.id`
+	rows, err := db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]models.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		members = append(members, *user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return members, nil
+}
+
+// scanGroup scans a row selected with groupColumns.
+func scanGroup(row rowScanner) (*models.Group, error) {
+	var group models.Group
+	if err := row.Scan(&group.ID, &group.Name, &group.OwnerID, &group.CreatedAt, &group.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
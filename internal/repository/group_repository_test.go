@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGroupRepoTest(t *testing.T) (*groupRepository, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+
+	db := &database.Database{}
+	db.SetDB(mockDB)
+
+	repo := &groupRepository{db: db}
+
+	cleanup := func() {
+		mockDB.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+var groupRowColumns = []string{"id", "name", "owner_id", "created_at", "updated_at"}
+
+func TestGroupRepository_Create(t *testing.T) {
+	repo, mock, cleanup := setupGroupRepoTest(t)
+	defer cleanup()
+
+	group := &models.Group{ID: "grp_1", Name: "Platform team", OwnerID: "user-1"}
+	mock.ExpectExec("INSERT INTO user_groups").
+		WithArgs("grp_1", "Platform team", "user-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.Create(context.Background(), group)
+	assert.NoError(t, err)
+	assert.False(t, group.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGroupRepository_GetByID(t *testing.T) {
+	t.Run("Success with members", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		mock.ExpectQuery("SELECT .+ FROM user_groups WHERE id = \\?").
+			WithArgs("grp_1").
+			WillReturnRows(sqlmock.NewRows(groupRowColumns).AddRow("grp_1", "Platform team", "user-1", now, now))
+		mock.ExpectQuery("SELECT .+ FROM group_members gm JOIN users u (.+) WHERE gm.group_id = \\?").
+			WithArgs("grp_1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+				AddRow("user-2", "Jane Smith", "jane@example.com", "UTC", false, now, now))
+
+		group, err := repo.GetByID(context.Background(), "grp_1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Platform team", group.Name)
+		assert.Equal(t, "user-1", group.OwnerID)
+		assert.Len(t, group.Members, 1)
+		assert.Equal(t, "user-2", group.Members[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM user_groups WHERE id = \\?").
+			WithArgs("grp_1").
+			WillReturnRows(sqlmock.NewRows(groupRowColumns))
+
+		_, err := repo.GetByID(context.Background(), "grp_1")
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_List(t *testing.T) {
+	t.Run("Groups of a user", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		mock.ExpectQuery("SELECT .+ FROM user_groups WHERE owner_id = \\? OR id IN \\(SELECT group_id FROM group_members WHERE user_id = \\?\\) ORDER BY name, id").
+			WithArgs("user-1", "user-1").
+			WillReturnRows(sqlmock.NewRows(groupRowColumns).AddRow("grp_1", "Platform team", "user-1", now, now))
+
+		groups, err := repo.List(context.Background(), "user-1")
+		assert.NoError(t, err)
+		assert.Len(t, groups, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("All groups", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM user_groups ORDER BY name, id").
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows(groupRowColumns))
+
+		groups, err := repo.List(context.Background(), "")
+		assert.NoError(t, err)
+		assert.Empty(t, groups)
+		assert.NotNil(t, groups)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_Update(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("UPDATE user_groups SET name = \\?").
+			WithArgs("Core team", "grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Update(context.Background(), &models.Group{ID: "grp_1", Name: "Core team"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("UPDATE user_groups SET name = \\?").
+			WithArgs("Core team", "grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Update(context.Background(), &models.Group{ID: "grp_1", Name: "Core team"})
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_Delete(t *testing.T) {
+	t.Run("Detaches participants and deletes", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE event_participants SET group_id = NULL WHERE group_id = \\?").
+			WithArgs("grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("DELETE FROM user_groups WHERE id = \\?").
+			WithArgs("grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Delete(context.Background(), "grp_1")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found rolls back", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE event_participants SET group_id = NULL WHERE group_id = \\?").
+			WithArgs("grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM user_groups WHERE id = \\?").
+			WithArgs("grp_1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Delete(context.Background(), "grp_1")
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_AddMembers(t *testing.T) {
+	t.Run("One insert for all", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("INSERT IGNORE INTO group_members .+ VALUES \\(\\?, \\?, NOW\\(\\)\\), \\(\\?, \\?, NOW\\(\\)\\)$").
+			WithArgs("grp_1", "user-1", "grp_1", "user-2").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.AddMembers(context.Background(), "grp_1", []string{"user-1", "user-2"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to add", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		err := repo.AddMembers(context.Background(), "grp_1", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupRepository_RemoveMember(t *testing.T) {
+	query := "DELETE FROM group_members WHERE group_id = \\? AND user_id = \\?"
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("grp_1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.RemoveMember(context.Background(), "grp_1", "user-1")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("grp_1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.RemoveMember(context.Background(), "grp_1", "user-1")
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupGroupRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("grp_1", "user-1").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.RemoveMember(context.Background(), "grp_1", "user-1")
+		assert.ErrorContains(t, err, "failed to remove group member")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// IdempotencyKeyTTL is how long a stored idempotency key is honoured. A retry
+// arriving later is treated as a new request.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+type idempotencyRepository struct {
+	db *database.Database
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *database.Database) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Get returns the record stored for scope and key, or nil when there is none
+// or it has expired.
+func (r *idempotencyRepository) Get(ctx context.Context, scope, key string) (*models.IdempotencyRecord, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT scope, idempotency_key, request_hash, response, created_at
+			  FROM idempotency_keys WHERE scope = ? AND idempotency_key = ? AND created_at > ?`
+
+	var record models.IdempotencyRecord
+	err = db.QueryRowContext(ctx, query, scope, key, time.Now().UTC().Add(-IdempotencyKeyTTL)).Scan(
+		&record.Scope, &record.Key, &record.RequestHash, &record.Response, &record.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &record, nil
+}
+
+// Save stores a record, replacing an expired one for the same scope and key.
+// If a live record already exists (a concurrent retry got there first) it is
+// kept and a conflict is returned. Call it in the transaction that makes the
+// change, so the record and the change are kept or dropped together.
+func (r *idempotencyRepository) Save(ctx context.Context, record *models.IdempotencyRecord) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	record.CreatedAt = time.Now().UTC()
+	query := `DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ? AND created_at <= ?`
+	_, err = db.ExecContext(ctx, query, record.Scope, record.Key, record.CreatedAt.Add(-IdempotencyKeyTTL))
+	if err != nil {
+		return fmt.Errorf("failed to expire idempotency key: %w", err)
+	}
+
+	query = `INSERT INTO idempotency_keys (scope, idempotency_key, user_id, request_hash, response, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = db.ExecContext(ctx, query, record.Scope, record.Key,
+		sql.NullString{String: record.UserID, Valid: record.UserID != ""},
+		record.RequestHash, record.Response, record.CreatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return utils.Conflict("idempotency key %q is already recorded", record.Key)
+		}
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUser deletes the records holding a user's data, so their responses
+// are no longer replayed.
+func (r *idempotencyRepository) DeleteByUser(ctx context.Context, userID string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete idempotency keys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/database"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupIdempotencyRepoTest(t *testing.T) (*idempotencyRepository, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+
+	db := &database.Database{}
+	db.SetDB(mockDB)
+
+	repo := &idempotencyRepository{db: db}
+
+	cleanup := func() {
+		mockDB.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestIdempotencyRepository_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows([]string{"scope", "idempotency_key", "request_hash", "response", "created_at"}).
+			AddRow("availability:e1:u1", "key-1", "abc", []byte(`{"message":"ok"}`), now)
+
+		mock.ExpectQuery("SELECT .+ FROM idempotency_keys WHERE scope = \\? AND idempotency_key = \\? AND created_at > \\?").
+			WithArgs("availability:e1:u1", "key-1", sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		record, err := repo.Get(context.Background(), "availability:e1:u1", "key-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", record.RequestHash)
+		assert.JSONEq(t, `{"message":"ok"}`, string(record.Response))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found or expired", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM idempotency_keys").
+			WithArgs("availability:e1:u1", "key-1", sqlmock.AnyArg()).
+			WillReturnError(sql.ErrNoRows)
+
+		record, err := repo.Get(context.Background(), "availability:e1:u1", "key-1")
+		assert.NoError(t, err)
+		assert.Nil(t, record)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery("SELECT .+ FROM idempotency_keys").
+			WillReturnError(errors.New("database error"))
+
+		record, err := repo.Get(context.Background(), "availability:e1:u1", "key-1")
+		assert.Error(t, err)
+		assert.Nil(t, record)
+		assert.Contains(t, err.Error(), "failed to get idempotency key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIdempotencyRepository_Save(t *testing.T) {
+	record := func() *models.IdempotencyRecord {
+		return &models.IdempotencyRecord{
+			Scope:       "availability:e1:u1",
+			Key:         "key-1",
+			UserID:      "u1",
+			RequestHash: "abc",
+			Response:    []byte(`{}`),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		r := record()
+		mock.ExpectExec("DELETE FROM idempotency_keys WHERE scope = \\? AND idempotency_key = \\? AND created_at <= \\?").
+			WithArgs(r.Scope, r.Key, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_keys").
+			WithArgs(r.Scope, r.Key, r.UserID, r.RequestHash, r.Response, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Save(context.Background(), r)
+		assert.NoError(t, err)
+		assert.NotZero(t, r.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Live record exists", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM idempotency_keys").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_keys").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+		err := repo.Save(context.Background(), record())
+		assert.True(t, errors.Is(err, utils.ErrConflict))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM idempotency_keys").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_keys").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.Save(context.Background(), &models.IdempotencyRecord{Scope: "s", Key: "k"})
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, utils.ErrConflict))
+		assert.Contains(t, err.Error(), "failed to save idempotency key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIdempotencyRepository_DeleteByUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM idempotency_keys WHERE user_id = \\?").
+			WithArgs("u1").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.DeleteByUser(context.Background(), "u1")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupIdempotencyRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("DELETE FROM idempotency_keys").
+			WithArgs("u1").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.DeleteByUser(context.Background(), "u1")
+		assert.Contains(t, err.Error(), "failed to delete idempotency keys")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,15 +3,39 @@ package repository
 import (
 	"context"
 	"meeting-slot-service/internal/models"
+	"time"
 )
 
+// UnitOfWork runs a function in a transaction. Repository calls made with
+// the context passed to fn are part of the transaction, so a service can make
+// changes across repositories all-or-nothing.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id string) (*models.User, error)
+	// IsDeleted reports whether the user is deleted. Unknown users are not.
+	IsDeleted(ctx context.Context, id string) (bool, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	// GetByIDs returns the users among ids that exist, in no particular
+	// order.
+	GetByIDs(ctx context.Context, ids []string) ([]*models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	// Delete soft-deletes a user: they are hidden everywhere until restored
+	// or purged.
 	Delete(ctx context.Context, id string) error
+	// Restore undoes a deletion made after deletedAfter.
+	Restore(ctx context.Context, id string, deletedAfter time.Time) error
+	// Purge hard-deletes the users deleted at or before deletedBefore and
+	// returns how many there were.
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// Erase replaces a user's name and email, and removes the reasons they
+	// gave when replying to invitations. Deleted users can be erased too. It
+	// returns the erased user.
+	Erase(ctx context.Context, id, name, email string) (*models.User, error)
 	List(ctx context.Context, limit, offset int) ([]*models.User, error)
 }
 
@@ -20,24 +44,96 @@ type EventRepository interface {
 	Create(ctx context.Context, event *models.Event) error
 	GetByID(ctx context.Context, id string) (*models.Event, error)
 	Update(ctx context.Context, event *models.Event) error
+	UpdateOrganizer(ctx context.Context, eventID, organizerID string) error
+	AddProposedSlot(ctx context.Context, slot *models.ProposedSlot) error
+	UpdateProposedSlot(ctx context.Context, slot *models.ProposedSlot) error
+	DeleteProposedSlot(ctx context.Context, eventID string, slotID uint) error
+	// Touch bumps the event's version, which changes with the event, its
+	// participants or their availability. A non-zero version makes the bump
+	// conditional on the event still being at that version.
+	Touch(ctx context.Context, id string, version int) (int, error)
+	// Delete soft-deletes an event.
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, filter models.EventFilter) ([]*models.Event, int, error)
+	// GetDeleted returns an event deleted after deletedAfter.
+	GetDeleted(ctx context.Context, id string, deletedAfter time.Time) (*models.Event, error)
+	// Restore undoes a deletion made after deletedAfter.
+	Restore(ctx context.Context, id string, deletedAfter time.Time) error
+	// GetIDsByDeletedOrganizer returns the events that are not deleted and
+	// whose organizer was deleted at or before deletedBefore.
+	GetIDsByDeletedOrganizer(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	// Purge hard-deletes the events deleted at or before deletedBefore, and
+	// those whose organizer was, and returns how many there were.
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	List(ctx context.Context, filter models.EventFilter) (*models.EventPage, error)
 }
 
 // AvailabilityRepository defines the interface for availability data operations
 type AvailabilityRepository interface {
-	CreateSlots(ctx context.Context, slots []models.AvailabilitySlot) error
 	GetByEventAndUser(ctx context.Context, eventID, userID string) ([]models.AvailabilitySlot, error)
 	GetByEvent(ctx context.Context, eventID string) ([]models.AvailabilitySlot, error)
-	UpdateUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot) error
+	SaveUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot, participantStatus string) error
 	DeleteUserSlots(ctx context.Context, eventID, userID string) error
+	// GetByUser returns a user's availability across the events that are not
+	// deleted.
+	GetByUser(ctx context.Context, userID string) ([]models.AvailabilitySlot, error)
 }
 
 // ParticipantRepository defines the interface for participant data operations
 type ParticipantRepository interface {
 	AddParticipant(ctx context.Context, participant *models.EventParticipant) error
+	// AddParticipants adds several participants to one event in a single
+	// insert.
+	AddParticipants(ctx context.Context, eventID string, participants []models.EventParticipant) error
 	GetEventParticipants(ctx context.Context, eventID string) ([]models.EventParticipant, error)
 	GetParticipant(ctx context.Context, eventID, userID string) (*models.EventParticipant, error)
 	RemoveParticipant(ctx context.Context, eventID, userID string) error
+	RemoveParticipants(ctx context.Context, eventID string, userIDs []string) error
 	UpdateParticipantStatus(ctx context.Context, eventID, userID, status string) error
+	UpdateParticipantResponse(ctx context.Context, eventID, userID, status, reason string) error
+	UpdateParticipantRole(ctx context.Context, eventID, userID, role string) error
+	// GetGroupEventIDs returns the pending events that have participants
+	// invited through a group.
+	GetGroupEventIDs(ctx context.Context, groupID string) ([]string, error)
+	// GetUserParticipations returns a user's invitations to the events that
+	// are not deleted.
+	GetUserParticipations(ctx context.Context, userID string) ([]models.UserParticipation, error)
+}
+
+// GroupRepository defines the interface for group data operations
+type GroupRepository interface {
+	Create(ctx context.Context, group *models.Group) error
+	// GetByID returns a group with its members.
+	GetByID(ctx context.Context, id string) (*models.Group, error)
+	// List returns the groups userID owns or belongs to, or every group when
+	// userID is empty.
+	List(ctx context.Context, userID string) ([]*models.Group, error)
+	Update(ctx context.Context, group *models.Group) error
+	Delete(ctx context.Context, id string) error
+	AddMembers(ctx context.Context, groupID string, userIDs []string) error
+	RemoveMember(ctx context.Context, groupID, userID string) error
+}
+
+// IdempotencyRepository defines the interface for idempotency key storage
+type IdempotencyRepository interface {
+	Get(ctx context.Context, scope, key string) (*models.IdempotencyRecord, error)
+	Save(ctx context.Context, record *models.IdempotencyRecord) error
+	// DeleteByUser deletes the records holding a user's data.
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+// APIKeyRepository defines the interface for API key data operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+// AuditRepository defines the interface for audit log operations. Entries
+// are never changed, except that RedactUser drops the recorded state of a
+// user, their participations and their availability when they are erased.
+type AuditRepository interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error)
+	RedactUser(ctx context.Context, userID string) error
 }
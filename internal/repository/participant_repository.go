@@ -2,12 +2,21 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 )
 
+// participantColumns is the column list scanned by scanParticipant. It
+// expects event_participants aliased as ep joined to users aliased as u.
+const participantColumns = `ep.id, ep.event_id, ep.user_id, ep.status, ep.role, ep.response_reason,
+	ep.group_id, ep.created_at, ep.updated_at,
+	u.id, u.name, u.email, u.is_guest, u.created_at, u.updated_at`
+
 type participantRepository struct {
 	db *database.Database
 }
@@ -18,15 +27,22 @@ func NewParticipantRepository(db *database.Database) ParticipantRepository {
 }
 
 func (r *participantRepository) AddParticipant(ctx context.Context, participant *models.EventParticipant) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	query := `INSERT INTO event_participants (event_id, user_id, status, created_at, updated_at) 
-			  VALUES (?, ?, ?, NOW(), NOW())`
-	result, err := db.ExecContext(ctx, query, participant.EventID, participant.UserID, participant.Status)
+	if participant.Role == "" {
+		participant.Role = models.ParticipantRoleAttendee
+	}
+
+	query := `INSERT INTO event_participants (event_id, user_id, status, role, created_at, updated_at) 
+			  VALUES (?, ?, ?, ?, NOW(), NOW())`
+	result, err := db.ExecContext(ctx, query, participant.EventID, participant.UserID, participant.Status, participant.Role)
 	if err != nil {
+		if isDuplicateKey(err) {
+			return utils.Conflict("user is already a participant")
+		}
 		return fmt.Errorf("failed to add participant: %w", err)
 	}
 
@@ -40,17 +56,47 @@ func (r *participantRepository) AddParticipant(ctx context.Context, participant
 	return nil
 }
 
+func (r *participantRepository) AddParticipants(ctx context.Context, eventID string, participants []models.EventParticipant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	rows := make([]string, 0, len(participants))
+	args := make([]interface{}, 0, 5*len(participants))
+	for _, p := range participants {
+		role := p.Role
+		if role == "" {
+			role = models.ParticipantRoleAttendee
+		}
+		rows = append(rows, "(?, ?, ?, ?, ?, NOW(), NOW())")
+		args = append(args, eventID, p.UserID, p.Status, role, sql.NullString{String: p.GroupID, Valid: p.GroupID != ""})
+	}
+
+	query := `INSERT INTO event_participants (event_id, user_id, status, role, group_id, created_at, updated_at) 
+			  VALUES ` + strings.Join(rows, ", ")
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		if isDuplicateKey(err) {
+			return utils.Conflict("user is already a participant")
+		}
+		return fmt.Errorf("failed to add participants: %w", err)
+	}
+	return nil
+}
+
 func (r *participantRepository) GetEventParticipants(ctx context.Context, eventID string) ([]models.EventParticipant, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	query := `SELECT ep.id, ep.event_id, ep.user_id, ep.status, ep.created_at, ep.updated_at,
-			  u.id, u.name, u.email, u.created_at, u.updated_at
+	query := `SELECT ` + participantColumns + `
 			  FROM event_participants ep
 			  LEFT JOIN users u ON ep.user_id = u.id
-			  WHERE ep.event_id = ?`
+			  WHERE ep.event_id = ? AND u.deleted_at IS NULL`
 
 	rows, err := db.QueryContext(ctx, query, eventID)
 	if err != nil {
@@ -60,14 +106,11 @@ func (r *participantRepository) GetEventParticipants(ctx context.Context, eventI
 
 	var participants []models.EventParticipant
 	for rows.Next() {
-		var p models.EventParticipant
-		var user models.User
-		if err := rows.Scan(&p.ID, &p.EventID, &p.UserID, &p.Status, &p.CreatedAt, &p.UpdatedAt,
-			&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		p, err := scanParticipant(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan participant: %w", err)
 		}
-		p.User = &user
-		participants = append(participants, p)
+		participants = append(participants, *p)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -78,28 +121,34 @@ func (r *participantRepository) GetEventParticipants(ctx context.Context, eventI
 }
 
 func (r *participantRepository) GetParticipant(ctx context.Context, eventID, userID string) (*models.EventParticipant, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	query := `SELECT id, event_id, user_id, status, created_at, updated_at
+	query := `SELECT id, event_id, user_id, status, role, response_reason, group_id, created_at, updated_at
 			  FROM event_participants
 			  WHERE event_id = ? AND user_id = ?`
 
 	var p models.EventParticipant
+	var reason, groupID sql.NullString
 	err = db.QueryRowContext(ctx, query, eventID, userID).Scan(
-		&p.ID, &p.EventID, &p.UserID, &p.Status, &p.CreatedAt, &p.UpdatedAt,
+		&p.ID, &p.EventID, &p.UserID, &p.Status, &p.Role, &reason, &groupID, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("participant not found: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, utils.NotFound("participant not found")
+		}
+		return nil, fmt.Errorf("failed to get participant: %w", err)
 	}
+	p.ResponseReason = reason.String
+	p.GroupID = groupID.String
 
 	return &p, nil
 }
 
 func (r *participantRepository) RemoveParticipant(ctx context.Context, eventID, userID string) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -115,14 +164,106 @@ func (r *participantRepository) RemoveParticipant(ctx context.Context, eventID,
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rows == 0 {
-		return fmt.Errorf("participant not found")
+		return utils.NotFound("participant not found")
 	}
 
 	return nil
 }
 
+// RemoveParticipants removes several participants from an event. It fails
+// with ErrNotFound, removing none, unless all of them are participants.
+func (r *participantRepository) RemoveParticipants(ctx context.Context, eventID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		query := `DELETE FROM event_participants WHERE event_id = ? AND user_id IN ` + placeholders(len(userIDs))
+		result, err := tx.ExecContext(ctx, query, append([]interface{}{eventID}, stringArgs(userIDs)...)...)
+		if err != nil {
+			return fmt.Errorf("failed to remove participants: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows != int64(len(userIDs)) {
+			return utils.NotFound("participant not found")
+		}
+		return nil
+	})
+}
+
+// GetGroupEventIDs returns the pending events that have participants
+// invited through a group.
+func (r *participantRepository) GetGroupEventIDs(ctx context.Context, groupID string) ([]string, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT DISTINCT ep.event_id
+			  FROM event_participants ep
+			  JOIN events e ON e.id = ep.event_id
+			  WHERE ep.group_id = ? AND e.status = ? AND e.deleted_at IS NULL`
+	rows, err := db.QueryContext(ctx, query, groupID, models.EventStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event id: %w", err)
+		}
+		eventIDs = append(eventIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return eventIDs, nil
+}
+
+func (r *participantRepository) GetUserParticipations(ctx context.Context, userID string) ([]models.UserParticipation, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `SELECT ep.event_id, e.title, ep.status, ep.role, ep.response_reason, ep.group_id,
+			  ep.created_at, ep.updated_at
+			  FROM event_participants ep
+			  JOIN events e ON e.id = ep.event_id
+			  WHERE ep.user_id = ? AND e.deleted_at IS NULL
+			  ORDER BY ep.created_at, ep.id`
+	rows, err := db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participations: %w", err)
+	}
+	defer rows.Close()
+
+	participations := make([]models.UserParticipation, 0)
+	for rows.Next() {
+		var p models.UserParticipation
+		var reason, groupID sql.NullString
+		if err := rows.Scan(&p.EventID, &p.EventTitle, &p.Status, &p.Role, &reason, &groupID,
+			&p.InvitedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan participation: %w", err)
+		}
+		p.ResponseReason = reason.String
+		p.GroupID = groupID.String
+		participations = append(participations, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return participations, nil
+}
+
 func (r *participantRepository) UpdateParticipantStatus(ctx context.Context, eventID, userID, status string) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -138,8 +279,74 @@ func (r *participantRepository) UpdateParticipantStatus(ctx context.Context, eve
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rows == 0 {
-		return fmt.Errorf("participant not found")
+		return utils.NotFound("participant not found")
 	}
 
 	return nil
 }
+
+// UpdateParticipantResponse records a participant's RSVP status together with
+// an optional reason. An empty reason clears any previous one.
+func (r *participantRepository) UpdateParticipantResponse(ctx context.Context, eventID, userID, status, reason string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `UPDATE event_participants SET status = ?, response_reason = ?, updated_at = NOW()
+			  WHERE event_id = ? AND user_id = ?`
+	result, err := db.ExecContext(ctx, query, status, sql.NullString{String: reason, Valid: reason != ""}, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update participant response: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("participant not found")
+	}
+
+	return nil
+}
+
+// UpdateParticipantRole changes a participant's role.
+func (r *participantRepository) UpdateParticipantRole(ctx context.Context, eventID, userID, role string) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `UPDATE event_participants SET role = ?, updated_at = NOW() WHERE event_id = ? AND user_id = ?`
+	result, err := db.ExecContext(ctx, query, role, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update participant role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("participant not found")
+	}
+
+	return nil
+}
+
+// scanParticipant scans a row selected with participantColumns.
+func scanParticipant(row rowScanner) (*models.EventParticipant, error) {
+	var p models.EventParticipant
+	var user models.User
+	var reason, groupID sql.NullString
+	if err := row.Scan(&p.ID, &p.EventID, &p.UserID, &p.Status, &p.Role, &reason,
+		&groupID, &p.CreatedAt, &p.UpdatedAt,
+		&user.ID, &user.Name, &user.Email, &user.IsGuest, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, err
+	}
+	p.ResponseReason = reason.String
+	p.GroupID = groupID.String
+	p.User = &user
+	return &p, nil
+}
@@ -9,8 +9,10 @@ import (
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,7 +44,7 @@ func TestParticipantRepository_AddParticipant(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO event_participants").
-			WithArgs(participant.EventID, participant.UserID, participant.Status).
+			WithArgs(participant.EventID, participant.UserID, participant.Status, models.ParticipantRoleAttendee).
 			WillReturnResult(sqlmock.NewResult(5, 1))
 
 		err := repo.AddParticipant(context.Background(), participant)
@@ -62,7 +64,7 @@ func TestParticipantRepository_AddParticipant(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO event_participants").
-			WithArgs(participant.EventID, participant.UserID, participant.Status).
+			WithArgs(participant.EventID, participant.UserID, participant.Status, models.ParticipantRoleAttendee).
 			WillReturnError(errors.New("database error"))
 
 		err := repo.AddParticipant(context.Background(), participant)
@@ -82,7 +84,7 @@ func TestParticipantRepository_AddParticipant(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO event_participants").
-			WithArgs(participant.EventID, participant.UserID, participant.Status).
+			WithArgs(participant.EventID, participant.UserID, participant.Status, models.ParticipantRoleAttendee).
 			WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
 
 		err := repo.AddParticipant(context.Background(), participant)
@@ -92,6 +94,49 @@ func TestParticipantRepository_AddParticipant(t *testing.T) {
 	})
 }
 
+func TestParticipantRepository_AddParticipants(t *testing.T) {
+	participants := []models.EventParticipant{
+		{UserID: "user-1", Status: models.ParticipantStatusInvited},
+		{UserID: "user-2", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleCoOrganizer, GroupID: "grp_1"},
+	}
+	insert := "INSERT INTO event_participants .+ VALUES \\(\\?, \\?, \\?, \\?, \\?, NOW\\(\\), NOW\\(\\)\\), \\(\\?, \\?, \\?, \\?, \\?, NOW\\(\\), NOW\\(\\)\\)$"
+
+	t.Run("One insert for all", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(insert).
+			WithArgs("event-1", "user-1", "invited", models.ParticipantRoleAttendee, nil,
+				"event-1", "user-2", "invited", models.ParticipantRoleCoOrganizer, "grp_1").
+			WillReturnResult(sqlmock.NewResult(7, 2))
+
+		err := repo.AddParticipants(context.Background(), "event-1", participants)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already a participant", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(insert).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'event-1-user-2' for key 'idx_event_user'"})
+
+		err := repo.AddParticipants(context.Background(), "event-1", participants)
+		assert.ErrorIs(t, err, utils.ErrConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to add", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		err := repo.AddParticipants(context.Background(), "event-1", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestParticipantRepository_GetEventParticipants(t *testing.T) {
 	t.Run("Success with multiple participants", func(t *testing.T) {
 		repo, mock, cleanup := setupParticipantRepoTest(t)
@@ -101,11 +146,11 @@ func TestParticipantRepository_GetEventParticipants(t *testing.T) {
 		now := time.Now().UTC()
 
 		rows := sqlmock.NewRows([]string{
-			"id", "event_id", "user_id", "status", "created_at", "updated_at",
-			"id", "name", "email", "created_at", "updated_at",
+			"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at",
+			"id", "name", "email", "is_guest", "created_at", "updated_at",
 		}).
-			AddRow(1, eventID, "user-1", "pending", now, now, "user-1", "John Doe", "john@example.com", now, now).
-			AddRow(2, eventID, "user-2", "accepted", now, now, "user-2", "Jane Smith", "jane@example.com", now, now)
+			AddRow(1, eventID, "user-1", "pending", "attendee", nil, nil, now, now, "user-1", "John Doe", "john@example.com", false, now, now).
+			AddRow(2, eventID, "user-2", "accepted", "attendee", nil, nil, now, now, "user-2", "Jane Smith", "jane@example.com", false, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM event_participants ep (.+) WHERE ep.event_id = \\?").
 			WithArgs(eventID).
@@ -129,8 +174,8 @@ func TestParticipantRepository_GetEventParticipants(t *testing.T) {
 		eventID := "event-1"
 
 		rows := sqlmock.NewRows([]string{
-			"id", "event_id", "user_id", "status", "created_at", "updated_at",
-			"id", "name", "email", "created_at", "updated_at",
+			"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at",
+			"id", "name", "email", "is_guest", "created_at", "updated_at",
 		})
 
 		mock.ExpectQuery("SELECT .+ FROM event_participants ep (.+) WHERE ep.event_id = \\?").
@@ -168,10 +213,10 @@ func TestParticipantRepository_GetEventParticipants(t *testing.T) {
 		now := time.Now().UTC()
 
 		rows := sqlmock.NewRows([]string{
-			"id", "event_id", "user_id", "status", "created_at", "updated_at",
-			"id", "name", "email", "created_at", "updated_at",
+			"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at",
+			"id", "name", "email", "is_guest", "created_at", "updated_at",
 		}).
-			AddRow("invalid-id", eventID, "user-1", "pending", now, now, "user-1", "John Doe", "john@example.com", now, now)
+			AddRow("invalid-id", eventID, "user-1", "pending", "attendee", nil, nil, now, now, "user-1", "John Doe", "john@example.com", false, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM event_participants ep (.+) WHERE ep.event_id = \\?").
 			WithArgs(eventID).
@@ -192,10 +237,10 @@ func TestParticipantRepository_GetEventParticipants(t *testing.T) {
 		now := time.Now().UTC()
 
 		rows := sqlmock.NewRows([]string{
-			"id", "event_id", "user_id", "status", "created_at", "updated_at",
-			"id", "name", "email", "created_at", "updated_at",
+			"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at",
+			"id", "name", "email", "is_guest", "created_at", "updated_at",
 		}).
-			AddRow(1, eventID, "user-1", "pending", now, now, "user-1", "John Doe", "john@example.com", now, now).
+			AddRow(1, eventID, "user-1", "pending", "attendee", nil, nil, now, now, "user-1", "John Doe", "john@example.com", false, now, now).
 			RowError(0, errors.New("row iteration error"))
 
 		mock.ExpectQuery("SELECT .+ FROM event_participants ep (.+) WHERE ep.event_id = \\?").
@@ -219,8 +264,8 @@ func TestParticipantRepository_GetParticipant(t *testing.T) {
 		userID := "user-1"
 		now := time.Now().UTC()
 
-		rows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "created_at", "updated_at"}).
-			AddRow(1, eventID, userID, "accepted", now, now)
+		rows := sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "role", "response_reason", "group_id", "created_at", "updated_at"}).
+			AddRow(1, eventID, userID, "declined", "co_organizer", "Out of office", nil, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM event_participants WHERE event_id = \\? AND user_id = \\?").
 			WithArgs(eventID, userID).
@@ -232,7 +277,9 @@ func TestParticipantRepository_GetParticipant(t *testing.T) {
 		assert.Equal(t, uint(1), participant.ID)
 		assert.Equal(t, eventID, participant.EventID)
 		assert.Equal(t, userID, participant.UserID)
-		assert.Equal(t, "accepted", participant.Status)
+		assert.Equal(t, "declined", participant.Status)
+		assert.Equal(t, "co_organizer", participant.Role)
+		assert.Equal(t, "Out of office", participant.ResponseReason)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -248,9 +295,9 @@ func TestParticipantRepository_GetParticipant(t *testing.T) {
 			WillReturnError(sql.ErrNoRows)
 
 		participant, err := repo.GetParticipant(context.Background(), eventID, userID)
-		assert.Error(t, err)
+		assert.True(t, errors.Is(err, utils.ErrNotFound))
 		assert.Nil(t, participant)
-		assert.Contains(t, err.Error(), "participant not found")
+		assert.EqualError(t, err, "participant not found")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -267,8 +314,9 @@ func TestParticipantRepository_GetParticipant(t *testing.T) {
 
 		participant, err := repo.GetParticipant(context.Background(), eventID, userID)
 		assert.Error(t, err)
+		assert.False(t, errors.Is(err, utils.ErrNotFound))
 		assert.Nil(t, participant)
-		assert.Contains(t, err.Error(), "participant not found")
+		assert.Contains(t, err.Error(), "failed to get participant")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -342,6 +390,40 @@ func TestParticipantRepository_RemoveParticipant(t *testing.T) {
 	})
 }
 
+func TestParticipantRepository_RemoveParticipants(t *testing.T) {
+	remove := "DELETE FROM event_participants WHERE event_id = \\? AND user_id IN \\(\\?, \\?\\)"
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(remove).
+			WithArgs("event-1", "user-1", "user-2").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.RemoveParticipants(context.Background(), "event-1", []string{"user-1", "user-2"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not all participants", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(remove).
+			WithArgs("event-1", "user-1", "user-2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err := repo.RemoveParticipants(context.Background(), "event-1", []string{"user-1", "user-2"})
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestParticipantRepository_UpdateParticipantStatus(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, mock, cleanup := setupParticipantRepoTest(t)
@@ -414,3 +496,158 @@ func TestParticipantRepository_UpdateParticipantStatus(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestParticipantRepository_UpdateParticipantResponse(t *testing.T) {
+	const query = "UPDATE event_participants SET status = \\?, response_reason = \\?, updated_at = NOW\\(\\)\\s+WHERE event_id = \\? AND user_id = \\?"
+
+	t.Run("Success with reason", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("declined", "Out of office", "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateParticipantResponse(context.Background(), "event-1", "user-1", "declined", "Out of office")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty reason is stored as NULL", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("tentative", nil, "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateParticipantResponse(context.Background(), "event-1", "user-1", "tentative", "")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Participant not found", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("declined", nil, "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateParticipantResponse(context.Background(), "event-1", "user-1", "declined", "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "participant not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("declined", nil, "event-1", "user-1").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.UpdateParticipantResponse(context.Background(), "event-1", "user-1", "declined", "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update participant response")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestParticipantRepository_UpdateParticipantRole(t *testing.T) {
+	const query = "UPDATE event_participants SET role = \\?, updated_at = NOW\\(\\) WHERE event_id = \\? AND user_id = \\?"
+
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("co_organizer", "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateParticipantRole(context.Background(), "event-1", "user-1", "co_organizer")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Participant not found", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("attendee", "event-1", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateParticipantRole(context.Background(), "event-1", "user-1", "attendee")
+		assert.EqualError(t, err, "participant not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs("attendee", "event-1", "user-1").
+			WillReturnError(errors.New("database error"))
+
+		err := repo.UpdateParticipantRole(context.Background(), "event-1", "user-1", "attendee")
+		assert.ErrorContains(t, err, "failed to update participant role")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestParticipantRepository_GetGroupEventIDs(t *testing.T) {
+	query := "SELECT DISTINCT ep.event_id FROM event_participants ep JOIN events e (.+) WHERE ep.group_id = \\? AND e.status = \\?"
+
+	t.Run("Pending events", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs("grp_1", models.EventStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow("event-1").AddRow("event-2"))
+
+		eventIDs, err := repo.GetGroupEventIDs(context.Background(), "grp_1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"event-1", "event-2"}, eventIDs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		repo, mock, cleanup := setupParticipantRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs("grp_1", models.EventStatusPending).
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.GetGroupEventIDs(context.Background(), "grp_1")
+		assert.ErrorContains(t, err, "failed to get group events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestParticipantRepository_GetUserParticipations(t *testing.T) {
+	repo, mock, cleanup := setupParticipantRepoTest(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"event_id", "title", "status", "role", "response_reason", "group_id", "created_at", "updated_at"}).
+		AddRow("event-1", "Team Meeting", "declined", "attendee", "On leave", nil, now, now).
+		AddRow("event-2", "Retro", "invited", "co_organizer", nil, "grp_1", now, now)
+
+	mock.ExpectQuery("FROM event_participants ep JOIN events e ON e.id = ep.event_id WHERE ep.user_id = \\? AND e.deleted_at IS NULL").
+		WithArgs("user-1").
+		WillReturnRows(rows)
+
+	participations, err := repo.GetUserParticipations(context.Background(), "user-1")
+	assert.NoError(t, err)
+	if assert.Len(t, participations, 2) {
+		assert.Equal(t, "Team Meeting", participations[0].EventTitle)
+		assert.Equal(t, "On leave", participations[0].ResponseReason)
+		assert.Equal(t, "grp_1", participations[1].GroupID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
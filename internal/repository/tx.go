@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"meeting-slot-service/internal/database"
+)
+
+// DBTX is the part of *sql.DB and *sql.Tx that repositories use, so the same
+// statements run inside or outside a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txKey is the context key under which the active transaction is stored.
+type txKey struct{}
+
+type unitOfWork struct {
+	db *database.Database
+}
+
+// NewUnitOfWork creates a unit of work backed by db
+func NewUnitOfWork(db *database.Database) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn in a transaction that every repository joins when called with
+// the context fn receives. The transaction commits if fn returns nil and is
+// rolled back otherwise. A nested Do joins the outer transaction, which then
+// decides the outcome.
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	db, err := u.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// conn returns the transaction active in ctx, or the connection pool when
+// there is none.
+func conn(ctx context.Context, db *database.Database) (DBTX, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx, nil
+	}
+	pool, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// inTx runs fn in the transaction active in ctx, or in a new one, for
+// repository methods that issue several statements that must not be applied
+// partially.
+func inTx(ctx context.Context, db *database.Database, fn func(tx DBTX) error) error {
+	return NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+		return fn(ctx.Value(txKey{}).(*sql.Tx))
+	})
+}
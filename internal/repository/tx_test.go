@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"meeting-slot-service/internal/database"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupUnitOfWorkTest(t *testing.T) (*database.Database, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	assert.NoError(t, err)
+
+	db := &database.Database{}
+	db.SetDB(mockDB)
+
+	return db, mock, func() { mockDB.Close() }
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	t.Run("Commits across repositories", func(t *testing.T) {
+		db, mock, cleanup := setupUnitOfWorkTest(t)
+		defer cleanup()
+
+		events := &eventRepository{db: db}
+		participants := &participantRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE events SET organizer_id").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM event_participants").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := NewUnitOfWork(db).Do(context.Background(), func(ctx context.Context) error {
+			if err := events.UpdateOrganizer(ctx, "event-1", "user-2"); err != nil {
+				return err
+			}
+			return participants.RemoveParticipant(ctx, "event-1", "user-2")
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls back when fn fails", func(t *testing.T) {
+		db, mock, cleanup := setupUnitOfWorkTest(t)
+		defer cleanup()
+
+		events := &eventRepository{db: db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE events SET organizer_id").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		failure := errors.New("later step failed")
+		err := NewUnitOfWork(db).Do(context.Background(), func(ctx context.Context) error {
+			if err := events.UpdateOrganizer(ctx, "event-1", "user-2"); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nested unit of work joins the outer transaction", func(t *testing.T) {
+		db, mock, cleanup := setupUnitOfWorkTest(t)
+		defer cleanup()
+
+		uow := NewUnitOfWork(db)
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return uow.Do(ctx, func(ctx context.Context) error { return nil })
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 )
 
+// userColumns is the column list scanned by scanUser.
+const userColumns = `id, name, email, timezone, is_guest, created_at, updated_at`
+
 type userRepository struct {
 	db *database.Database
 }
@@ -20,7 +25,7 @@ func NewUserRepository(db *database.Database) UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -29,61 +34,107 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	query := `INSERT INTO users (id, name, email, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?)`
-	_, err = db.ExecContext(ctx, query, user.ID, user.Name, user.Email, user.CreatedAt, user.UpdatedAt)
+	query := `INSERT INTO users (id, name, email, timezone, is_guest, created_at, updated_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err = db.ExecContext(ctx, query, user.ID, user.Name, user.Email, user.Timezone, user.IsGuest, user.CreatedAt, user.UpdatedAt)
 	if err != nil {
+		if isDuplicateKey(err) {
+			return utils.Conflict("email already exists")
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
-	var user models.User
-	query := `SELECT id, name, email, created_at, updated_at FROM users WHERE id = ?`
-	err = db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt,
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ? AND deleted_at IS NULL`
+	user, err := scanUser(db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, utils.NotFound("user not found")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	return &user, nil
+	return user, nil
+}
+
+// IsDeleted reports whether the user is deleted. Unknown users are not.
+func (r *userRepository) IsDeleted(ctx context.Context, id string) (bool, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return false, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	var deleted bool
+	query := `SELECT deleted_at IS NOT NULL FROM users WHERE id = ?`
+	if err := db.QueryRowContext(ctx, query, id).Scan(&deleted); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	return deleted, nil
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
-	var user models.User
-	query := `SELECT id, name, email, created_at, updated_at FROM users WHERE email = ?`
-	err = db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt,
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ? AND deleted_at IS NULL`
+	user, err := scanUser(db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, utils.NotFound("user not found")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	return &user, nil
+	return user, nil
+}
+
+func (r *userRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `SELECT ` + userColumns + ` FROM users WHERE id IN ` + placeholders(len(ids)) + ` AND deleted_at IS NULL`
+	rows, err := db.QueryContext(ctx, query, stringArgs(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0, len(ids))
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return users, nil
 }
 
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
-	query := `UPDATE users SET name = ?, email = ?, updated_at = NOW() WHERE id = ?`
-	result, err := db.ExecContext(ctx, query, user.Name, user.Email, user.ID)
+	query := `UPDATE users SET name = ?, email = ?, timezone = ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+	result, err := db.ExecContext(ctx, query, user.Name, user.Email, user.Timezone, user.ID)
 	if err != nil {
+		if isDuplicateKey(err) {
+			return utils.Conflict("email already exists")
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	rows, err := result.RowsAffected()
@@ -91,17 +142,19 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rows == 0 {
-		return fmt.Errorf("user not found")
+		return utils.NotFound("user not found")
 	}
 	return nil
 }
 
+// Delete soft-deletes a user. Their participations, availability and group
+// memberships are kept until the user is purged.
 func (r *userRepository) Delete(ctx context.Context, id string) error {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
-	query := `DELETE FROM users WHERE id = ?`
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
 	result, err := db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
@@ -111,17 +164,98 @@ func (r *userRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rows == 0 {
-		return fmt.Errorf("user not found")
+		return utils.NotFound("user not found")
 	}
 	return nil
 }
 
+// Restore undoes the deletion of a user deleted after deletedAfter.
+func (r *userRepository) Restore(ctx context.Context, id string, deletedAfter time.Time) error {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	query := `UPDATE users SET deleted_at = NULL WHERE id = ? AND deleted_at > ?`
+	result, err := db.ExecContext(ctx, query, id, deletedAfter)
+	if err != nil {
+		// Deleted users do not hold their email, so someone else may have
+		// taken it since
+		if isDuplicateKey(err) {
+			return utils.Conflict("email already exists: another user has taken it since the user was deleted")
+		}
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return utils.NotFound("deleted user not found")
+	}
+	return nil
+}
+
+// Purge hard-deletes the users deleted at or before deletedBefore, with
+// everything that references them, and returns how many there were. The
+// events they organize are not removed with them; callers hand those over
+// or purge them first.
+func (r *userRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	db, err := conn(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	result, err := db.ExecContext(ctx, `DELETE FROM users WHERE deleted_at <= ?`, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge users: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
+
+// Erase anonymizes a user in one transaction. Deleted users are erased too,
+// so their data does not wait for the purge.
+func (r *userRepository) Erase(ctx context.Context, id, name, email string) (*models.User, error) {
+	var user *models.User
+	err := inTx(ctx, r.db, func(tx DBTX) error {
+		query := `UPDATE users SET name = ?, email = ?, timezone = 'UTC', updated_at = NOW() 
+				  WHERE id = ?`
+		result, err := tx.ExecContext(ctx, query, name, email, id)
+		if err != nil {
+			return fmt.Errorf("failed to erase user: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return utils.NotFound("user not found")
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE event_participants SET response_reason = NULL WHERE user_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to erase response reasons: %w", err)
+		}
+
+		user, err = scanUser(tx.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id))
+		if err != nil {
+			return fmt.Errorf("failed to get erased user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	db, err := r.db.DB()
+	db, err := conn(ctx, r.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
-	query := `SELECT id, name, email, created_at, updated_at FROM users ORDER BY created_at DESC LIMIT ? OFFSET ?`
+	query := `SELECT ` + userColumns + ` FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT ? OFFSET ?`
 	rows, err := db.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
@@ -130,11 +264,11 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models
 
 	users := make([]*models.User, 0)
 	for rows.Next() {
-		var user models.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -143,3 +277,32 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models
 
 	return users, nil
 }
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// placeholders returns "(?, ?, ...)" with n placeholders, for an IN list.
+func placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// stringArgs converts values to query arguments.
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// scanUser scans a row selected with userColumns.
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Timezone, &user.IsGuest,
+		&user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
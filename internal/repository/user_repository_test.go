@@ -9,8 +9,10 @@ import (
 
 	"meeting-slot-service/internal/database"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,7 +44,7 @@ func TestUserRepository_Create(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO users").
-			WithArgs(user.ID, user.Name, user.Email, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(user.ID, user.Name, user.Email, user.Timezone, user.IsGuest, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repo.Create(context.Background(), user)
@@ -63,12 +65,26 @@ func TestUserRepository_Create(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO users").
-			WithArgs(user.ID, user.Name, user.Email, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(user.ID, user.Name, user.Email, user.Timezone, user.IsGuest, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(errors.New("database error"))
 
 		err := repo.Create(context.Background(), user)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create user")
+		assert.NotErrorIs(t, err, utils.ErrConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Email", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectExec("INSERT INTO users").
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'test@example.com' for key 'email'"})
+
+		err := repo.Create(context.Background(), &models.User{ID: "user-1", Email: "test@example.com"})
+		assert.ErrorIs(t, err, utils.ErrConflict)
+		assert.EqualError(t, err, "email already exists")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -81,8 +97,8 @@ func TestUserRepository_GetByID(t *testing.T) {
 		userID := "user-1"
 		now := time.Now().UTC()
 
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
-			AddRow(userID, "Test User", "test@example.com", now, now)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+			AddRow(userID, "Test User", "test@example.com", "UTC", false, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM users WHERE id = \\?").
 			WithArgs(userID).
@@ -108,7 +124,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 			WillReturnError(sql.ErrNoRows)
 
 		user, err := repo.GetByID(context.Background(), userID)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "user not found")
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -132,6 +148,83 @@ func TestUserRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestUserRepository_IsDeleted(t *testing.T) {
+	const query = "SELECT deleted_at IS NOT NULL FROM users WHERE id = \\?"
+
+	t.Run("Deleted user", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs("user-1").
+			WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+
+		deleted, err := repo.IsDeleted(context.Background(), "user-1")
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs("external").
+			WillReturnError(sql.ErrNoRows)
+
+		deleted, err := repo.IsDeleted(context.Background(), "external")
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs("user-1").
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.IsDeleted(context.Background(), "user-1")
+		assert.Contains(t, err.Error(), "failed to get user")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_GetByIDs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+			AddRow("user-2", "Second", "second@example.com", "UTC", false, now, now)
+
+		mock.ExpectQuery("SELECT .+ FROM users WHERE id IN \\(\\?, \\?\\)").
+			WithArgs("user-1", "user-2").
+			WillReturnRows(rows)
+
+		users, err := repo.GetByIDs(context.Background(), []string{"user-1", "user-2"})
+		assert.NoError(t, err)
+		if assert.Len(t, users, 1) {
+			assert.Equal(t, "user-2", users[0].ID)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No IDs", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		users, err := repo.GetByIDs(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUserRepository_GetByEmail(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, mock, cleanup := setupUserRepoTest(t)
@@ -140,8 +233,8 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 		email := "test@example.com"
 		now := time.Now().UTC()
 
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
-			AddRow("user-1", "Test User", email, now, now)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+			AddRow("user-1", "Test User", email, "UTC", false, now, now)
 
 		mock.ExpectQuery("SELECT .+ FROM users WHERE email = \\?").
 			WithArgs(email).
@@ -184,7 +277,7 @@ func TestUserRepository_Update(t *testing.T) {
 		}
 
 		mock.ExpectExec("UPDATE users SET").
-			WithArgs(user.Name, user.Email, user.ID).
+			WithArgs(user.Name, user.Email, user.Timezone, user.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Update(context.Background(), user)
@@ -203,7 +296,7 @@ func TestUserRepository_Update(t *testing.T) {
 		}
 
 		mock.ExpectExec("UPDATE users SET").
-			WithArgs(user.Name, user.Email, user.ID).
+			WithArgs(user.Name, user.Email, user.Timezone, user.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err := repo.Update(context.Background(), user)
@@ -223,7 +316,7 @@ func TestUserRepository_Update(t *testing.T) {
 		}
 
 		mock.ExpectExec("UPDATE users SET").
-			WithArgs(user.Name, user.Email, user.ID).
+			WithArgs(user.Name, user.Email, user.Timezone, user.ID).
 			WillReturnError(errors.New("database error"))
 
 		err := repo.Update(context.Background(), user)
@@ -240,7 +333,7 @@ func TestUserRepository_Delete(t *testing.T) {
 
 		userID := "user-1"
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\?").
+		mock.ExpectExec("UPDATE users SET deleted_at = NOW\\(\\) WHERE id = \\? AND deleted_at IS NULL").
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -255,7 +348,7 @@ func TestUserRepository_Delete(t *testing.T) {
 
 		userID := "user-1"
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\?").
+		mock.ExpectExec("UPDATE users SET deleted_at = NOW\\(\\) WHERE id = \\? AND deleted_at IS NULL").
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -271,7 +364,7 @@ func TestUserRepository_Delete(t *testing.T) {
 
 		userID := "user-1"
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\?").
+		mock.ExpectExec("UPDATE users SET deleted_at = NOW\\(\\) WHERE id = \\? AND deleted_at IS NULL").
 			WithArgs(userID).
 			WillReturnError(errors.New("database error"))
 
@@ -282,17 +375,141 @@ func TestUserRepository_Delete(t *testing.T) {
 	})
 }
 
+func TestUserRepository_Restore(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectExec("UPDATE users SET deleted_at = NULL WHERE id = \\? AND deleted_at > \\?").
+			WithArgs("user-1", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.Restore(context.Background(), "user-1", cutoff))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to restore", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectExec("UPDATE users SET deleted_at = NULL").
+			WithArgs("user-1", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Restore(context.Background(), "user-1", cutoff)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Email taken since deletion", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		cutoff := time.Now().UTC()
+		mock.ExpectExec("UPDATE users SET deleted_at = NULL").
+			WithArgs("user-1", cutoff).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'test@example.com' for key 'uq_users_live_email'"})
+
+		err := repo.Restore(context.Background(), "user-1", cutoff)
+		assert.ErrorIs(t, err, utils.ErrConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_Purge(t *testing.T) {
+	repo, mock, cleanup := setupUserRepoTest(t)
+	defer cleanup()
+
+	cutoff := time.Now().UTC()
+	mock.ExpectExec("DELETE FROM users WHERE deleted_at <= \\?").
+		WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := repo.Purge(context.Background(), cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_Erase(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE users SET name = \\?, email = \\?, timezone = 'UTC'").
+			WithArgs("Erased user", "user-1@erased.invalid", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE event_participants SET response_reason = NULL WHERE user_id = \\?").
+			WithArgs("user-1").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery("SELECT .+ FROM users WHERE id = \\?").
+			WithArgs("user-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+				AddRow("user-1", "Erased user", "user-1@erased.invalid", "UTC", false, time.Now(), time.Now()))
+		mock.ExpectCommit()
+
+		user, err := repo.Erase(context.Background(), "user-1", "Erased user", "user-1@erased.invalid")
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1@erased.invalid", user.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Deleted user", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		// The update does not skip deleted users, and neither does the read
+		// of the result
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE users SET name = \\?, email = \\?, timezone = 'UTC', updated_at = NOW\\(\\)\\s+WHERE id = \\?$").
+			WithArgs("Erased user", "user-1@erased.invalid", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE event_participants SET response_reason = NULL").
+			WithArgs("user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT .+ FROM users WHERE id = \\?$").
+			WithArgs("user-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+				AddRow("user-1", "Erased user", "user-1@erased.invalid", "UTC", false, time.Now(), time.Now()))
+		mock.ExpectCommit()
+
+		user, err := repo.Erase(context.Background(), "user-1", "Erased user", "user-1@erased.invalid")
+		assert.NoError(t, err)
+		assert.Equal(t, "Erased user", user.Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		repo, mock, cleanup := setupUserRepoTest(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE users SET name").
+			WithArgs("Erased user", "user-1@erased.invalid", "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		user, err := repo.Erase(context.Background(), "user-1", "Erased user", "user-1@erased.invalid")
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.Nil(t, user)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUserRepository_List(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, mock, cleanup := setupUserRepoTest(t)
 		defer cleanup()
 
 		now := time.Now().UTC()
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
-			AddRow("user-1", "User 1", "user1@example.com", now, now).
-			AddRow("user-2", "User 2", "user2@example.com", now, now)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+			AddRow("user-1", "User 1", "user1@example.com", "UTC", false, now, now).
+			AddRow("user-2", "User 2", "user2@example.com", "UTC", false, now, now)
 
-		mock.ExpectQuery("SELECT .+ FROM users ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
+		mock.ExpectQuery("SELECT .+ FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
 			WithArgs(10, 0).
 			WillReturnRows(rows)
 
@@ -308,9 +525,9 @@ func TestUserRepository_List(t *testing.T) {
 		repo, mock, cleanup := setupUserRepoTest(t)
 		defer cleanup()
 
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"})
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"})
 
-		mock.ExpectQuery("SELECT .+ FROM users ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
+		mock.ExpectQuery("SELECT .+ FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
 			WithArgs(10, 0).
 			WillReturnRows(rows)
 
@@ -324,7 +541,7 @@ func TestUserRepository_List(t *testing.T) {
 		repo, mock, cleanup := setupUserRepoTest(t)
 		defer cleanup()
 
-		mock.ExpectQuery("SELECT .+ FROM users ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
+		mock.ExpectQuery("SELECT .+ FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
 			WithArgs(10, 0).
 			WillReturnError(errors.New("database error"))
 
@@ -339,10 +556,10 @@ func TestUserRepository_List(t *testing.T) {
 		repo, mock, cleanup := setupUserRepoTest(t)
 		defer cleanup()
 
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
-			AddRow("user-1", "User 1", "user1@example.com", "invalid-date", time.Now())
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "timezone", "is_guest", "created_at", "updated_at"}).
+			AddRow("user-1", "User 1", "user1@example.com", "UTC", false, "invalid-date", time.Now())
 
-		mock.ExpectQuery("SELECT .+ FROM users ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
+		mock.ExpectQuery("SELECT .+ FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\? OFFSET \\?").
 			WithArgs(10, 0).
 			WillReturnRows(rows)
 
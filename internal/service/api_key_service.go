@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// APIKeyService manages API keys for service-to-service calls. Every
+// operation requires an admin principal.
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// CreateAPIKey creates a key and returns it with its plaintext secret. The
+// secret cannot be retrieved again.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, utils.InvalidField("name", "name is required")
+	}
+
+	if req.UserID != "" {
+		if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := models.APIKey{
+		ID:      utils.GenerateAPIKeyID(),
+		Name:    name,
+		KeyHash: auth.HashAPIKey(secret),
+		UserID:  req.UserID,
+		IsAdmin: req.IsAdmin,
+	}
+	if err := s.apiKeyRepo.Create(ctx, &key); err != nil {
+		return nil, err
+	}
+
+	return &models.CreatedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+// ListAPIKeys lists all keys, including revoked ones. Secrets are never
+// returned.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepo.List(ctx)
+}
+
+// RevokeAPIKey revokes a key. Requests using it are rejected from then on.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	return s.apiKeyRepo.Revoke(ctx, id)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func adminContext() context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Kind: auth.PrincipalUser, Subject: "admin", Admin: true})
+}
+
+func TestAPIKeyService_CreateAPIKey_Success(t *testing.T) {
+	keyRepo := new(MockAPIKeyRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewAPIKeyService(keyRepo, userRepo)
+	ctx := adminContext()
+
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	var stored *models.APIKey
+	keyRepo.On("Create", ctx, mock.AnythingOfType("*models.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.APIKey) }).
+		Return(nil)
+
+	created, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyRequest{Name: " billing ", UserID: "u1"})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, "msk_"))
+	assert.Equal(t, "billing", created.Name)
+	assert.Equal(t, auth.HashAPIKey(created.Key), stored.KeyHash)
+	keyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_CreateAPIKey_RequiresAdmin(t *testing.T) {
+	keyRepo := new(MockAPIKeyRepository)
+	svc := NewAPIKeyService(keyRepo, new(MockUserRepository))
+
+	contexts := map[string]context.Context{
+		"anonymous": context.Background(),
+		"non-admin": auth.NewContext(context.Background(), &auth.Principal{Kind: auth.PrincipalUser, Subject: "u1"}),
+	}
+	for name, ctx := range contexts {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyRequest{Name: "billing"})
+			assert.ErrorIs(t, err, auth.ErrForbidden)
+		})
+	}
+	keyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_CreateAPIKey_Validation(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	svc := NewAPIKeyService(new(MockAPIKeyRepository), userRepo)
+	ctx := adminContext()
+
+	_, err := svc.CreateAPIKey(ctx, models.CreateAPIKeyRequest{Name: "  "})
+	assert.EqualError(t, err, "name is required")
+
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
+	_, err = svc.CreateAPIKey(ctx, models.CreateAPIKeyRequest{Name: "billing", UserID: "ghost"})
+	assert.EqualError(t, err, "user not found")
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	keyRepo := new(MockAPIKeyRepository)
+	svc := NewAPIKeyService(keyRepo, new(MockUserRepository))
+	ctx := adminContext()
+
+	keyRepo.On("Revoke", ctx, "key_1").Return(nil)
+
+	assert.NoError(t, svc.RevokeAPIKey(ctx, "key_1"))
+	assert.ErrorIs(t, svc.RevokeAPIKey(context.Background(), "key_1"), auth.ErrForbidden)
+	keyRepo.AssertNumberOfCalls(t, "Revoke", 1)
+}
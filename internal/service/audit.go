@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// auditChange describes a change to an entity for the audit log. before and
+// after are the entity around the change, stored as JSON; leave one nil when
+// the entity did not exist on that side of it. eventID names the event the
+// entity belongs to, if any.
+type auditChange struct {
+	action     string
+	entityType string
+	entityID   string
+	eventID    string
+	before     interface{}
+	after      interface{}
+}
+
+// recordAudit adds an entry for a change to the audit log, naming the caller
+// in ctx as its actor and the request in ctx as its origin. Call it in the
+// unit of work that makes the change, so the entry is only kept if the
+// change is.
+func recordAudit(ctx context.Context, auditRepo repository.AuditRepository, change auditChange) error {
+	before, err := auditState(change.before)
+	if err != nil {
+		return err
+	}
+	after, err := auditState(change.after)
+	if err != nil {
+		return err
+	}
+
+	return auditRepo.Record(ctx, &models.AuditEntry{
+		Actor:      auditActor(ctx),
+		Action:     change.action,
+		EntityType: change.entityType,
+		EntityID:   change.entityID,
+		EventID:    change.eventID,
+		Before:     before,
+		After:      after,
+		RequestID:  utils.RequestIDFromContext(ctx),
+	})
+}
+
+// recordParticipant records a change to an event's participant in the audit
+// log. before or after is nil when the participant was added or removed.
+func recordParticipant(
+	ctx context.Context,
+	auditRepo repository.AuditRepository,
+	action, eventID, userID string,
+	before, after *models.EventParticipant,
+) error {
+	return recordAudit(ctx, auditRepo, auditChange{
+		action:     action,
+		entityType: models.AuditEntityParticipant,
+		entityID:   userID,
+		eventID:    eventID,
+		before:     auditParticipant(before),
+		after:      auditParticipant(after),
+	})
+}
+
+// auditState encodes an entity for the audit log. A nil entity, including a
+// nil pointer, has no state.
+func auditState(entity interface{}) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	state, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit state: %w", err)
+	}
+	if string(state) == "null" {
+		return nil, nil
+	}
+	return state, nil
+}
+
+// auditActor names the caller in audit entries: the user they act as, or
+// the credential's subject when they act as no user. Internal calls have no
+// actor.
+func auditActor(ctx context.Context) string {
+	p := auth.FromContext(ctx)
+	switch {
+	case p == nil:
+		return ""
+	case p.UserID != "":
+		return p.UserID
+	default:
+		return p.Subject
+	}
+}
+
+// auditEvent is the state of an event recorded in the audit log. Its
+// participants are left out: they are audited on their own, and hold the
+// participants' personal data.
+func auditEvent(event *models.Event) *models.Event {
+	if event == nil {
+		return nil
+	}
+	state := *event
+	state.Participants = nil
+	state.ParticipantIDs = nil
+	state.AvailabilitySummary = nil
+	return &state
+}
+
+// auditParticipant is the state of a participant recorded in the audit log,
+// without the user's profile.
+func auditParticipant(participant *models.EventParticipant) *models.EventParticipant {
+	if participant == nil {
+		return nil
+	}
+	state := *participant
+	state.User = nil
+	return &state
+}
+
+// findParticipant returns the event's participant with userID, or nil.
+func findParticipant(event *models.Event, userID string) *models.EventParticipant {
+	for i := range event.Participants {
+		if event.Participants[i].UserID == userID {
+			return &event.Participants[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// AuditService reads the audit log, which the services that change events,
+// participants, availability, proposed slots and users write to.
+type AuditService struct {
+	auditRepo repository.AuditRepository
+	eventRepo repository.EventRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repository.AuditRepository, eventRepo repository.EventRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// EventHistory retrieves a page of the changes to an event and everything in
+// it, newest first. Whoever may see the event may see its history, also
+// after it is deleted and until it is purged; filter narrows the history
+// further.
+func (s *AuditService) EventHistory(ctx context.Context, eventID string, filter models.AuditFilter) (*models.AuditPage, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if errors.Is(err, utils.ErrNotFound) {
+		// Any deleted event, however long ago, still has its history
+		event, err = s.eventRepo.GetDeleted(ctx, eventID, time.Time{})
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, utils.NotFound("event not found")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, err
+	}
+
+	filter.EventID = eventID
+	return s.list(ctx, filter)
+}
+
+// ListAudit retrieves a page of the audit log, newest first. Only admins may
+// read the whole log.
+func (s *AuditService) ListAudit(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.list(ctx, filter)
+}
+
+// list applies the listing defaults to filter, validates it and retrieves
+// the page.
+func (s *AuditService) list(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, utils.InvalidField("to", "to must be after from")
+	}
+
+	page, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	page.Limit = filter.Limit
+	return page, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditService_EventHistory_Participant(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	eventRepo := new(MockEventRepository)
+	svc := NewAuditService(auditRepo, eventRepo)
+	ctx := userContext("p1")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	auditRepo.On("List", ctx, models.AuditFilter{EventID: "e1", Action: models.AuditActionParticipantRemove, Limit: 20}).
+		Return(&models.AuditPage{Entries: []*models.AuditEntry{{ID: 3}}, NextCursor: "next"}, nil)
+
+	page, err := svc.EventHistory(ctx, "e1", models.AuditFilter{EventID: "other", Action: models.AuditActionParticipantRemove})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 1)
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, "next", page.NextCursor)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_EventHistory_Outsider(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	eventRepo := new(MockEventRepository)
+	svc := NewAuditService(auditRepo, eventRepo)
+	ctx := userContext("stranger")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+
+	_, err := svc.EventHistory(ctx, "e1", models.AuditFilter{})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	auditRepo.AssertNotCalled(t, "List")
+}
+
+func TestAuditService_EventHistory_DeletedEvent(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	eventRepo := new(MockEventRepository)
+	svc := NewAuditService(auditRepo, eventRepo)
+	ctx := userContext("org")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(nil, utils.NotFound("event not found"))
+	eventRepo.On("GetDeleted", ctx, "e1", time.Time{}).Return(authzEvent(), nil)
+	auditRepo.On("List", ctx, models.AuditFilter{EventID: "e1", Limit: 20}).Return(&models.AuditPage{}, nil)
+
+	_, err := svc.EventHistory(ctx, "e1", models.AuditFilter{})
+
+	require.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_EventHistory_NotFound(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewAuditService(new(MockAuditRepository), eventRepo)
+	ctx := userContext("org")
+
+	eventRepo.On("GetByID", ctx, "missing").Return(nil, utils.NotFound("event not found"))
+	eventRepo.On("GetDeleted", ctx, "missing", time.Time{}).Return(nil, utils.NotFound("deleted event not found"))
+
+	_, err := svc.EventHistory(ctx, "missing", models.AuditFilter{})
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.EqualError(t, err, "event not found")
+}
+
+func TestAuditService_ListAudit_RequiresAdmin(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	svc := NewAuditService(auditRepo, new(MockEventRepository))
+
+	_, err := svc.ListAudit(userContext("org"), models.AuditFilter{})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	auditRepo.AssertNotCalled(t, "List")
+}
+
+func TestAuditService_ListAudit_CapsLimit(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	svc := NewAuditService(auditRepo, new(MockEventRepository))
+	ctx := adminContext()
+
+	auditRepo.On("List", ctx, models.AuditFilter{Actor: "org", Limit: 100}).Return(&models.AuditPage{}, nil)
+
+	page, err := svc.ListAudit(ctx, models.AuditFilter{Actor: "org", Limit: 500})
+
+	require.NoError(t, err)
+	assert.Equal(t, 100, page.Limit)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListAudit_InvalidRange(t *testing.T) {
+	auditRepo := new(MockAuditRepository)
+	svc := NewAuditService(auditRepo, new(MockEventRepository))
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.ListAudit(adminContext(), models.AuditFilter{From: from, To: from.Add(-time.Hour)})
+
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	auditRepo.AssertNotCalled(t, "List")
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLog returns an audit repository that accepts every entry, for tests
+// that do not check what is recorded.
+func auditLog() *MockAuditRepository {
+	audit := new(MockAuditRepository)
+	audit.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return audit
+}
+
+// recordedAudit returns an audit repository that keeps the entries recorded
+// in entries.
+func recordedAudit(entries *[]*models.AuditEntry) *MockAuditRepository {
+	audit := new(MockAuditRepository)
+	audit.On("Record", mock.Anything, mock.AnythingOfType("*models.AuditEntry")).
+		Run(func(args mock.Arguments) { *entries = append(*entries, args.Get(1).(*models.AuditEntry)) }).
+		Return(nil)
+	return audit
+}
+
+func TestRecordAudit(t *testing.T) {
+	var entries []*models.AuditEntry
+	ctx := utils.WithRequestID(userContext("org"), "req-1")
+
+	err := recordAudit(ctx, recordedAudit(&entries), auditChange{
+		action:     models.AuditActionParticipantUpdate,
+		entityType: models.AuditEntityParticipant,
+		entityID:   "p1",
+		eventID:    "e1",
+		before:     auditParticipant(&models.EventParticipant{UserID: "p1", Role: "attendee", User: &models.User{Name: "P One"}}),
+		after:      (*models.EventParticipant)(nil),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "org", entry.Actor)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, "e1", entry.EventID)
+	assert.JSONEq(t, `{"user_id":"p1","status":"","role":"attendee"}`, string(entry.Before))
+	assert.Nil(t, entry.After)
+}
+
+func TestAuditActor(t *testing.T) {
+	assert.Empty(t, auditActor(context.Background()))
+	assert.Equal(t, "org", auditActor(userContext("org")))
+	assert.Equal(t, "ci-key", auditActor(auth.NewContext(context.Background(), &auth.Principal{Subject: "ci-key", Admin: true})))
+}
+
+func TestAuditEvent_LeavesOutParticipants(t *testing.T) {
+	event := authzEvent()
+
+	state, err := json.Marshal(auditEvent(event))
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(state), "participants")
+	assert.Len(t, event.Participants, 3)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func userContext(userID string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Kind: auth.PrincipalUser, Subject: userID, UserID: userID})
+}
+
+// authzEvent is organized by "org", co-organized by "co" and attended by
+// "p1" and "p2".
+func authzEvent() *models.Event {
+	return &models.Event{
+		ID:          "e1",
+		OrganizerID: "org",
+		Participants: []models.EventParticipant{
+			{EventID: "e1", UserID: "co", Role: models.ParticipantRoleCoOrganizer},
+			{EventID: "e1", UserID: "p1", Role: models.ParticipantRoleAttendee},
+			{EventID: "e1", UserID: "p2", Role: models.ParticipantRoleAttendee},
+		},
+	}
+}
+
+func TestAuthorization_Rules(t *testing.T) {
+	event := authzEvent()
+	unbound := auth.NewContext(context.Background(), &auth.Principal{Kind: auth.PrincipalService, Subject: "apikey:key_1"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		manage   bool
+		view     bool
+		respond  bool // for participant "p1"
+		transfer bool
+	}{
+		{"trusted call", context.Background(), true, true, true, true},
+		{"admin", adminContext(), true, true, true, true},
+		{"organizer", userContext("org"), true, true, true, true},
+		{"co-organizer", userContext("co"), true, true, true, false},
+		{"participant for self", userContext("p1"), false, true, true, false},
+		{"other participant", userContext("p2"), false, true, false, false},
+		{"outsider", userContext("x"), false, false, false, false},
+		{"unbound service key", unbound, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := func(allowed bool, err error) {
+				t.Helper()
+				if allowed {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, auth.ErrForbidden)
+				}
+			}
+			check(tt.manage, authorizeManage(tt.ctx, event))
+			check(tt.view, authorizeView(tt.ctx, event))
+			check(tt.respond, authorizeRespond(tt.ctx, event, "p1"))
+			check(tt.transfer, authorizeTransfer(tt.ctx, event))
+		})
+	}
+}
+
+func TestEventService_UpdateEvent_Forbidden(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := userContext("p1")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+
+	err := svc.UpdateEvent(ctx, &models.Event{ID: "e1", Title: "Hijacked"})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	eventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEventService_RemoveParticipant_Forbidden(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	partRepo := new(MockParticipantRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), partRepo, auditLog(), new(MockUnitOfWork))
+	ctx := userContext("p1")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+
+	err := svc.RemoveParticipant(ctx, "e1", "p2")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	partRepo.AssertNotCalled(t, "RemoveParticipant", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventService_GetEvent_OutsiderForbidden(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := userContext("x")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+
+	event, err := svc.GetEvent(ctx, "e1")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	assert.Nil(t, event)
+}
+
+func TestEventService_CreateEvent_DefaultsOrganizerToCaller(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := userContext("org")
+
+	userRepo.On("GetByID", ctx, "org").Return(&models.User{ID: "org"}, nil)
+	eventRepo.On("Create", ctx, mock.AnythingOfType("*models.Event")).Return(nil)
+
+	event := baseEvent()
+	event.OrganizerID = ""
+	err := svc.CreateEvent(ctx, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, "org", event.OrganizerID)
+}
+
+func TestEventService_CreateEvent_ForAnotherOrganizerForbidden(t *testing.T) {
+	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+
+	err := svc.CreateEvent(userContext("p1"), &models.Event{OrganizerID: "org", DurationMinutes: 30})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+}
+
+func TestEventService_ListEvents_ScopedToMember(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := userContext("p1")
+
+	eventRepo.On("List", ctx, mock.MatchedBy(func(f models.EventFilter) bool {
+		return f.MemberID == "p1"
+	})).Return(&models.EventPage{Events: []*models.Event{}}, nil)
+
+	_, err := svc.ListEvents(ctx, models.EventFilter{})
+
+	require.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestEventService_ListUserEvents_OtherUserForbidden(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+
+	_, err := svc.ListUserEvents(userContext("p2"), "p1", "", models.EventFilter{})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = svc.ListUserInbox(userContext("p2"), "p1", models.EventFilter{})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	eventRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestAvailabilityService_SubmitAvailability_ForOtherParticipantForbidden(t *testing.T) {
+	svc, availRepo, eventRepo, _, _ := setupAvailabilitySvc()
+	ctx := userContext("p2")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "p1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	availRepo.AssertNotCalled(t, "SaveUserSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAvailabilityService_WithdrawAvailability_OrganizerOnBehalf(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := userContext("org")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	userRepo.On("GetByID", ctx, "p1").Return(&models.User{ID: "p1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "p1").Return(&models.EventParticipant{Status: models.ParticipantStatusInvited}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "p1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("DeleteUserSlots", ctx, "e1", "p1").Return(nil)
+
+	err := svc.WithdrawAvailability(ctx, "e1", "p1")
+
+	require.NoError(t, err)
+	availRepo.AssertExpectations(t)
+}
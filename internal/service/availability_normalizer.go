@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+)
+
+// normalizeAvailability fits submitted slots to the event's proposed windows
+// and merges overlapping or adjacent ranges. Slots reaching outside every
+// window are clipped (or dropped entirely) with a warning; in strict mode they
+// are rejected instead. Slots must already have been validated.
+func normalizeAvailability(
+	slots []models.AvailabilitySlot,
+	proposed []models.ProposedSlot,
+	strict bool,
+) ([]models.AvailabilitySlot, []models.AvailabilityWarning, error) {
+	windows := mergedWindows(proposed)
+
+	var warnings []models.AvailabilityWarning
+	var pieces []models.AvailabilitySlot
+
+	for i, slot := range slots {
+		original := utils.TimeSlot{
+			Start: utils.NormalizeToUTC(slot.StartTime),
+			End:   utils.NormalizeToUTC(slot.EndTime),
+		}
+
+		// Events without proposed windows accept availability unchanged
+		clipped := []utils.TimeSlot{original}
+		if len(windows) > 0 {
+			clipped = clipped[:0]
+			for _, w := range windows {
+				if part, ok := original.Intersect(w); ok {
+					clipped = append(clipped, part)
+				}
+			}
+		}
+
+		index := i
+		switch {
+		case len(clipped) == 0:
+			if strict {
+				return nil, nil, utils.Invalid("invalid time slot %d: outside every proposed window", i)
+			}
+			warnings = append(warnings, models.AvailabilityWarning{
+				Code:      models.AvailabilityWarningDropped,
+				SlotIndex: &index,
+				Message:   fmt.Sprintf("time slot %d is outside every proposed window and was ignored", i),
+			})
+		case len(clipped) > 1 || clipped[0] != original:
+			if strict {
+				return nil, nil, utils.Invalid("invalid time slot %d: extends outside the proposed windows", i)
+			}
+			warnings = append(warnings, models.AvailabilityWarning{
+				Code:      models.AvailabilityWarningClipped,
+				SlotIndex: &index,
+				Message:   fmt.Sprintf("time slot %d was clipped to the proposed windows", i),
+			})
+		}
+
+		for _, part := range clipped {
+			pieces = append(pieces, models.AvailabilitySlot{
+				EventID:   slot.EventID,
+				UserID:    slot.UserID,
+				StartTime: part.Start,
+				EndTime:   part.End,
+				Timezone:  slot.Timezone,
+			})
+		}
+	}
+
+	merged := mergeAvailability(pieces)
+	if len(merged) < len(pieces) {
+		warnings = append(warnings, models.AvailabilityWarning{
+			Code:    models.AvailabilityWarningMerged,
+			Message: fmt.Sprintf("%d overlapping or adjacent ranges were merged into %d", len(pieces), len(merged)),
+		})
+	}
+
+	return merged, warnings, nil
+}
+
+// mergeAvailability merges overlapping or adjacent slots. A merged slot keeps
+// the IDs and timezone of the earliest slot it absorbed.
+func mergeAvailability(slots []models.AvailabilitySlot) []models.AvailabilitySlot {
+	if len(slots) == 0 {
+		return []models.AvailabilitySlot{}
+	}
+
+	sorted := make([]models.AvailabilitySlot, len(slots))
+	copy(sorted, slots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	merged := []models.AvailabilitySlot{sorted[0]}
+	for _, slot := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if slot.StartTime.After(last.EndTime) {
+			merged = append(merged, slot)
+			continue
+		}
+		if slot.EndTime.After(last.EndTime) {
+			last.EndTime = slot.EndTime
+		}
+	}
+	return merged
+}
+
+// mergedWindows merges proposed slots into disjoint UTC windows.
+func mergedWindows(slots []models.ProposedSlot) []utils.TimeSlot {
+	windows := make([]utils.TimeSlot, 0, len(slots))
+	for _, p := range slots {
+		windows = append(windows, utils.TimeSlot{
+			Start: utils.NormalizeToUTC(p.StartTime),
+			End:   utils.NormalizeToUTC(p.EndTime),
+		})
+	}
+	return utils.MergeTimeSlots(windows)
+}
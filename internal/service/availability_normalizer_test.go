@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 2, 2, hour, minute, 0, 0, time.UTC)
+}
+
+func proposedWindows() []models.ProposedSlot {
+	return []models.ProposedSlot{
+		{StartTime: at(9, 0), EndTime: at(12, 0), Timezone: "UTC"},
+		{StartTime: at(14, 0), EndTime: at(17, 0), Timezone: "UTC"},
+	}
+}
+
+func TestNormalizeAvailability_InsideWindowsUnchanged(t *testing.T) {
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(9, 0), EndTime: at(10, 0), Timezone: "UTC"},
+		{StartTime: at(15, 0), EndTime: at(16, 0), Timezone: "UTC"},
+	}
+
+	result, warnings, err := normalizeAvailability(slots, proposedWindows(), false)
+
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Len(t, result, 2)
+}
+
+func TestNormalizeAvailability_ClipsToWindows(t *testing.T) {
+	// Spans the gap between both windows
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(11, 0), EndTime: at(15, 0), Timezone: "UTC"},
+	}
+
+	result, warnings, err := normalizeAvailability(slots, proposedWindows(), false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, at(11, 0), result[0].StartTime)
+	assert.Equal(t, at(12, 0), result[0].EndTime)
+	assert.Equal(t, at(14, 0), result[1].StartTime)
+	assert.Equal(t, at(15, 0), result[1].EndTime)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.AvailabilityWarningClipped, warnings[0].Code)
+	assert.Equal(t, 0, *warnings[0].SlotIndex)
+}
+
+func TestNormalizeAvailability_DropsSlotOutsideWindows(t *testing.T) {
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(9, 0), EndTime: at(10, 0), Timezone: "UTC"},
+		{StartTime: at(12, 30), EndTime: at(13, 30), Timezone: "UTC"},
+	}
+
+	result, warnings, err := normalizeAvailability(slots, proposedWindows(), false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.AvailabilityWarningDropped, warnings[0].Code)
+	assert.Equal(t, 1, *warnings[0].SlotIndex)
+}
+
+func TestNormalizeAvailability_StrictRejects(t *testing.T) {
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(11, 0), EndTime: at(13, 0), Timezone: "UTC"},
+	}
+
+	result, _, err := normalizeAvailability(slots, proposedWindows(), true)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "invalid time slot 0: extends outside the proposed windows")
+}
+
+func TestNormalizeAvailability_MergesOverlapsAndDuplicates(t *testing.T) {
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(10, 0), EndTime: at(11, 0), Timezone: "UTC"},
+		{StartTime: at(9, 0), EndTime: at(10, 0), Timezone: "UTC"},    // adjacent
+		{StartTime: at(10, 30), EndTime: at(11, 30), Timezone: "UTC"}, // overlapping
+		{StartTime: at(10, 30), EndTime: at(11, 30), Timezone: "UTC"}, // duplicate
+	}
+
+	result, warnings, err := normalizeAvailability(slots, proposedWindows(), true)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, at(9, 0), result[0].StartTime)
+	assert.Equal(t, at(11, 30), result[0].EndTime)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.AvailabilityWarningMerged, warnings[0].Code)
+}
+
+func TestNormalizeAvailability_NoProposedWindows(t *testing.T) {
+	slots := []models.AvailabilitySlot{
+		{StartTime: at(1, 0), EndTime: at(2, 0), Timezone: "UTC"},
+	}
+
+	result, warnings, err := normalizeAvailability(slots, nil, true)
+
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Len(t, result, 1)
+}
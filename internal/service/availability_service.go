@@ -2,17 +2,28 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
 )
 
+// maxResponseReasonLength matches the size of the response_reason column.
+const maxResponseReasonLength = 500
+
 // AvailabilityService handles participant availability business logic
 type AvailabilityService struct {
 	availabilityRepo repository.AvailabilityRepository
 	eventRepo        repository.EventRepository
 	participantRepo  repository.ParticipantRepository
 	userRepo         repository.UserRepository
+	idempotencyRepo  repository.IdempotencyRepository
+	auditRepo        repository.AuditRepository
+	uow              repository.UnitOfWork
 }
 
 // NewAvailabilityService creates a new availability service
@@ -21,94 +32,331 @@ func NewAvailabilityService(
 	eventRepo repository.EventRepository,
 	participantRepo repository.ParticipantRepository,
 	userRepo repository.UserRepository,
+	idempotencyRepo repository.IdempotencyRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
 ) *AvailabilityService {
 	return &AvailabilityService{
 		availabilityRepo: availabilityRepo,
 		eventRepo:        eventRepo,
 		participantRepo:  participantRepo,
 		userRepo:         userRepo,
+		idempotencyRepo:  idempotencyRepo,
+		auditRepo:        auditRepo,
+		uow:              uow,
 	}
 }
 
-// SubmitAvailability submits a participant's availability for an event
-func (s *AvailabilityService) SubmitAvailability(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot) error {
-	// Check if event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
-	if err != nil {
-		return fmt.Errorf("event not found")
+// SubmitAvailability submits a participant's availability for an event.
+// Slots are clipped to the event's proposed windows (or rejected in strict
+// mode) and overlapping ranges are merged before they are stored. Unless the
+// request says otherwise, the slots are merged into any existing availability.
+func (s *AvailabilityService) SubmitAvailability(ctx context.Context, eventID, userID string, req models.AvailabilityRequest) (*models.AvailabilitySubmission, error) {
+	return s.saveAvailability(ctx, eventID, userID, req, models.AvailabilityModeMerge, "Availability submitted successfully")
+}
+
+// UpdateAvailability stores a participant's availability like
+// SubmitAvailability, but replaces existing availability unless the request
+// says otherwise.
+func (s *AvailabilityService) UpdateAvailability(ctx context.Context, eventID, userID string, req models.AvailabilityRequest) (*models.AvailabilitySubmission, error) {
+	return s.saveAvailability(ctx, eventID, userID, req, models.AvailabilityModeReplace, "Availability updated successfully")
+}
+
+// saveAvailability normalizes and stores availability, and marks the
+// participant as responded, in one transaction. With an idempotency key a
+// retry of the same request returns the original result without writing
+// again. The key is recorded in the same transaction, so of two concurrent
+// requests with one key only the first is applied and the other replays it.
+func (s *AvailabilityService) saveAvailability(
+	ctx context.Context,
+	eventID, userID string,
+	req models.AvailabilityRequest,
+	defaultMode, message string,
+) (*models.AvailabilitySubmission, error) {
+	if req.Mode == "" {
+		req.Mode = defaultMode
+	}
+	if req.Mode != models.AvailabilityModeMerge && req.Mode != models.AvailabilityModeReplace {
+		return nil, utils.InvalidField("mode", "invalid mode %q: must be %q or %q",
+			req.Mode, models.AvailabilityModeMerge, models.AvailabilityModeReplace)
 	}
 
-	// Check if user exists
-	_, err = s.userRepo.GetByID(ctx, userID)
+	// Authorize before replaying so a stored result is never returned to a
+	// caller who could not have made the request
+	respond := func(ctx context.Context, event *models.Event) error {
+		return authorizeRespond(ctx, event, userID)
+	}
+	if err := authorizeEventID(ctx, s.eventRepo, eventID, respond); err != nil {
+		return nil, err
+	}
+
+	scope := fmt.Sprintf("availability:%s:%s", eventID, userID)
+	var requestHash string
+	if req.IdempotencyKey != "" {
+		var err error
+		if requestHash, err = hashRequest(req); err != nil {
+			return nil, err
+		}
+		previous, err := s.replayIdempotent(ctx, scope, req.IdempotencyKey, requestHash)
+		if err != nil || previous != nil {
+			return previous, err
+		}
+	}
+
+	event, _, err := s.checkParticipant(ctx, eventID, userID)
 	if err != nil {
-		return fmt.Errorf("user not found")
+		return nil, err
 	}
 
-	// Check if user is a participant of this event
-	_, err = s.participantRepo.GetParticipant(ctx, eventID, userID)
+	slots, warnings, err := s.prepareSlots(event, userID, req)
 	if err != nil {
-		return fmt.Errorf("participant not found")
+		return nil, err
 	}
 
-	// Validate slots
-	for i, slot := range slots {
-		if slot.EndTime.Before(slot.StartTime) || slot.EndTime.Equal(slot.StartTime) {
-			return fmt.Errorf("invalid time slot %d: end time must be after start time", i)
+	var result *models.AvailabilitySubmission
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		// Read once the event is locked, so concurrent merges see each
+		// other's slots
+		existing, err := s.availabilityRepo.GetByEventAndUser(ctx, eventID, userID)
+		if err != nil {
+			return err
 		}
-		// Set event and user IDs
-		slots[i].EventID = eventID
-		slots[i].UserID = userID
+		saved := slots
+		if req.Mode == models.AvailabilityModeMerge {
+			saved = mergeAvailability(append(append([]models.AvailabilitySlot{}, existing...), slots...))
+		}
+
+		if err := s.availabilityRepo.SaveUserSlots(ctx, eventID, userID, saved, models.ParticipantStatusResponded); err != nil {
+			return err
+		}
+		if err := s.recordAvailability(ctx, models.AuditActionAvailabilitySave, eventID, userID, existing, saved); err != nil {
+			return err
+		}
+
+		result = &models.AvailabilitySubmission{
+			Message:        message,
+			AvailableSlots: saved,
+			Warnings:       warnings,
+		}
+		if req.IdempotencyKey == "" {
+			return nil
+		}
+		return s.recordIdempotent(ctx, scope, req.IdempotencyKey, userID, requestHash, result)
+	})
+	if errors.Is(err, errIdempotencyKeyRecorded) {
+		// A concurrent request with the same key was applied first
+		return s.replayIdempotent(ctx, scope, req.IdempotencyKey, requestHash)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
 
-	// Create slots
-	err = s.availabilityRepo.CreateSlots(ctx, slots)
+// replayIdempotent returns the stored result for an idempotency key, or nil
+// when the key has not been used. Reusing a key for a different request is an
+// error.
+func (s *AvailabilityService) replayIdempotent(ctx context.Context, scope, key, requestHash string) (*models.AvailabilitySubmission, error) {
+	record, err := s.idempotencyRepo.Get(ctx, scope, key)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	if record.RequestHash != requestHash {
+		return nil, utils.Conflict("idempotency key %q was already used for a different request", key)
+	}
+
+	var previous models.AvailabilitySubmission
+	if err := json.Unmarshal(record.Response, &previous); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	return &previous, nil
+}
+
+// errIdempotencyKeyRecorded reports that another request recorded the same
+// idempotency key first.
+var errIdempotencyKeyRecorded = errors.New("idempotency key already recorded")
+
+// recordIdempotent stores result, which holds userID's availability, under an
+// idempotency key. It returns errIdempotencyKeyRecorded when the key is
+// already taken.
+func (s *AvailabilityService) recordIdempotent(ctx context.Context, scope, key, userID, requestHash string, result *models.AvailabilitySubmission) error {
+	response, err := json.Marshal(result)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	err = s.idempotencyRepo.Save(ctx, &models.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		UserID:      userID,
+		RequestHash: requestHash,
+		Response:    response,
+	})
+	if errors.Is(err, utils.ErrConflict) {
+		return errIdempotencyKeyRecorded
 	}
+	return err
+}
 
-	// Update participant status to responded
-	return s.participantRepo.UpdateParticipantStatus(ctx, eventID, userID, models.ParticipantStatusResponded)
+// hashRequest returns a SHA-256 fingerprint of the request body fields.
+func hashRequest(req models.AvailabilityRequest) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
 }
 
-// UpdateAvailability updates a participant's availability
-func (s *AvailabilityService) UpdateAvailability(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot) error {
+// checkParticipant loads the event and verifies that the user exists and is
+// one of its participants.
+func (s *AvailabilityService) checkParticipant(ctx context.Context, eventID, userID string) (*models.Event, *models.EventParticipant, error) {
 	// Check if event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("event not found")
+		return nil, nil, err
 	}
 
 	// Check if user exists
 	_, err = s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found")
+		return nil, nil, err
 	}
 
 	// Check if user is a participant of this event
-	_, err = s.participantRepo.GetParticipant(ctx, eventID, userID)
+	participant, err := s.participantRepo.GetParticipant(ctx, eventID, userID)
 	if err != nil {
-		return fmt.Errorf("participant not found")
+		return nil, nil, err
 	}
 
-	// Validate and set IDs
+	return event, participant, nil
+}
+
+// prepareSlots validates the submitted slots, stamps them with the event and
+// user IDs, and normalizes them against the event's proposed windows.
+func (s *AvailabilityService) prepareSlots(event *models.Event, userID string, req models.AvailabilityRequest) ([]models.AvailabilitySlot, []models.AvailabilityWarning, error) {
+	slots := req.AvailableSlots
 	for i := range slots {
-		if slots[i].EndTime.Before(slots[i].StartTime) || slots[i].EndTime.Equal(slots[i].StartTime) {
-			return fmt.Errorf("invalid time slot %d: end time must be after start time", i)
+		if err := validateSlot(i, slots[i].StartTime, slots[i].EndTime, slots[i].Timezone); err != nil {
+			return nil, nil, err
 		}
-		slots[i].EventID = eventID
+		slots[i].EventID = event.ID
 		slots[i].UserID = userID
 	}
 
-	// Update slots (delete old, insert new)
-	return s.availabilityRepo.UpdateUserSlots(ctx, eventID, userID, slots)
+	return normalizeAvailability(slots, event.ProposedSlots, req.Strict)
 }
 
-// GetAvailability retrieves a participant's availability
-func (s *AvailabilityService) GetAvailability(ctx context.Context, eventID, userID string) ([]models.AvailabilitySlot, error) {
-	return s.availabilityRepo.GetByEventAndUser(ctx, eventID, userID)
+// WithdrawAvailability removes all of a participant's availability for an
+// event. A participant who had responded goes back to invited; a declined or
+// tentative RSVP is left as it is.
+func (s *AvailabilityService) WithdrawAvailability(ctx context.Context, eventID, userID string) error {
+	event, participant, err := s.checkParticipant(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeRespond(ctx, event, userID); err != nil {
+		return err
+	}
+
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		existing, err := s.availabilityRepo.GetByEventAndUser(ctx, eventID, userID)
+		if err != nil {
+			return err
+		}
+		if participant.Status == models.ParticipantStatusResponded {
+			err = s.availabilityRepo.SaveUserSlots(ctx, eventID, userID, nil, models.ParticipantStatusInvited)
+		} else {
+			err = s.availabilityRepo.DeleteUserSlots(ctx, eventID, userID)
+		}
+		if err != nil {
+			return err
+		}
+		return s.recordAvailability(ctx, models.AuditActionAvailabilityWithdraw, eventID, userID, existing, nil)
+	})
+	return err
+}
+
+// recordAvailability records a change to a participant's availability in
+// the audit log. A participant without slots has no availability to record.
+func (s *AvailabilityService) recordAvailability(ctx context.Context, action, eventID, userID string, before, after []models.AvailabilitySlot) error {
+	change := auditChange{
+		action:     action,
+		entityType: models.AuditEntityAvailability,
+		entityID:   userID,
+		eventID:    eventID,
+	}
+	if len(before) > 0 {
+		change.before = before
+	}
+	if len(after) > 0 {
+		change.after = after
+	}
+	return recordAudit(ctx, s.auditRepo, change)
+}
+
+// RespondToInvitation records a declined or tentative RSVP with an optional
+// reason. Submitted availability is kept, but recommendations ignore
+// participants who have declined.
+func (s *AvailabilityService) RespondToInvitation(ctx context.Context, eventID, userID string, req models.RSVPRequest) (*models.EventParticipant, error) {
+	if req.Status != models.ParticipantStatusDeclined && req.Status != models.ParticipantStatusTentative {
+		return nil, utils.InvalidField("status", "invalid status %q: must be %q or %q",
+			req.Status, models.ParticipantStatusDeclined, models.ParticipantStatusTentative)
+	}
+	if len([]rune(req.Reason)) > maxResponseReasonLength {
+		return nil, utils.InvalidField("reason", "reason must be at most %d characters", maxResponseReasonLength)
+	}
+
+	event, participant, err := s.checkParticipant(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeRespond(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
+	updated := *participant
+	updated.Status = req.Status
+	updated.ResponseReason = req.Reason
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.UpdateParticipantResponse(ctx, eventID, userID, req.Status, req.Reason); err != nil {
+			return err
+		}
+		return recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantRespond, eventID, userID, participant, &updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// GetAvailability retrieves a participant's availability and the version of
+// the event it was read at. The version is read first, so it never claims
+// changes the slots do not reflect.
+func (s *AvailabilityService) GetAvailability(ctx context.Context, eventID, userID string) ([]models.AvailabilitySlot, int, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, 0, err
+	}
+
+	slots, err := s.availabilityRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slots, event.Version, nil
 }
 
 // GetEventAvailability retrieves all availability for an event
 func (s *AvailabilityService) GetEventAvailability(ctx context.Context, eventID string) ([]models.AvailabilitySlot, error) {
+	if err := authorizeEventID(ctx, s.eventRepo, eventID, authorizeView); err != nil {
+		return nil, err
+	}
 	return s.availabilityRepo.GetByEvent(ctx, eventID)
 }
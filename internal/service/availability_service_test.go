@@ -3,13 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAvailabilityService_SubmitAvailability_Success(t *testing.T) {
@@ -20,10 +24,11 @@ func TestAvailabilityService_SubmitAvailability_Success(t *testing.T) {
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
 	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
-	availRepo.On("CreateSlots", ctx, mock.AnythingOfType("[]models.AvailabilitySlot")).Return(nil)
-	partRepo.On("UpdateParticipantStatus", ctx, "e1", "u1", models.ParticipantStatusResponded).Return(nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
 
-	err := svc.SubmitAvailability(ctx, "e1", "u1", slots)
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: slots})
 
 	assert.NoError(t, err)
 	// IDs should be injected into each slot
@@ -39,22 +44,37 @@ func TestAvailabilityService_SubmitAvailability_EventNotFound(t *testing.T) {
 	svc, _, eventRepo, _, _ := setupAvailabilitySvc()
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
-	err := svc.SubmitAvailability(ctx, "ghost", "u1", validAvailabilitySlots())
+	_, err := svc.SubmitAvailability(ctx, "ghost", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "event not found")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
 	eventRepo.AssertExpectations(t)
 }
 
+func TestAvailabilityService_SubmitAvailability_DatabaseErrorIsNotAClientError(t *testing.T) {
+	svc, _, eventRepo, _, _ := setupAvailabilitySvc()
+	ctx := context.Background()
+	outage := errors.New("failed to get event: connection refused")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(nil, outage)
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
+
+	assert.ErrorIs(t, err, outage)
+	status, _ := utils.ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
 func TestAvailabilityService_SubmitAvailability_UserNotFound(t *testing.T) {
 	svc, _, eventRepo, _, userRepo := setupAvailabilitySvc()
 	ctx := context.Background()
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
-	userRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
 
-	err := svc.SubmitAvailability(ctx, "e1", "ghost", validAvailabilitySlots())
+	_, err := svc.SubmitAvailability(ctx, "e1", "ghost", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "user not found")
 	eventRepo.AssertExpectations(t)
@@ -67,11 +87,12 @@ func TestAvailabilityService_SubmitAvailability_ParticipantNotFound(t *testing.T
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
-	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, errors.New("not found"))
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, utils.NotFound("participant not found"))
 
-	err := svc.SubmitAvailability(ctx, "e1", "u1", validAvailabilitySlots())
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "participant not found")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
 	partRepo.AssertExpectations(t)
 }
 
@@ -90,7 +111,7 @@ func TestAvailabilityService_SubmitAvailability_InvalidSlotTimes(t *testing.T) {
 		},
 	}
 
-	err := svc.SubmitAvailability(ctx, "e1", "u1", badSlots)
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: badSlots})
 
 	assert.ErrorContains(t, err, "invalid time slot 0")
 }
@@ -102,9 +123,11 @@ func TestAvailabilityService_SubmitAvailability_RepoError(t *testing.T) {
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
 	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
-	availRepo.On("CreateSlots", ctx, mock.AnythingOfType("[]models.AvailabilitySlot")).Return(errors.New("db error"))
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(errors.New("db error"))
 
-	err := svc.SubmitAvailability(ctx, "e1", "u1", validAvailabilitySlots())
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "db error")
 	availRepo.AssertExpectations(t)
@@ -118,9 +141,11 @@ func TestAvailabilityService_UpdateAvailability_Success(t *testing.T) {
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
 	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
-	availRepo.On("UpdateUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot")).Return(nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
 
-	err := svc.UpdateAvailability(ctx, "e1", "u1", slots)
+	_, err := svc.UpdateAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: slots})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "e1", slots[0].EventID)
@@ -132,9 +157,9 @@ func TestAvailabilityService_UpdateAvailability_EventNotFound(t *testing.T) {
 	svc, _, eventRepo, _, _ := setupAvailabilitySvc()
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
-	err := svc.UpdateAvailability(ctx, "ghost", "u1", validAvailabilitySlots())
+	_, err := svc.UpdateAvailability(ctx, "ghost", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "event not found")
 	eventRepo.AssertExpectations(t)
@@ -145,9 +170,9 @@ func TestAvailabilityService_UpdateAvailability_UserNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
-	userRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
 
-	err := svc.UpdateAvailability(ctx, "e1", "ghost", validAvailabilitySlots())
+	_, err := svc.UpdateAvailability(ctx, "e1", "ghost", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "user not found")
 }
@@ -158,11 +183,12 @@ func TestAvailabilityService_UpdateAvailability_ParticipantNotFound(t *testing.T
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
-	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, errors.New("not found"))
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, utils.NotFound("participant not found"))
 
-	err := svc.UpdateAvailability(ctx, "e1", "u1", validAvailabilitySlots())
+	_, err := svc.UpdateAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
 
 	assert.EqualError(t, err, "participant not found")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
 }
 
 func TestAvailabilityService_UpdateAvailability_InvalidSlotTimes(t *testing.T) {
@@ -180,32 +206,35 @@ func TestAvailabilityService_UpdateAvailability_InvalidSlotTimes(t *testing.T) {
 		},
 	}
 
-	err := svc.UpdateAvailability(ctx, "e1", "u1", badSlots)
+	_, err := svc.UpdateAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: badSlots})
 
 	assert.ErrorContains(t, err, "invalid time slot 0")
 }
 
 func TestAvailabilityService_GetAvailability_Success(t *testing.T) {
-	svc, availRepo, _, _, _ := setupAvailabilitySvc()
+	svc, availRepo, eventRepo, _, _ := setupAvailabilitySvc()
 	ctx := context.Background()
 
 	expected := []models.AvailabilitySlot{{EventID: "e1", UserID: "u1"}}
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", Version: 7}, nil)
 	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return(expected, nil)
 
-	result, err := svc.GetAvailability(ctx, "e1", "u1")
+	result, version, err := svc.GetAvailability(ctx, "e1", "u1")
 
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
+	assert.Equal(t, 7, version)
 	availRepo.AssertExpectations(t)
 }
 
 func TestAvailabilityService_GetAvailability_RepoError(t *testing.T) {
-	svc, availRepo, _, _, _ := setupAvailabilitySvc()
+	svc, availRepo, eventRepo, _, _ := setupAvailabilitySvc()
 	ctx := context.Background()
 
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", Version: 7}, nil)
 	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, errors.New("db error"))
 
-	result, err := svc.GetAvailability(ctx, "e1", "u1")
+	result, _, err := svc.GetAvailability(ctx, "e1", "u1")
 
 	assert.EqualError(t, err, "db error")
 	assert.Empty(t, result)
@@ -250,6 +279,316 @@ func setupAvailabilitySvc() (
 	event := new(MockEventRepository)
 	part := new(MockParticipantRepository)
 	user := new(MockUserRepository)
-	svc := NewAvailabilityService(avail, event, part, user)
+	svc := NewAvailabilityService(avail, event, part, user, new(MockIdempotencyRepository), auditLog(), new(MockUnitOfWork))
 	return svc, avail, event, part, user
 }
+
+func TestAvailabilityService_SubmitAvailability_InvalidTimezone(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+
+	slots := validAvailabilitySlots()
+	slots[0].Timezone = "EST5EDT-ish"
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: slots})
+
+	assert.ErrorContains(t, err, "invalid time slot 0")
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
+func TestAvailabilityService_SubmitAvailability_MergesWithExisting(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	existing := []models.AvailabilitySlot{
+		{
+			ID:        7,
+			StartTime: time.Date(2025, 1, 12, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC),
+			Timezone:  "UTC",
+		},
+	}
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return(existing, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+
+	result, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
+
+	assert.NoError(t, err)
+	// 08:00-09:00 and 09:00-10:00 are adjacent and become one range
+	assert.Len(t, result.AvailableSlots, 1)
+	assert.Equal(t, time.Date(2025, 1, 12, 8, 0, 0, 0, time.UTC), result.AvailableSlots[0].StartTime)
+	assert.Equal(t, time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC), result.AvailableSlots[0].EndTime)
+	availRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_SubmitAvailability_ReplaceModeDropsExisting(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	var entries []*models.AuditEntry
+	svc.auditRepo = recordedAudit(&entries)
+	ctx := context.Background()
+
+	existing := []models.AvailabilitySlot{
+		{
+			ID:        7,
+			StartTime: time.Date(2025, 1, 12, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC),
+			Timezone:  "UTC",
+		},
+	}
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return(existing, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+
+	result, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{
+		AvailableSlots: validAvailabilitySlots(),
+		Mode:           models.AvailabilityModeReplace,
+	})
+
+	assert.NoError(t, err)
+	require.Len(t, result.AvailableSlots, 1)
+	assert.Equal(t, time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC), result.AvailableSlots[0].StartTime)
+	availRepo.AssertExpectations(t)
+
+	// The audit log keeps the availability that was replaced
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AuditActionAvailabilitySave, entries[0].Action)
+	assert.Equal(t, "u1", entries[0].EntityID)
+	assert.Equal(t, "e1", entries[0].EventID)
+	assert.Contains(t, string(entries[0].Before), "08:00:00Z")
+	assert.NotContains(t, string(entries[0].After), "08:00:00Z")
+}
+
+func TestAvailabilityService_SubmitAvailability_InvalidMode(t *testing.T) {
+	svc, _, _, _, _ := setupAvailabilitySvc()
+
+	_, err := svc.SubmitAvailability(context.Background(), "e1", "u1", models.AvailabilityRequest{Mode: "append"})
+
+	assert.ErrorContains(t, err, `invalid mode "append"`)
+}
+
+func TestAvailabilityService_SubmitAvailability_IdempotentReplay(t *testing.T) {
+	svc, availRepo, _, _, _ := setupAvailabilitySvc()
+	idemRepo := svc.idempotencyRepo.(*MockIdempotencyRepository)
+	ctx := context.Background()
+
+	req := models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots(), IdempotencyKey: "key-1"}
+	req.Mode = models.AvailabilityModeMerge
+	hash, err := hashRequest(req)
+	assert.NoError(t, err)
+
+	idemRepo.On("Get", ctx, "availability:e1:u1", "key-1").Return(&models.IdempotencyRecord{
+		RequestHash: hash,
+		Response:    []byte(`{"message":"Availability submitted successfully","available_slots":[]}`),
+	}, nil)
+
+	result, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{
+		AvailableSlots: validAvailabilitySlots(),
+		IdempotencyKey: "key-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Availability submitted successfully", result.Message)
+	availRepo.AssertNotCalled(t, "SaveUserSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	idemRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_SubmitAvailability_IdempotencyKeyReused(t *testing.T) {
+	svc, _, _, _, _ := setupAvailabilitySvc()
+	idemRepo := svc.idempotencyRepo.(*MockIdempotencyRepository)
+	ctx := context.Background()
+
+	idemRepo.On("Get", ctx, "availability:e1:u1", "key-1").Return(&models.IdempotencyRecord{
+		RequestHash: "something-else",
+	}, nil)
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{
+		AvailableSlots: validAvailabilitySlots(),
+		IdempotencyKey: "key-1",
+	})
+
+	assert.ErrorContains(t, err, "already used for a different request")
+}
+
+func TestAvailabilityService_SubmitAvailability_RecordsIdempotencyKey(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	idemRepo := svc.idempotencyRepo.(*MockIdempotencyRepository)
+	ctx := context.Background()
+
+	idemRepo.On("Get", ctx, "availability:e1:u1", "key-1").Return(nil, nil)
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+	idemRepo.On("Save", ctx, mock.MatchedBy(func(r *models.IdempotencyRecord) bool {
+		return r.Scope == "availability:e1:u1" && r.Key == "key-1" && r.UserID == "u1" && len(r.Response) > 0
+	})).Return(nil)
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{
+		AvailableSlots: validAvailabilitySlots(),
+		IdempotencyKey: "key-1",
+	})
+
+	assert.NoError(t, err)
+	idemRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_SubmitAvailability_ConcurrentIdempotencyKey(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	idemRepo := svc.idempotencyRepo.(*MockIdempotencyRepository)
+	ctx := context.Background()
+
+	req := models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots(), IdempotencyKey: "key-1"}
+	req.Mode = models.AvailabilityModeMerge
+	hash, err := hashRequest(req)
+	require.NoError(t, err)
+
+	// The other request records the key between the first lookup and the
+	// insert, so the insert conflicts and its result is replayed instead
+	idemRepo.On("Get", ctx, "availability:e1:u1", "key-1").Return(nil, nil).Once()
+	idemRepo.On("Get", ctx, "availability:e1:u1", "key-1").Return(&models.IdempotencyRecord{
+		RequestHash: hash,
+		Response:    []byte(`{"message":"Availability submitted successfully","available_slots":[]}`),
+	}, nil).Once()
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+	idemRepo.On("Save", ctx, mock.AnythingOfType("*models.IdempotencyRecord")).Return(utils.Conflict("idempotency key \"key-1\" is already recorded"))
+
+	result, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{
+		AvailableSlots: validAvailabilitySlots(),
+		IdempotencyKey: "key-1",
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.AvailableSlots)
+	idemRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_SubmitAvailability_MergeReadsInTransaction(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	var touched bool
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Run(func(mock.Arguments) { touched = true }).Return(2, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Run(func(mock.Arguments) {
+		assert.True(t, touched, "existing slots must be read after the event is locked")
+	}).Return([]models.AvailabilitySlot{}, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+
+	_, err := svc.SubmitAvailability(ctx, "e1", "u1", models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
+
+	require.NoError(t, err)
+	availRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_WithdrawAvailability_ResetsRespondedStatus(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{Status: models.ParticipantStatusResponded}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return(validAvailabilitySlots(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", ctx, "e1", "u1", []models.AvailabilitySlot(nil), models.ParticipantStatusInvited).Return(nil)
+
+	err := svc.WithdrawAvailability(ctx, "e1", "u1")
+
+	assert.NoError(t, err)
+	availRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_WithdrawAvailability_KeepsRSVP(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{Status: models.ParticipantStatusTentative}, nil)
+	availRepo.On("GetByEventAndUser", ctx, "e1", "u1").Return(validAvailabilitySlots(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	availRepo.On("DeleteUserSlots", ctx, "e1", "u1").Return(nil)
+
+	err := svc.WithdrawAvailability(ctx, "e1", "u1")
+
+	assert.NoError(t, err)
+	availRepo.AssertExpectations(t)
+	availRepo.AssertNotCalled(t, "SaveUserSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAvailabilityService_WithdrawAvailability_ParticipantNotFound(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, utils.NotFound("participant not found"))
+
+	err := svc.WithdrawAvailability(ctx, "e1", "u1")
+
+	assert.EqualError(t, err, "participant not found")
+}
+
+func TestAvailabilityService_RespondToInvitation_Declined(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupAvailabilitySvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(&models.EventParticipant{Status: models.ParticipantStatusResponded}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	partRepo.On("UpdateParticipantResponse", ctx, "e1", "u1", models.ParticipantStatusDeclined, "On holiday").Return(nil)
+
+	participant, err := svc.RespondToInvitation(ctx, "e1", "u1", models.RSVPRequest{
+		Status: models.ParticipantStatusDeclined,
+		Reason: "On holiday",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ParticipantStatusDeclined, participant.Status)
+	assert.Equal(t, "On holiday", participant.ResponseReason)
+	partRepo.AssertExpectations(t)
+}
+
+func TestAvailabilityService_RespondToInvitation_InvalidStatus(t *testing.T) {
+	svc, _, _, partRepo, _ := setupAvailabilitySvc()
+
+	_, err := svc.RespondToInvitation(context.Background(), "e1", "u1", models.RSVPRequest{
+		Status: models.ParticipantStatusResponded,
+	})
+
+	assert.ErrorContains(t, err, "invalid status")
+	partRepo.AssertNotCalled(t, "UpdateParticipantResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAvailabilityService_RespondToInvitation_ReasonTooLong(t *testing.T) {
+	svc, _, _, _, _ := setupAvailabilitySvc()
+
+	_, err := svc.RespondToInvitation(context.Background(), "e1", "u1", models.RSVPRequest{
+		Status: models.ParticipantStatusTentative,
+		Reason: strings.Repeat("x", maxResponseReasonLength+1),
+	})
+
+	assert.ErrorContains(t, err, "reason must be at most")
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"meeting-slot-service/internal/auth"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/repository"
 	"meeting-slot-service/internal/utils"
+	"strconv"
 )
 
 // EventService handles event business logic
@@ -13,6 +17,8 @@ type EventService struct {
 	eventRepo       repository.EventRepository
 	userRepo        repository.UserRepository
 	participantRepo repository.ParticipantRepository
+	auditRepo       repository.AuditRepository
+	uow             repository.UnitOfWork
 }
 
 // NewEventService creates a new event service
@@ -20,21 +26,36 @@ func NewEventService(
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
 	participantRepo repository.ParticipantRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
 ) *EventService {
 	return &EventService{
 		eventRepo:       eventRepo,
 		userRepo:        userRepo,
 		participantRepo: participantRepo,
+		auditRepo:       auditRepo,
+		uow:             uow,
 	}
 }
 
-// CreateEvent creates a new event with proposed slots
+// CreateEvent creates a new event with proposed slots and invites the users
+// in ParticipantIDs. Either all of it is stored or none of it is.
 func (s *EventService) CreateEvent(ctx context.Context, event *models.Event) error {
 	// Generate event ID
 	if event.ID == "" {
 		event.ID = utils.GenerateEventID()
 	}
 
+	// Callers organize their own events
+	if p := auth.FromContext(ctx); !unrestricted(p) {
+		if event.OrganizerID == "" {
+			event.OrganizerID = p.UserID
+		}
+		if p.UserID == "" || event.OrganizerID != p.UserID {
+			return utils.Forbidden("events can only be created with yourself as organizer")
+		}
+	}
+
 	// Validate organizer exists
 	_, err := s.userRepo.GetByID(ctx, event.OrganizerID)
 	if err != nil {
@@ -43,17 +64,24 @@ func (s *EventService) CreateEvent(ctx context.Context, event *models.Event) err
 
 	// Validate duration
 	if event.DurationMinutes <= 0 {
-		return fmt.Errorf("invalid duration: must be greater than 0")
+		return utils.InvalidField("duration_minutes", "invalid duration: must be greater than 0")
 	}
 
 	// Validate proposed slots
 	if len(event.ProposedSlots) == 0 {
-		return fmt.Errorf("at least one proposed slot is required")
+		return utils.InvalidField("proposed_slots", "at least one proposed slot is required")
 	}
 
 	for i, slot := range event.ProposedSlots {
-		if slot.EndTime.Before(slot.StartTime) || slot.EndTime.Equal(slot.StartTime) {
-			return fmt.Errorf("invalid time slot %d: end time must be after start time", i)
+		if err := validateSlot(i, slot.StartTime, slot.EndTime, slot.Timezone); err != nil {
+			return err
+		}
+	}
+
+	// Validate invited users exist
+	for _, userID := range event.ParticipantIDs {
+		if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+			return err
 		}
 	}
 
@@ -62,16 +90,63 @@ func (s *EventService) CreateEvent(ctx context.Context, event *models.Event) err
 		event.Status = models.EventStatusPending
 	}
 
-	// Create event
-	return s.eventRepo.Create(ctx, event)
+	// Create event and invitations together
+	participants := make([]models.EventParticipant, 0, len(event.ParticipantIDs))
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Create(ctx, event); err != nil {
+			return err
+		}
+		err := recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionEventCreate,
+			entityType: models.AuditEntityEvent,
+			entityID:   event.ID,
+			eventID:    event.ID,
+			after:      auditEvent(event),
+		})
+		if err != nil {
+			return err
+		}
+		for _, userID := range event.ParticipantIDs {
+			participant := models.EventParticipant{
+				EventID: event.ID,
+				UserID:  userID,
+				Status:  models.ParticipantStatusInvited,
+				Role:    models.ParticipantRoleAttendee,
+			}
+			if err := s.participantRepo.AddParticipant(ctx, &participant); err != nil {
+				return err
+			}
+			if err := recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantAdd, event.ID, userID, nil, &participant); err != nil {
+				return err
+			}
+			participants = append(participants, participant)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	event.Participants = participants
+	return nil
 }
 
 // GetEvent retrieves an event by ID
 func (s *EventService) GetEvent(ctx context.Context, eventID string) (*models.Event, error) {
-	return s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
 }
 
-// UpdateEvent updates an existing event
+// UpdateEvent replaces an existing event. Proposed slots are kept when none
+// are given, and so is the status.
 func (s *EventService) UpdateEvent(ctx context.Context, event *models.Event) error {
 	// Check if event exists
 	existing, err := s.eventRepo.GetByID(ctx, event.ID)
@@ -79,28 +154,293 @@ func (s *EventService) UpdateEvent(ctx context.Context, event *models.Event) err
 		return err
 	}
 
+	if err := authorizeManage(ctx, existing); err != nil {
+		return err
+	}
+
+	if event.Status == "" {
+		event.Status = existing.Status
+	}
+
+	return s.saveEvent(ctx, existing, event)
+}
+
+// eventReadOnlyFields are the event members a patch may not set. The
+// organizer changes through TransferOwnership and participants through their
+// own endpoints.
+var eventReadOnlyFields = []string{
+	"id", "organizer_id", "participants", "participant_ids", "availability_summary", "version", "created_at", "updated_at",
+}
+
+// PatchEvent applies a JSON merge patch (RFC 7396) to an event, so only the
+// fields sent change. proposed_slots may be an array, which replaces all
+// slots, or an object keyed by slot ID whose members patch that slot, or
+// remove it when null.
+func (s *EventService) PatchEvent(ctx context.Context, eventID string, patch []byte) (*models.Event, error) {
+	existing, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	doc, err := patchDocument(patch, eventReadOnlyFields...)
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := doc["proposed_slots"]; ok && isJSONObject(raw) {
+		slots, err := patchSlots(existing.ProposedSlots, raw)
+		if err != nil {
+			return nil, err
+		}
+		if doc["proposed_slots"], err = json.Marshal(slots); err != nil {
+			return nil, err
+		}
+		if patch, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	var event models.Event
+	if err := utils.ApplyMergePatch(existing, patch, &event); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	if len(event.ProposedSlots) == 0 {
+		return nil, utils.InvalidField("proposed_slots", "at least one proposed slot is required")
+	}
+
+	if err := s.saveEvent(ctx, existing, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// patchSlots applies a proposed_slots patch keyed by slot ID to slots and
+// returns the result.
+func patchSlots(slots []models.ProposedSlot, raw json.RawMessage) ([]models.ProposedSlot, error) {
+	var patches map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &patches); err != nil {
+		return nil, utils.InvalidField("proposed_slots", "proposed_slots must be an array or an object keyed by slot ID")
+	}
+
+	byID := make(map[string]int, len(slots))
+	for i, slot := range slots {
+		byID[strconv.FormatUint(uint64(slot.ID), 10)] = i
+	}
+	removed := make(map[int]bool)
+	patched := make([]models.ProposedSlot, len(slots))
+	copy(patched, slots)
+
+	for id, slotPatch := range patches {
+		i, ok := byID[id]
+		if !ok {
+			return nil, utils.NotFound("proposed slot %s not found", id)
+		}
+		if string(slotPatch) == "null" {
+			removed[i] = true
+			continue
+		}
+		var slot models.ProposedSlot
+		if err := utils.ApplyMergePatch(slots[i], slotPatch, &slot); err != nil {
+			return nil, err
+		}
+		slot.ID = slots[i].ID
+		patched[i] = slot
+	}
+
+	result := make([]models.ProposedSlot, 0, len(patched))
+	for i, slot := range patched {
+		if !removed[i] {
+			result = append(result, slot)
+		}
+	}
+	return result, nil
+}
+
+// saveEvent validates the new state of an existing event and stores it. The
+// audit entry is recorded once the version is bumped, so it shows the
+// version the change produced.
+func (s *EventService) saveEvent(ctx context.Context, existing, event *models.Event) error {
+	known := make(map[uint]bool, len(existing.ProposedSlots))
+	for _, slot := range existing.ProposedSlots {
+		known[slot.ID] = true
+	}
+	for i, slot := range event.ProposedSlots {
+		if err := validateSlot(i, slot.StartTime, slot.EndTime, slot.Timezone); err != nil {
+			return err
+		}
+		if slot.ID != 0 && !known[slot.ID] {
+			return utils.NotFound("proposed slot %d not found", slot.ID)
+		}
+		event.ProposedSlots[i].EventID = existing.ID
+	}
+
 	// Preserve certain fields
+	event.ID = existing.ID
 	event.CreatedAt = existing.CreatedAt
 	event.OrganizerID = existing.OrganizerID
 
-	return s.eventRepo.Update(ctx, event)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		version, err := changeEvent(ctx, s.uow, s.eventRepo, event.ID, func(ctx context.Context) error {
+			return s.eventRepo.Update(ctx, event)
+		})
+		if err != nil {
+			return err
+		}
+
+		event.Version = version
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionEventUpdate,
+			entityType: models.AuditEntityEvent,
+			entityID:   event.ID,
+			eventID:    event.ID,
+			before:     auditEvent(existing),
+			after:      auditEvent(event),
+		})
+	})
 }
 
 // DeleteEvent deletes an event
 func (s *EventService) DeleteEvent(ctx context.Context, eventID string) error {
 	// Check if event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return err
 	}
 
-	return s.eventRepo.Delete(ctx, eventID)
+	if err := authorizeManage(ctx, event); err != nil {
+		return err
+	}
+
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.eventRepo.Delete(ctx, eventID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionEventDelete,
+			entityType: models.AuditEntityEvent,
+			entityID:   eventID,
+			eventID:    eventID,
+			before:     auditEvent(event),
+		})
+	})
+	return err
+}
+
+// ListEvents retrieves a page of events with filters. Callers subject to
+// ownership checks only see events they organize or participate in.
+func (s *EventService) ListEvents(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+	if p := auth.FromContext(ctx); !unrestricted(p) {
+		// A key that acts as no user is a member of no event
+		if p.UserID == "" {
+			filter, err := listDefaults(filter)
+			if err != nil {
+				return nil, err
+			}
+			return &models.EventPage{Events: []*models.Event{}, Page: filter.Page, Limit: filter.Limit}, nil
+		}
+		filter.MemberID = p.UserID
+	}
+	return s.listEvents(ctx, filter)
 }
 
-// ListEvents retrieves events with filters
-func (s *EventService) ListEvents(ctx context.Context, filter models.EventFilter) ([]*models.Event, int, error) {
-	// Set default pagination
-	if filter.Page <= 0 {
+// ListUserEvents retrieves a page of the events a user organizes or
+// participates in. role narrows them to one of the two, and
+// filter.ParticipantStatus to the events where the user's invitation has that
+// status. Users may only list their own events.
+func (s *EventService) ListUserEvents(ctx context.Context, userID, role string, filter models.EventFilter) (*models.EventPage, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if filter.ParticipantStatus != "" && !validParticipantStatus(filter.ParticipantStatus) {
+		return nil, utils.InvalidField("status", "invalid status %q: must be %s, %s, %s or %s", filter.ParticipantStatus,
+			models.ParticipantStatusInvited, models.ParticipantStatusResponded,
+			models.ParticipantStatusTentative, models.ParticipantStatusDeclined)
+	}
+
+	switch role {
+	case models.UserEventRoleOrganizer:
+		// Organizers have no invitation to filter on
+		if filter.ParticipantStatus != "" {
+			return nil, utils.InvalidField("status", "status only applies to role %s", models.UserEventRoleParticipant)
+		}
+		filter.OrganizerID = userID
+	case models.UserEventRoleParticipant:
+		filter.ParticipantID = userID
+	case "":
+		if filter.ParticipantStatus != "" {
+			filter.ParticipantID = userID
+		} else {
+			filter.MemberID = userID
+		}
+	default:
+		return nil, utils.InvalidField("role", "invalid role %q: must be %s or %s",
+			role, models.UserEventRoleParticipant, models.UserEventRoleOrganizer)
+	}
+	return s.listEvents(ctx, filter)
+}
+
+// ListUserInbox retrieves a page of the pending events still awaiting the
+// user's availability, those where they are invited and have not responded,
+// soonest response deadline first. Users may only list their own inbox.
+func (s *EventService) ListUserInbox(ctx context.Context, userID string, filter models.EventFilter) (*models.EventPage, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	filter.Status = models.EventStatusPending
+	filter.ParticipantID = userID
+	filter.ParticipantStatus = models.ParticipantStatusInvited
+	filter.Sort = models.EventSortResponseDeadline
+	return s.listEvents(ctx, filter)
+}
+
+// validParticipantStatus reports whether status is a participant status.
+func validParticipantStatus(status string) bool {
+	switch status {
+	case models.ParticipantStatusInvited, models.ParticipantStatusResponded,
+		models.ParticipantStatusTentative, models.ParticipantStatusDeclined:
+		return true
+	}
+	return false
+}
+
+// listEvents applies the listing defaults to filter, validates it and
+// retrieves the page.
+func (s *EventService) listEvents(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+	filter, err := listDefaults(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.eventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	page.Page = filter.Page
+	page.Limit = filter.Limit
+	return page, nil
+}
+
+// listDefaults fills in the default page, limit and sort of an event
+// listing and validates the rest of the filter.
+func listDefaults(filter models.EventFilter) (models.EventFilter, error) {
+	// Set default pagination; a cursor replaces the page number
+	if filter.Cursor != "" {
+		filter.Page = 0
+	} else if filter.Page <= 0 {
 		filter.Page = 1
 	}
 	if filter.Limit <= 0 {
@@ -109,18 +449,37 @@ func (s *EventService) ListEvents(ctx context.Context, filter models.EventFilter
 	if filter.Limit > 100 {
 		filter.Limit = 100
 	}
+	if filter.Sort == "" {
+		filter.Sort = models.EventSortDefault
+	}
+	for _, relation := range filter.Include {
+		if relation != models.EventIncludeProposedSlots && relation != models.EventIncludeParticipants {
+			return filter, utils.InvalidField("include", "invalid include %q: must be %q or %q",
+				relation, models.EventIncludeProposedSlots, models.EventIncludeParticipants)
+		}
+	}
 
-	return s.eventRepo.List(ctx, filter)
+	if !filter.SlotsFrom.IsZero() && !filter.SlotsTo.IsZero() && !filter.SlotsTo.After(filter.SlotsFrom) {
+		return filter, utils.InvalidField("slots_to", "slots_to must be after slots_from")
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedTo.After(filter.CreatedFrom) {
+		return filter, utils.InvalidField("created_to", "created_to must be after created_from")
+	}
+	return filter, nil
 }
 
 // AddParticipant adds a participant to an event
 func (s *EventService) AddParticipant(ctx context.Context, eventID, userID string) error {
 	// Check if event exists
-	_, err := s.eventRepo.GetByID(ctx, eventID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return err
 	}
 
+	if err := authorizeManage(ctx, event); err != nil {
+		return err
+	}
+
 	// Check if user exists
 	_, err = s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -132,17 +491,149 @@ func (s *EventService) AddParticipant(ctx context.Context, eventID, userID strin
 		EventID: eventID,
 		UserID:  userID,
 		Status:  models.ParticipantStatusInvited,
+		Role:    models.ParticipantRoleAttendee,
+	}
+
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.AddParticipant(ctx, participant); err != nil {
+			return err
+		}
+		return recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantAdd, eventID, userID, nil, participant)
+	})
+	return err
+}
+
+// SetParticipantRole makes a participant a co-organizer or an attendee.
+// Co-organizers have the same rights over the event as the organizer, except
+// transferring it. Guests cannot be co-organizers because they cannot sign in.
+func (s *EventService) SetParticipantRole(ctx context.Context, eventID, userID, role string) (*models.EventParticipant, error) {
+	if role != models.ParticipantRoleAttendee && role != models.ParticipantRoleCoOrganizer {
+		return nil, utils.InvalidField("role", "invalid role %q: must be %q or %q",
+			role, models.ParticipantRoleAttendee, models.ParticipantRoleCoOrganizer)
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
 	}
 
-	return s.participantRepo.AddParticipant(ctx, participant)
+	participant, err := s.participantRepo.GetParticipant(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == models.ParticipantRoleCoOrganizer {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.IsGuest {
+			return nil, utils.InvalidField("role", "guests cannot be co-organizers")
+		}
+	}
+
+	updated := *participant
+	updated.Role = role
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.UpdateParticipantRole(ctx, eventID, userID, role); err != nil {
+			return err
+		}
+		return recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantUpdate, eventID, userID, participant, &updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// TransferOwnership hands an event over to another user, keeping its
+// participants and their availability. Only the current organizer may do
+// this. The previous organizer keeps any participant role they hold.
+func (s *EventService) TransferOwnership(ctx context.Context, eventID, organizerID string) (*models.Event, error) {
+	if organizerID == "" {
+		return nil, utils.InvalidField("organizer_id", "organizer_id is required")
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeTransfer(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if organizerID == event.OrganizerID {
+		return nil, utils.Conflict("user is already the organizer")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, organizerID)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, utils.NotFound("organizer not found")
+		}
+		return nil, err
+	}
+	if user.IsGuest {
+		return nil, utils.InvalidField("organizer_id", "guests cannot organize events")
+	}
+
+	before := auditEvent(event)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		version, err := changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+			return s.eventRepo.UpdateOrganizer(ctx, eventID, organizerID)
+		})
+		if err != nil {
+			return err
+		}
+
+		event.OrganizerID = organizerID
+		event.Version = version
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionEventTransfer,
+			entityType: models.AuditEntityEvent,
+			entityID:   eventID,
+			eventID:    eventID,
+			before:     before,
+			after:      auditEvent(event),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
 // RemoveParticipant removes a participant from an event
 func (s *EventService) RemoveParticipant(ctx context.Context, eventID, userID string) error {
-	return s.participantRepo.RemoveParticipant(ctx, eventID, userID)
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return err
+	}
+
+	participant := findParticipant(event, userID)
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.RemoveParticipant(ctx, eventID, userID); err != nil {
+			return err
+		}
+		return recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantRemove, eventID, userID, participant, nil)
+	})
+	return err
 }
 
 // GetEventParticipants retrieves all participants of an event
 func (s *EventService) GetEventParticipants(ctx context.Context, eventID string) ([]models.EventParticipant, error) {
+	if err := authorizeEventID(ctx, s.eventRepo, eventID, authorizeView); err != nil {
+		return nil, err
+	}
 	return s.participantRepo.GetEventParticipants(ctx, eventID)
 }
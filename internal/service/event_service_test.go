@@ -2,21 +2,22 @@ package service
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEventService_CreateEvent_Success(t *testing.T) {
 	eventRepo := new(MockEventRepository)
 	userRepo := new(MockUserRepository)
 	partRepo := new(MockParticipantRepository)
-	svc := NewEventService(eventRepo, userRepo, partRepo)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	event := baseEvent()
@@ -32,13 +33,79 @@ func TestEventService_CreateEvent_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestEventService_CreateEvent_WithParticipants(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	partRepo := new(MockParticipantRepository)
+	uow := new(MockUnitOfWork)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), uow)
+	ctx := context.Background()
+
+	event := baseEvent()
+	event.ParticipantIDs = []string{"u2", "u3"}
+	userRepo.On("GetByID", ctx, mock.Anything).Return(&models.User{}, nil)
+	eventRepo.On("Create", ctx, event).Return(nil)
+	partRepo.On("AddParticipant", ctx, mock.AnythingOfType("*models.EventParticipant")).Return(nil)
+
+	err := svc.CreateEvent(ctx, event)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
+	if assert.Len(t, event.Participants, 2) {
+		assert.Equal(t, "u3", event.Participants[1].UserID)
+		assert.Equal(t, models.ParticipantStatusInvited, event.Participants[1].Status)
+	}
+	partRepo.AssertNumberOfCalls(t, "AddParticipant", 2)
+}
+
+func TestEventService_CreateEvent_ParticipantFailureRollsBack(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	partRepo := new(MockParticipantRepository)
+	uow := new(MockUnitOfWork)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), uow)
+	ctx := context.Background()
+
+	event := baseEvent()
+	event.ParticipantIDs = []string{"u2", "u2"}
+	userRepo.On("GetByID", ctx, mock.Anything).Return(&models.User{}, nil)
+	eventRepo.On("Create", ctx, event).Return(nil)
+	partRepo.On("AddParticipant", ctx, mock.AnythingOfType("*models.EventParticipant")).Return(nil).Once()
+	partRepo.On("AddParticipant", ctx, mock.AnythingOfType("*models.EventParticipant")).Return(utils.Conflict("user is already a participant")).Once()
+
+	err := svc.CreateEvent(ctx, event)
+
+	assert.ErrorIs(t, err, utils.ErrConflict)
+	assert.ErrorIs(t, uow.Err, utils.ErrConflict, "unit of work must see the failure to roll back")
+	assert.Empty(t, event.Participants)
+}
+
+func TestEventService_CreateEvent_UnknownParticipant(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	uow := new(MockUnitOfWork)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), uow)
+	ctx := context.Background()
+
+	event := baseEvent()
+	event.ParticipantIDs = []string{"ghost"}
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
+
+	err := svc.CreateEvent(ctx, event)
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.Zero(t, uow.Calls)
+	eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestEventService_CreateEvent_OrganizerNotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
 	userRepo := new(MockUserRepository)
-	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	userRepo.On("GetByID", ctx, "u1").Return(nil, errors.New("not found"))
+	userRepo.On("GetByID", ctx, "u1").Return(nil, utils.NotFound("user not found"))
 
 	err := svc.CreateEvent(ctx, baseEvent())
 
@@ -48,7 +115,7 @@ func TestEventService_CreateEvent_OrganizerNotFound(t *testing.T) {
 
 func TestEventService_CreateEvent_InvalidDuration(t *testing.T) {
 	userRepo := new(MockUserRepository)
-	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository))
+	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
@@ -63,7 +130,7 @@ func TestEventService_CreateEvent_InvalidDuration(t *testing.T) {
 
 func TestEventService_CreateEvent_NoProposedSlots(t *testing.T) {
 	userRepo := new(MockUserRepository)
-	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository))
+	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
@@ -78,7 +145,7 @@ func TestEventService_CreateEvent_NoProposedSlots(t *testing.T) {
 
 func TestEventService_CreateEvent_InvalidSlotTimes(t *testing.T) {
 	userRepo := new(MockUserRepository)
-	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository))
+	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
@@ -99,7 +166,7 @@ func TestEventService_CreateEvent_InvalidSlotTimes(t *testing.T) {
 
 func TestEventService_GetEvent_Success(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	expected := &models.Event{ID: "e1", Title: "Planning"}
@@ -114,10 +181,10 @@ func TestEventService_GetEvent_Success(t *testing.T) {
 
 func TestEventService_GetEvent_NotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
 	result, err := svc.GetEvent(ctx, "ghost")
 
@@ -128,13 +195,15 @@ func TestEventService_GetEvent_NotFound(t *testing.T) {
 
 func TestEventService_UpdateEvent_Success(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	var entries []*models.AuditEntry
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), recordedAudit(&entries), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	existing := &models.Event{ID: "e1", OrganizerID: "u1", CreatedAt: time.Now()}
 	updated := &models.Event{ID: "e1", Title: "Updated"}
 
 	eventRepo.On("GetByID", ctx, "e1").Return(existing, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
 	eventRepo.On("Update", ctx, updated).Return(nil)
 
 	err := svc.UpdateEvent(ctx, updated)
@@ -143,15 +212,150 @@ func TestEventService_UpdateEvent_Success(t *testing.T) {
 	// OrganizerID and CreatedAt must be preserved from existing
 	assert.Equal(t, existing.OrganizerID, updated.OrganizerID)
 	assert.Equal(t, existing.CreatedAt, updated.CreatedAt)
+	assert.Equal(t, 2, updated.Version)
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AuditActionEventUpdate, entries[0].Action)
+	assert.Contains(t, string(entries[0].Before), `"title":""`)
+	assert.Contains(t, string(entries[0].After), `"title":"Updated"`)
+	assert.Contains(t, string(entries[0].After), `"version":2`)
 	eventRepo.AssertExpectations(t)
 }
 
+func TestEventService_UpdateEvent_StaleIfMatch(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	uow := new(MockUnitOfWork)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), uow)
+	ctx := WithIfMatch(context.Background(), 3)
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", Version: 4}, nil)
+	eventRepo.On("Touch", ctx, "e1", 3).Return(0, utils.PreconditionFailed("event is no longer at version 3"))
+
+	err := svc.UpdateEvent(ctx, &models.Event{ID: "e1", Title: "Updated"})
+
+	assert.ErrorIs(t, err, utils.ErrPreconditionFailed)
+	assert.ErrorIs(t, uow.Err, utils.ErrPreconditionFailed)
+	eventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEventService_UpdateEvent_KeepsStatusAndChecksSlotIDs(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	existing := patchableEvent()
+	eventRepo.On("GetByID", ctx, "e1").Return(existing, nil)
+
+	slots := validSlots()
+	slots[0].ID = 99
+	err := svc.UpdateEvent(ctx, &models.Event{ID: "e1", Title: "Updated", DurationMinutes: 30, ProposedSlots: slots})
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	eventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("Update", ctx, mock.AnythingOfType("*models.Event")).Return(nil)
+
+	event := &models.Event{ID: "e1", Title: "Updated", DurationMinutes: 30}
+	err = svc.UpdateEvent(ctx, event)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.EventStatusPending, event.Status)
+}
+
+// patchableEvent has two proposed slots with IDs 1 and 2.
+func patchableEvent() *models.Event {
+	return &models.Event{
+		ID:              "e1",
+		Title:           "Planning",
+		Description:     "Quarterly planning",
+		OrganizerID:     "u1",
+		DurationMinutes: 60,
+		Status:          models.EventStatusPending,
+		Version:         3,
+		ProposedSlots: []models.ProposedSlot{
+			{ID: 1, EventID: "e1", StartTime: time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 1, 12, 11, 0, 0, 0, time.UTC), Timezone: "UTC"},
+			{ID: 2, EventID: "e1", StartTime: time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 1, 13, 11, 0, 0, 0, time.UTC), Timezone: "UTC"},
+		},
+	}
+}
+
+func TestEventService_PatchEvent_OnlySentFieldsChange(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("Update", ctx, mock.AnythingOfType("*models.Event")).Return(nil)
+
+	event, err := svc.PatchEvent(ctx, "e1", []byte(`{"duration_minutes":45,"description":null}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Planning", event.Title)
+	assert.Equal(t, "", event.Description)
+	assert.Equal(t, 45, event.DurationMinutes)
+	assert.Equal(t, models.EventStatusPending, event.Status)
+	assert.Equal(t, "u1", event.OrganizerID)
+	assert.Equal(t, 4, event.Version)
+	assert.Equal(t, patchableEvent().ProposedSlots, event.ProposedSlots)
+}
+
+func TestEventService_PatchEvent_SlotsByID(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("Update", ctx, mock.AnythingOfType("*models.Event")).Return(nil)
+
+	event, err := svc.PatchEvent(ctx, "e1", []byte(`{"proposed_slots":{"1":null,"2":{"end_time":"2025-01-13T10:00:00Z"}}}`))
+
+	assert.NoError(t, err)
+	if assert.Len(t, event.ProposedSlots, 1) {
+		slot := event.ProposedSlots[0]
+		assert.Equal(t, uint(2), slot.ID)
+		assert.True(t, slot.StartTime.Equal(time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC)))
+		assert.True(t, slot.EndTime.Equal(time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)))
+	}
+}
+
+func TestEventService_PatchEvent_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		kind  error
+	}{
+		{"removing a required field", `{"title":null}`, utils.ErrValidation},
+		{"read-only field", `{"organizer_id":"u2"}`, utils.ErrValidation},
+		{"removing every slot", `{"proposed_slots":{"1":null,"2":null}}`, utils.ErrValidation},
+		{"slot ending before it starts", `{"proposed_slots":{"1":{"end_time":"2025-01-12T08:00:00Z"}}}`, utils.ErrValidation},
+		{"unknown slot", `{"proposed_slots":{"7":null}}`, utils.ErrNotFound},
+		{"wrong type", `{"duration_minutes":"long"}`, utils.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := new(MockEventRepository)
+			svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+			ctx := context.Background()
+
+			eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+
+			_, err := svc.PatchEvent(ctx, "e1", []byte(tt.patch))
+
+			assert.ErrorIs(t, err, tt.kind)
+			eventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestEventService_UpdateEvent_NotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
 	err := svc.UpdateEvent(ctx, &models.Event{ID: "ghost"})
 
@@ -161,10 +365,11 @@ func TestEventService_UpdateEvent_NotFound(t *testing.T) {
 
 func TestEventService_DeleteEvent_Success(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
 	eventRepo.On("Delete", ctx, "e1").Return(nil)
 
 	err := svc.DeleteEvent(ctx, "e1")
@@ -175,10 +380,10 @@ func TestEventService_DeleteEvent_Success(t *testing.T) {
 
 func TestEventService_DeleteEvent_NotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
 	err := svc.DeleteEvent(ctx, "ghost")
 
@@ -188,31 +393,171 @@ func TestEventService_DeleteEvent_NotFound(t *testing.T) {
 
 func TestEventService_ListEvents_DefaultPagination(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	events := []*models.Event{{ID: "e1"}}
-	// page=0 → 1, limit=0 → 20
-	eventRepo.On("List", ctx, models.EventFilter{Page: 1, Limit: 20}).Return(events, 1, nil)
+	// page=0 → 1, limit=0 → 20, newest first
+	eventRepo.On("List", ctx, models.EventFilter{Page: 1, Limit: 20, Sort: "-created_at"}).
+		Return(&models.EventPage{Events: events, Total: 1}, nil)
+
+	result, err := svc.ListEvents(ctx, models.EventFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, events, result.Events)
+	assert.Equal(t, 1, result.Total)
+	assert.Equal(t, 1, result.Page)
+	assert.Equal(t, 20, result.Limit)
+	eventRepo.AssertExpectations(t)
+}
 
-	result, total, err := svc.ListEvents(ctx, models.EventFilter{})
+func TestEventService_ListEvents_CursorReplacesPage(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("List", ctx, models.EventFilter{Cursor: "abc", Limit: 20, Sort: "title"}).
+		Return(&models.EventPage{Events: []*models.Event{}}, nil)
+
+	result, err := svc.ListEvents(ctx, models.EventFilter{Cursor: "abc", Page: 3, Sort: "title"})
 
 	assert.NoError(t, err)
-	assert.Equal(t, events, result)
-	assert.Equal(t, 1, total)
+	assert.Zero(t, result.Page)
 	eventRepo.AssertExpectations(t)
 }
 
+func TestEventService_ListEvents_UnknownInclude(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+
+	_, err := svc.ListEvents(context.Background(), models.EventFilter{Include: []string{"participants", "organizer"}})
+
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	eventRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestEventService_ListEvents_InvalidRange(t *testing.T) {
+	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.ListEvents(context.Background(), models.EventFilter{SlotsFrom: day, SlotsTo: day})
+	assert.ErrorIs(t, err, utils.ErrValidation)
+
+	_, err = svc.ListEvents(context.Background(), models.EventFilter{CreatedFrom: day, CreatedTo: day.Add(-time.Hour)})
+	assert.ErrorIs(t, err, utils.ErrValidation)
+}
+
 func TestEventService_ListEvents_LimitCappedAt100(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("List", ctx, models.EventFilter{Page: 1, Limit: 100, Sort: "-created_at"}).
+		Return(&models.EventPage{Events: []*models.Event{}}, nil)
+
+	_, err := svc.ListEvents(ctx, models.EventFilter{Page: 1, Limit: 500})
+
+	assert.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestEventService_ListUserEvents_Roles(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   string
+		status string
+		want   models.EventFilter
+	}{
+		{"any role", "", "", models.EventFilter{MemberID: "u1"}},
+		{"organizer", models.UserEventRoleOrganizer, "", models.EventFilter{OrganizerID: "u1"}},
+		{"participant", models.UserEventRoleParticipant, "", models.EventFilter{ParticipantID: "u1"}},
+		{"status implies participant", "", "invited", models.EventFilter{ParticipantID: "u1", ParticipantStatus: "invited"}},
+		{"participant with status", models.UserEventRoleParticipant, "responded", models.EventFilter{ParticipantID: "u1", ParticipantStatus: "responded"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := new(MockEventRepository)
+			userRepo := new(MockUserRepository)
+			svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+			ctx := context.Background()
+
+			want := tt.want
+			want.Page, want.Limit, want.Sort = 1, 20, models.EventSortDefault
+			userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+			eventRepo.On("List", ctx, want).Return(&models.EventPage{Events: []*models.Event{}}, nil)
+
+			_, err := svc.ListUserEvents(ctx, "u1", tt.role, models.EventFilter{ParticipantStatus: tt.status})
+
+			assert.NoError(t, err)
+			eventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_ListUserEvents_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   string
+		status string
+	}{
+		{"unknown role", "guest", ""},
+		{"unknown status", models.UserEventRoleParticipant, "maybe"},
+		{"status for organizer", models.UserEventRoleOrganizer, "invited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := new(MockEventRepository)
+			userRepo := new(MockUserRepository)
+			svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+			ctx := context.Background()
+
+			userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+
+			_, err := svc.ListUserEvents(ctx, "u1", tt.role, models.EventFilter{ParticipantStatus: tt.status})
+
+			assert.ErrorIs(t, err, utils.ErrValidation)
+			eventRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEventService_ListUserEvents_UnknownUser(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	eventRepo.On("List", ctx, models.EventFilter{Page: 1, Limit: 100}).Return([]*models.Event{}, 0, nil)
+	userRepo.On("GetByID", ctx, "nobody").Return(nil, utils.NotFound("user not found"))
 
-	_, _, err := svc.ListEvents(ctx, models.EventFilter{Page: 1, Limit: 500})
+	_, err := svc.ListUserEvents(ctx, "nobody", "", models.EventFilter{})
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	eventRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestEventService_ListUserInbox(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := userContext("u1")
+
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	eventRepo.On("List", ctx, models.EventFilter{
+		Status:            models.EventStatusPending,
+		ParticipantID:     "u1",
+		ParticipantStatus: models.ParticipantStatusInvited,
+		Sort:              models.EventSortResponseDeadline,
+		Page:              1,
+		Limit:             20,
+	}).Return(&models.EventPage{Events: []*models.Event{}}, nil)
+
+	// The inbox is always in deadline order
+	result, err := svc.ListUserInbox(ctx, "u1", models.EventFilter{Sort: "title"})
 
 	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Page)
 	eventRepo.AssertExpectations(t)
 }
 
@@ -220,11 +565,12 @@ func TestEventService_AddParticipant_Success(t *testing.T) {
 	eventRepo := new(MockEventRepository)
 	userRepo := new(MockUserRepository)
 	partRepo := new(MockParticipantRepository)
-	svc := NewEventService(eventRepo, userRepo, partRepo)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
 	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
 	partRepo.On("AddParticipant", ctx, mock.MatchedBy(func(p *models.EventParticipant) bool {
 		return p.EventID == "e1" && p.UserID == "u1" && p.Status == models.ParticipantStatusInvited
 	})).Return(nil)
@@ -239,10 +585,10 @@ func TestEventService_AddParticipant_Success(t *testing.T) {
 
 func TestEventService_AddParticipant_EventNotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
-	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	eventRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
 
 	err := svc.AddParticipant(ctx, "ghost", "u1")
 
@@ -253,11 +599,11 @@ func TestEventService_AddParticipant_EventNotFound(t *testing.T) {
 func TestEventService_AddParticipant_UserNotFound(t *testing.T) {
 	eventRepo := new(MockEventRepository)
 	userRepo := new(MockUserRepository)
-	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository))
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
-	userRepo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
 
 	err := svc.AddParticipant(ctx, "e1", "ghost")
 
@@ -267,21 +613,35 @@ func TestEventService_AddParticipant_UserNotFound(t *testing.T) {
 }
 
 func TestEventService_RemoveParticipant_Success(t *testing.T) {
+	eventRepo := new(MockEventRepository)
 	partRepo := new(MockParticipantRepository)
-	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), partRepo)
-	ctx := context.Background()
+	var entries []*models.AuditEntry
+	svc := NewEventService(eventRepo, new(MockUserRepository), partRepo, recordedAudit(&entries), new(MockUnitOfWork))
+	ctx := utils.WithRequestID(userContext("org"), "req-7")
 
-	partRepo.On("RemoveParticipant", ctx, "e1", "u1").Return(nil)
+	eventRepo.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	partRepo.On("RemoveParticipant", ctx, "e1", "p1").Return(nil)
 
-	err := svc.RemoveParticipant(ctx, "e1", "u1")
+	err := svc.RemoveParticipant(ctx, "e1", "p1")
 
 	assert.NoError(t, err)
 	partRepo.AssertExpectations(t)
+
+	// The audit log tells who removed the participant
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AuditActionParticipantRemove, entries[0].Action)
+	assert.Equal(t, "org", entries[0].Actor)
+	assert.Equal(t, "req-7", entries[0].RequestID)
+	assert.Equal(t, "p1", entries[0].EntityID)
+	assert.Equal(t, "e1", entries[0].EventID)
+	assert.JSONEq(t, `{"user_id":"p1","status":"","role":"attendee"}`, string(entries[0].Before))
+	assert.Nil(t, entries[0].After)
 }
 
 func TestEventService_GetEventParticipants_Success(t *testing.T) {
 	partRepo := new(MockParticipantRepository)
-	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), partRepo)
+	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), partRepo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	expected := []models.EventParticipant{{UserID: "u1"}, {UserID: "u2"}}
@@ -312,3 +672,122 @@ func baseEvent() *models.Event {
 		ProposedSlots:   validSlots(),
 	}
 }
+
+func TestEventService_CreateEvent_InvalidTimezone(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(new(MockEventRepository), userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+
+	event := baseEvent()
+	event.ProposedSlots[0].Timezone = "Mars/Olympus_Mons"
+
+	err := svc.CreateEvent(ctx, event)
+
+	assert.ErrorIs(t, err, utils.ErrInvalidTimezone)
+	assert.ErrorContains(t, err, "invalid time slot 0")
+}
+
+func TestEventService_SetParticipantRole_Success(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	partRepo := new(MockParticipantRepository)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u2").Return(&models.EventParticipant{UserID: "u2", Role: models.ParticipantRoleAttendee}, nil)
+	userRepo.On("GetByID", ctx, "u2").Return(&models.User{ID: "u2"}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	partRepo.On("UpdateParticipantRole", ctx, "e1", "u2", models.ParticipantRoleCoOrganizer).Return(nil)
+
+	participant, err := svc.SetParticipantRole(ctx, "e1", "u2", models.ParticipantRoleCoOrganizer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ParticipantRoleCoOrganizer, participant.Role)
+	partRepo.AssertExpectations(t)
+}
+
+func TestEventService_SetParticipantRole_NotParticipant(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	partRepo := new(MockParticipantRepository)
+	svc := NewEventService(eventRepo, new(MockUserRepository), partRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u9").Return(nil, utils.NotFound("participant not found"))
+
+	_, err := svc.SetParticipantRole(ctx, "e1", "u9", models.ParticipantRoleCoOrganizer)
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	eventRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventService_SetParticipantRole_InvalidRole(t *testing.T) {
+	svc := NewEventService(new(MockEventRepository), new(MockUserRepository), new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+
+	_, err := svc.SetParticipantRole(context.Background(), "e1", "u2", "owner")
+
+	assert.ErrorContains(t, err, "invalid role")
+}
+
+func TestEventService_SetParticipantRole_GuestCannotCoOrganize(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	partRepo := new(MockParticipantRepository)
+	svc := NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "u1"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "g1").Return(&models.EventParticipant{UserID: "g1"}, nil)
+	userRepo.On("GetByID", ctx, "g1").Return(&models.User{ID: "g1", IsGuest: true}, nil)
+
+	_, err := svc.SetParticipantRole(ctx, "e1", "g1", models.ParticipantRoleCoOrganizer)
+
+	assert.EqualError(t, err, "guests cannot be co-organizers")
+	partRepo.AssertNotCalled(t, "UpdateParticipantRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventService_TransferOwnership_Success(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "u1"}, nil)
+	userRepo.On("GetByID", ctx, "u2").Return(&models.User{ID: "u2"}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	eventRepo.On("UpdateOrganizer", ctx, "e1", "u2").Return(nil)
+
+	event, err := svc.TransferOwnership(ctx, "e1", "u2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u2", event.OrganizerID)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestEventService_TransferOwnership_Validation(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewEventService(eventRepo, userRepo, new(MockParticipantRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "u1"}, nil)
+	userRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
+	userRepo.On("GetByID", ctx, "g1").Return(&models.User{ID: "g1", IsGuest: true}, nil)
+
+	_, err := svc.TransferOwnership(ctx, "e1", "")
+	assert.EqualError(t, err, "organizer_id is required")
+
+	_, err = svc.TransferOwnership(ctx, "e1", "u1")
+	assert.EqualError(t, err, "user is already the organizer")
+
+	_, err = svc.TransferOwnership(ctx, "e1", "ghost")
+	assert.EqualError(t, err, "organizer not found")
+
+	_, err = svc.TransferOwnership(ctx, "e1", "g1")
+	assert.EqualError(t, err, "guests cannot organize events")
+
+	eventRepo.AssertNotCalled(t, "UpdateOrganizer", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// GroupService manages groups of users and inviting them to events as a
+// unit. Pending events a group was invited to follow changes to its
+// membership: new members are invited, and removed members who have not
+// responded yet are uninvited.
+type GroupService struct {
+	groupRepo       repository.GroupRepository
+	userRepo        repository.UserRepository
+	eventRepo       repository.EventRepository
+	participantRepo repository.ParticipantRepository
+	auditRepo       repository.AuditRepository
+	uow             repository.UnitOfWork
+}
+
+// NewGroupService creates a new group service
+func NewGroupService(
+	groupRepo repository.GroupRepository,
+	userRepo repository.UserRepository,
+	eventRepo repository.EventRepository,
+	participantRepo repository.ParticipantRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+) *GroupService {
+	return &GroupService{
+		groupRepo:       groupRepo,
+		userRepo:        userRepo,
+		eventRepo:       eventRepo,
+		participantRepo: participantRepo,
+		auditRepo:       auditRepo,
+		uow:             uow,
+	}
+}
+
+// CreateGroup creates a group owned by the caller with the given members.
+func (s *GroupService) CreateGroup(ctx context.Context, req models.CreateGroupRequest) (*models.Group, error) {
+	// Callers own the groups they create
+	ownerID := req.OwnerID
+	if p := auth.FromContext(ctx); !unrestricted(p) {
+		if ownerID == "" {
+			ownerID = p.UserID
+		}
+		if p.UserID == "" || ownerID != p.UserID {
+			return nil, utils.Forbidden("groups can only be created with yourself as owner")
+		}
+	}
+	if ownerID == "" {
+		return nil, utils.InvalidField("owner_id", "owner_id is required")
+	}
+	if _, err := s.userRepo.GetByID(ctx, ownerID); err != nil {
+		return nil, fmt.Errorf("owner not found: %w", err)
+	}
+
+	memberIDs, err := s.memberUsers(ctx, req.MemberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &models.Group{
+		ID:      utils.GenerateGroupID(),
+		Name:    req.Name,
+		OwnerID: ownerID,
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.Create(ctx, group); err != nil {
+			return err
+		}
+		return s.groupRepo.AddMembers(ctx, group.ID, memberIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetByID(ctx, group.ID)
+}
+
+// GetGroup returns a group with its members.
+func (s *GroupService) GetGroup(ctx context.Context, groupID string) (*models.Group, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGroupView(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// ListGroups lists the groups the caller owns or belongs to. Admins and
+// trusted calls see every group.
+func (s *GroupService) ListGroups(ctx context.Context) ([]*models.Group, error) {
+	p := auth.FromContext(ctx)
+	if unrestricted(p) {
+		return s.groupRepo.List(ctx, "")
+	}
+	if p.UserID == "" {
+		return []*models.Group{}, nil
+	}
+	return s.groupRepo.List(ctx, p.UserID)
+}
+
+// UpdateGroup renames a group.
+func (s *GroupService) UpdateGroup(ctx context.Context, groupID string, req models.UpdateGroupRequest) (*models.Group, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGroupManage(ctx, group); err != nil {
+		return nil, err
+	}
+
+	group.Name = req.Name
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteGroup deletes a group. Participants invited through it stay on their
+// events.
+func (s *GroupService) DeleteGroup(ctx context.Context, groupID string) error {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeGroupManage(ctx, group); err != nil {
+		return err
+	}
+
+	return s.groupRepo.Delete(ctx, groupID)
+}
+
+// AddMembers adds users to a group and invites them to the pending events the
+// group was invited to that the caller manages. Users who are already members
+// are left as they are.
+func (s *GroupService) AddMembers(ctx context.Context, groupID string, userIDs []string) (*models.Group, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGroupManage(ctx, group); err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := s.memberUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool, len(group.Members))
+	for _, member := range group.Members {
+		current[member.ID] = true
+	}
+	var added []string
+	for _, id := range memberIDs {
+		if !current[id] {
+			added = append(added, id)
+		}
+	}
+	if len(added) == 0 {
+		return group, nil
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.AddMembers(ctx, groupID, added); err != nil {
+			return err
+		}
+		return s.syncEvents(ctx, groupID, func(event *models.Event) ([]models.EventParticipant, []string) {
+			return groupInvitations(event, groupID, added), nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetByID(ctx, groupID)
+}
+
+// RemoveMember removes a user from a group, and from the pending events the
+// group invited them to unless they have already responded. Members may
+// leave a group themselves, which also drops their own invitations; others
+// are only uninvited from events the caller manages.
+func (s *GroupService) RemoveMember(ctx context.Context, groupID, userID string) error {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if p := auth.FromContext(ctx); p == nil || p.UserID == "" || p.UserID != userID {
+		if err := authorizeGroupManage(ctx, group); err != nil {
+			return err
+		}
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.groupRepo.RemoveMember(ctx, groupID, userID); err != nil {
+			return err
+		}
+		return s.syncEvents(ctx, groupID, func(event *models.Event) ([]models.EventParticipant, []string) {
+			for _, p := range event.Participants {
+				if p.UserID == userID && p.GroupID == groupID && p.Status == models.ParticipantStatusInvited {
+					return nil, []string{userID}
+				}
+			}
+			return nil, nil
+		})
+	})
+}
+
+// InviteGroup invites a group's members to an event, recording the group as
+// the reason each was invited. Members who already take part in the event,
+// and its organizer, are skipped. It returns the IDs of the users invited.
+func (s *GroupService) InviteGroup(ctx context.Context, eventID, groupID string) ([]string, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGroupView(ctx, group); err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]string, 0, len(group.Members))
+	for _, member := range group.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	invitations := groupInvitations(event, groupID, memberIDs)
+	added := make([]string, 0, len(invitations))
+	if len(invitations) == 0 {
+		return added, nil
+	}
+
+	_, err = changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.AddParticipants(ctx, eventID, invitations); err != nil {
+			return err
+		}
+		return s.recordSync(ctx, event, invitations, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range invitations {
+		added = append(added, p.UserID)
+	}
+	return added, nil
+}
+
+// memberUsers checks that userIDs name existing users who can be group
+// members, and returns them without duplicates. Guests cannot be members
+// because a group invitation does not send them a respond link.
+func (s *GroupService) memberUsers(ctx context.Context, userIDs []string) ([]string, error) {
+	ids := make([]string, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		found[user.ID] = user
+	}
+	for _, id := range ids {
+		user := found[id]
+		if user == nil {
+			return nil, utils.NotFound("user %s not found", id)
+		}
+		if user.IsGuest {
+			return nil, utils.InvalidField("user_ids", "guest %s cannot be a group member", id)
+		}
+	}
+	return ids, nil
+}
+
+// syncEvents applies a membership change to each pending event the group was
+// invited to. change returns the participants to add to and remove from an
+// event. Events with nothing to change are left at their version, and so are
+// events the caller may not manage: owning a group does not give a say over
+// other organizers' events. Members may still drop their own invitation.
+func (s *GroupService) syncEvents(
+	ctx context.Context,
+	groupID string,
+	change func(*models.Event) ([]models.EventParticipant, []string),
+) error {
+	eventIDs, err := s.participantRepo.GetGroupEventIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	p := auth.FromContext(ctx)
+
+	for _, eventID := range eventIDs {
+		event, err := s.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		adds, removes := change(event)
+		if len(adds) == 0 && len(removes) == 0 {
+			continue
+		}
+		leaving := len(adds) == 0 && len(removes) == 1 && p != nil && removes[0] == p.UserID
+		if !canManageEvent(p, event) && !leaving {
+			continue
+		}
+
+		if _, err := s.eventRepo.Touch(ctx, eventID, 0); err != nil {
+			return err
+		}
+		if err := s.participantRepo.AddParticipants(ctx, eventID, adds); err != nil {
+			return err
+		}
+		if err := s.participantRepo.RemoveParticipants(ctx, eventID, removes); err != nil {
+			return err
+		}
+		if err := s.recordSync(ctx, event, adds, removes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordSync adds an audit log entry for each participant a group added to
+// or removed from an event.
+func (s *GroupService) recordSync(ctx context.Context, event *models.Event, adds []models.EventParticipant, removes []string) error {
+	for i := range adds {
+		if err := recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantAdd, event.ID, adds[i].UserID, nil, &adds[i]); err != nil {
+			return err
+		}
+	}
+	for _, userID := range removes {
+		before := findParticipant(event, userID)
+		if err := recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantRemove, event.ID, userID, before, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupInvitations returns invitations through groupID for the users among
+// userIDs who are neither the event's organizer nor already participants.
+func groupInvitations(event *models.Event, groupID string, userIDs []string) []models.EventParticipant {
+	present := make(map[string]bool, len(event.Participants)+1)
+	present[event.OrganizerID] = true
+	for _, p := range event.Participants {
+		present[p.UserID] = true
+	}
+
+	var invitations []models.EventParticipant
+	for _, id := range userIDs {
+		if present[id] {
+			continue
+		}
+		invitations = append(invitations, models.EventParticipant{
+			UserID:  id,
+			Status:  models.ParticipantStatusInvited,
+			Role:    models.ParticipantRoleAttendee,
+			GroupID: groupID,
+		})
+	}
+	return invitations
+}
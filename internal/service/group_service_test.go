@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type groupMocks struct {
+	groups       *MockGroupRepository
+	users        *MockUserRepository
+	events       *MockEventRepository
+	participants *MockParticipantRepository
+}
+
+func setupGroupService() (*GroupService, groupMocks) {
+	m := groupMocks{
+		groups:       new(MockGroupRepository),
+		users:        new(MockUserRepository),
+		events:       new(MockEventRepository),
+		participants: new(MockParticipantRepository),
+	}
+	return NewGroupService(m.groups, m.users, m.events, m.participants, auditLog(), new(MockUnitOfWork)), m
+}
+
+// team is owned by "org" and has members "p1" and "m1".
+func team() *models.Group {
+	return &models.Group{
+		ID:      "grp_1",
+		Name:    "Platform team",
+		OwnerID: "org",
+		Members: []models.User{{ID: "p1"}, {ID: "m1"}},
+	}
+}
+
+func TestGroupService_CreateGroup(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	m.users.On("GetByID", ctx, "org").Return(&models.User{ID: "org"}, nil)
+	m.users.On("GetByIDs", ctx, []string{"p1", "m1"}).Return([]*models.User{{ID: "m1"}, {ID: "p1"}}, nil)
+	var created *models.Group
+	m.groups.On("Create", ctx, mock.AnythingOfType("*models.Group")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*models.Group) }).
+		Return(nil)
+	m.groups.On("AddMembers", ctx, mock.AnythingOfType("string"), []string{"p1", "m1"}).Return(nil)
+	m.groups.On("GetByID", ctx, mock.AnythingOfType("string")).Return(team(), nil)
+
+	group, err := svc.CreateGroup(ctx, models.CreateGroupRequest{Name: "Platform team", MemberIDs: []string{"p1", "m1", "p1"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "org", created.OwnerID)
+	assert.Contains(t, created.ID, utils.GroupIDPrefix)
+	assert.Len(t, group.Members, 2)
+	m.groups.AssertExpectations(t)
+}
+
+func TestGroupService_CreateGroup_Rejects(t *testing.T) {
+	t.Run("someone else as owner", func(t *testing.T) {
+		svc, m := setupGroupService()
+
+		_, err := svc.CreateGroup(userContext("p1"), models.CreateGroupRequest{Name: "Team", OwnerID: "org"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		m.groups.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("guest member", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("org")
+		m.users.On("GetByID", ctx, "org").Return(&models.User{ID: "org"}, nil)
+		m.users.On("GetByIDs", ctx, []string{"g1"}).Return([]*models.User{{ID: "g1", IsGuest: true}}, nil)
+
+		_, err := svc.CreateGroup(ctx, models.CreateGroupRequest{Name: "Team", MemberIDs: []string{"g1"}})
+
+		assert.ErrorIs(t, err, utils.ErrValidation)
+		m.groups.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("org")
+		m.users.On("GetByID", ctx, "org").Return(&models.User{ID: "org"}, nil)
+		m.users.On("GetByIDs", ctx, []string{"ghost"}).Return([]*models.User{}, nil)
+
+		_, err := svc.CreateGroup(ctx, models.CreateGroupRequest{Name: "Team", MemberIDs: []string{"ghost"}})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestGroupService_GetGroup_Authorization(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed bool
+	}{
+		{"owner", userContext("org"), true},
+		{"member", userContext("m1"), true},
+		{"admin", adminContext(), true},
+		{"outsider", userContext("x"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := setupGroupService()
+			m.groups.On("GetByID", tt.ctx, "grp_1").Return(team(), nil)
+
+			_, err := svc.GetGroup(tt.ctx, "grp_1")
+
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, auth.ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestGroupService_ListGroups(t *testing.T) {
+	t.Run("user sees own groups", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("m1")
+		m.groups.On("List", ctx, "m1").Return([]*models.Group{team()}, nil)
+
+		groups, err := svc.ListGroups(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, groups, 1)
+	})
+
+	t.Run("admin sees all", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := adminContext()
+		m.groups.On("List", ctx, "").Return([]*models.Group{}, nil)
+
+		_, err := svc.ListGroups(ctx)
+
+		require.NoError(t, err)
+		m.groups.AssertExpectations(t)
+	})
+}
+
+func TestGroupService_UpdateGroup_MemberForbidden(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("m1")
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+
+	_, err := svc.UpdateGroup(ctx, "grp_1", models.UpdateGroupRequest{Name: "Renamed"})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	m.groups.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestGroupService_AddMembers_SyncsPendingEvents(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+	m.users.On("GetByIDs", ctx, []string{"m1", "u3", "co"}).Return([]*models.User{{ID: "m1"}, {ID: "u3"}, {ID: "co"}}, nil)
+	m.groups.On("AddMembers", ctx, "grp_1", []string{"u3", "co"}).Return(nil)
+	m.participants.On("GetGroupEventIDs", ctx, "grp_1").Return([]string{"e1"}, nil)
+	m.events.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	m.events.On("Touch", ctx, "e1", 0).Return(2, nil)
+	m.participants.On("AddParticipants", ctx, "e1", []models.EventParticipant{
+		{UserID: "u3", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleAttendee, GroupID: "grp_1"},
+	}).Return(nil)
+	m.participants.On("RemoveParticipants", ctx, "e1", []string(nil)).Return(nil)
+
+	_, err := svc.AddMembers(ctx, "grp_1", []string{"m1", "u3", "co"})
+
+	require.NoError(t, err)
+	m.groups.AssertExpectations(t)
+	m.events.AssertExpectations(t)
+	m.participants.AssertExpectations(t)
+}
+
+func TestGroupService_AddMembers_SkipsEventsOfOtherOrganizers(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	other := authzEvent()
+	other.ID = "e2"
+	other.OrganizerID = "someone-else"
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+	m.users.On("GetByIDs", ctx, []string{"u3"}).Return([]*models.User{{ID: "u3"}}, nil)
+	m.groups.On("AddMembers", ctx, "grp_1", []string{"u3"}).Return(nil)
+	m.participants.On("GetGroupEventIDs", ctx, "grp_1").Return([]string{"e2"}, nil)
+	m.events.On("GetByID", ctx, "e2").Return(other, nil)
+
+	_, err := svc.AddMembers(ctx, "grp_1", []string{"u3"})
+
+	require.NoError(t, err)
+	m.events.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+	m.participants.AssertNotCalled(t, "AddParticipants", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGroupService_AddMembers_AlreadyMembers(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+	m.users.On("GetByIDs", ctx, []string{"m1"}).Return([]*models.User{{ID: "m1"}}, nil)
+
+	group, err := svc.AddMembers(ctx, "grp_1", []string{"m1"})
+
+	require.NoError(t, err)
+	assert.Len(t, group.Members, 2)
+	m.groups.AssertNotCalled(t, "AddMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGroupService_RemoveMember_SyncsPendingEvents(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	event := authzEvent()
+	event.Participants = append(event.Participants,
+		models.EventParticipant{UserID: "m1", Status: models.ParticipantStatusInvited, GroupID: "grp_1"})
+	responded := authzEvent()
+	responded.ID = "e2"
+	responded.Participants = append(responded.Participants,
+		models.EventParticipant{UserID: "m1", Status: models.ParticipantStatusResponded, GroupID: "grp_1"})
+
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+	m.groups.On("RemoveMember", ctx, "grp_1", "m1").Return(nil)
+	m.participants.On("GetGroupEventIDs", ctx, "grp_1").Return([]string{"e1", "e2"}, nil)
+	m.events.On("GetByID", ctx, "e1").Return(event, nil)
+	m.events.On("GetByID", ctx, "e2").Return(responded, nil)
+	m.events.On("Touch", ctx, "e1", 0).Return(2, nil)
+	m.participants.On("AddParticipants", ctx, "e1", []models.EventParticipant(nil)).Return(nil)
+	m.participants.On("RemoveParticipants", ctx, "e1", []string{"m1"}).Return(nil)
+
+	err := svc.RemoveMember(ctx, "grp_1", "m1")
+
+	require.NoError(t, err)
+	m.participants.AssertExpectations(t)
+	m.events.AssertNotCalled(t, "Touch", ctx, "e2", 0)
+}
+
+func TestGroupService_RemoveMember_Authorization(t *testing.T) {
+	t.Run("member leaves", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("m1")
+		m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+		m.groups.On("RemoveMember", ctx, "grp_1", "m1").Return(nil)
+		m.participants.On("GetGroupEventIDs", ctx, "grp_1").Return([]string{}, nil)
+
+		assert.NoError(t, svc.RemoveMember(ctx, "grp_1", "m1"))
+	})
+
+	t.Run("member leaves events they do not manage", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("m1")
+		event := authzEvent()
+		event.Participants = append(event.Participants,
+			models.EventParticipant{UserID: "m1", Status: models.ParticipantStatusInvited, GroupID: "grp_1"})
+		m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+		m.groups.On("RemoveMember", ctx, "grp_1", "m1").Return(nil)
+		m.participants.On("GetGroupEventIDs", ctx, "grp_1").Return([]string{"e1"}, nil)
+		m.events.On("GetByID", ctx, "e1").Return(event, nil)
+		m.events.On("Touch", ctx, "e1", 0).Return(2, nil)
+		m.participants.On("AddParticipants", ctx, "e1", []models.EventParticipant(nil)).Return(nil)
+		m.participants.On("RemoveParticipants", ctx, "e1", []string{"m1"}).Return(nil)
+
+		assert.NoError(t, svc.RemoveMember(ctx, "grp_1", "m1"))
+		m.participants.AssertExpectations(t)
+	})
+
+	t.Run("member removes another", func(t *testing.T) {
+		svc, m := setupGroupService()
+		ctx := userContext("m1")
+		m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+
+		err := svc.RemoveMember(ctx, "grp_1", "p1")
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		m.groups.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGroupService_InviteGroup(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("org")
+
+	group := team()
+	group.Members = append(group.Members, models.User{ID: "org"})
+	m.events.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	m.groups.On("GetByID", ctx, "grp_1").Return(group, nil)
+	m.events.On("Touch", ctx, "e1", 0).Return(2, nil)
+	m.participants.On("AddParticipants", ctx, "e1", []models.EventParticipant{
+		{UserID: "m1", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleAttendee, GroupID: "grp_1"},
+	}).Return(nil)
+
+	added, err := svc.InviteGroup(ctx, "e1", "grp_1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m1"}, added)
+	m.participants.AssertExpectations(t)
+}
+
+func TestGroupService_InviteGroup_GroupNotVisible(t *testing.T) {
+	svc, m := setupGroupService()
+	ctx := userContext("co")
+
+	m.events.On("GetByID", ctx, "e1").Return(authzEvent(), nil)
+	m.groups.On("GetByID", ctx, "grp_1").Return(team(), nil)
+
+	_, err := svc.InviteGroup(ctx, "e1", "grp_1")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	m.participants.AssertNotCalled(t, "AddParticipants", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// GuestRespondPath is the route prefix guest respond links point at.
+const GuestRespondPath = "/api/v1/respond/"
+
+// GuestService invites people to events by email and lets guests without an
+// account respond through a signed link.
+type GuestService struct {
+	eventRepo           repository.EventRepository
+	userRepo            repository.UserRepository
+	participantRepo     repository.ParticipantRepository
+	auditRepo           repository.AuditRepository
+	uow                 repository.UnitOfWork
+	availabilityService *AvailabilityService
+	signer              *utils.GuestTokenSigner
+	baseURL             string
+	now                 func() time.Time
+}
+
+// NewGuestService creates a new guest service. baseURL is prefixed to respond
+// links; when empty the links are returned as paths.
+func NewGuestService(
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	participantRepo repository.ParticipantRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+	availabilityService *AvailabilityService,
+	signer *utils.GuestTokenSigner,
+	baseURL string,
+) *GuestService {
+	return &GuestService{
+		eventRepo:           eventRepo,
+		userRepo:            userRepo,
+		participantRepo:     participantRepo,
+		auditRepo:           auditRepo,
+		uow:                 uow,
+		availabilityService: availabilityService,
+		signer:              signer,
+		baseURL:             baseURL,
+		now:                 time.Now,
+	}
+}
+
+// InviteByEmail adds the owner of an email address as a participant. If no
+// user has that email, a guest identity is created and a respond link is
+// issued for it. Inviting a guest again reissues their link.
+func (s *GuestService) InviteByEmail(ctx context.Context, eventID string, invite models.GuestInvite) (*models.GuestInvitation, error) {
+	email := strings.TrimSpace(invite.Email)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, utils.InvalidField("email", "invalid email %q", invite.Email)
+	}
+
+	// Check if event exists
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return nil, err
+	}
+
+	// A new guest is only kept if they are invited too
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if user == nil {
+			if user, err = s.createGuest(ctx, email, invite); err != nil {
+				return err
+			}
+		}
+
+		if _, err := s.participantRepo.GetParticipant(ctx, eventID, user.ID); !errors.Is(err, utils.ErrNotFound) {
+			return err
+		}
+		participant := &models.EventParticipant{
+			EventID: eventID,
+			UserID:  user.ID,
+			Status:  models.ParticipantStatusInvited,
+			Role:    models.ParticipantRoleAttendee,
+		}
+		_, err := changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+			if err := s.participantRepo.AddParticipant(ctx, participant); err != nil {
+				return err
+			}
+			return recordParticipant(ctx, s.auditRepo, models.AuditActionParticipantAdd, eventID, user.ID, nil, participant)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.GuestInvitation{
+		UserID:  user.ID,
+		Email:   user.Email,
+		IsGuest: user.IsGuest,
+	}
+	if user.IsGuest {
+		token, expiresAt := s.signer.Sign(eventID, user.ID, s.now())
+		invitation.Token = token
+		invitation.RespondURL = s.baseURL + GuestRespondPath + token
+		invitation.ExpiresAt = &expiresAt
+	}
+
+	return invitation, nil
+}
+
+// createGuest creates a guest identity for an email with no account.
+func (s *GuestService) createGuest(ctx context.Context, email string, invite models.GuestInvite) (*models.User, error) {
+	guest := &models.User{
+		ID:       utils.GenerateUserID(),
+		Name:     strings.TrimSpace(invite.Name),
+		Email:    email,
+		Timezone: invite.Timezone,
+		IsGuest:  true,
+	}
+	if guest.Name == "" {
+		guest.Name = email
+	}
+	if guest.Timezone == "" {
+		guest.Timezone = "UTC"
+	} else if _, err := utils.LoadTimezone(guest.Timezone); err != nil {
+		return nil, utils.InvalidField("timezone", "%w", err)
+	}
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, guest); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionUserCreate,
+			entityType: models.AuditEntityUser,
+			entityID:   guest.ID,
+			after:      guest,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+// resolveToken verifies a respond link and checks that the guest is still a
+// participant, so removing a guest from an event revokes their link. The
+// returned context carries the guest as its principal, so the guest can only
+// act as themselves.
+func (s *GuestService) resolveToken(ctx context.Context, token string) (context.Context, *utils.GuestToken, *models.EventParticipant, error) {
+	claim, err := s.signer.Parse(token, s.now())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	participant, err := s.participantRepo.GetParticipant(ctx, claim.EventID, claim.UserID)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, nil, nil, utils.ErrInvalidGuestToken
+		}
+		return nil, nil, nil, err
+	}
+
+	ctx = auth.NewContext(ctx, &auth.Principal{
+		Kind:    auth.PrincipalGuest,
+		Subject: "guest:" + claim.UserID,
+		UserID:  claim.UserID,
+	})
+	return ctx, claim, participant, nil
+}
+
+// GetInvitation returns the event a respond link grants access to, together
+// with the guest's current response.
+func (s *GuestService) GetInvitation(ctx context.Context, token string) (*models.GuestEventView, error) {
+	ctx, claim, participant, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, claim.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, _, err := s.availabilityService.GetAvailability(ctx, claim.EventID, claim.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if slots == nil {
+		slots = []models.AvailabilitySlot{}
+	}
+
+	return &models.GuestEventView{
+		EventID:         event.ID,
+		Title:           event.Title,
+		Description:     event.Description,
+		DurationMinutes: event.DurationMinutes,
+		ProposedSlots:   event.ProposedSlots,
+		Status:          participant.Status,
+		ResponseReason:  participant.ResponseReason,
+		AvailableSlots:  slots,
+		ExpiresAt:       claim.ExpiresAt,
+	}, nil
+}
+
+// SubmitAvailability submits availability on behalf of the guest a respond
+// link was issued to. It behaves like AvailabilityService.SubmitAvailability.
+func (s *GuestService) SubmitAvailability(ctx context.Context, token string, req models.AvailabilityRequest) (*models.AvailabilitySubmission, error) {
+	ctx, claim, _, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.availabilityService.SubmitAvailability(ctx, claim.EventID, claim.UserID, req)
+}
+
+// UpdateAvailability replaces the availability of the guest a respond link
+// was issued to. It behaves like AvailabilityService.UpdateAvailability.
+func (s *GuestService) UpdateAvailability(ctx context.Context, token string, req models.AvailabilityRequest) (*models.AvailabilitySubmission, error) {
+	ctx, claim, _, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.availabilityService.UpdateAvailability(ctx, claim.EventID, claim.UserID, req)
+}
+
+// RespondToInvitation records a declined or tentative RSVP for the guest a
+// respond link was issued to.
+func (s *GuestService) RespondToInvitation(ctx context.Context, token string, req models.RSVPRequest) (*models.EventParticipant, error) {
+	ctx, claim, _, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.availabilityService.RespondToInvitation(ctx, claim.EventID, claim.UserID, req)
+}
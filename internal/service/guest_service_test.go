@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var guestTestNow = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func setupGuestSvc() (
+	*GuestService,
+	*MockAvailabilityRepository,
+	*MockEventRepository,
+	*MockParticipantRepository,
+	*MockUserRepository,
+) {
+	avail := new(MockAvailabilityRepository)
+	event := new(MockEventRepository)
+	part := new(MockParticipantRepository)
+	user := new(MockUserRepository)
+	availSvc := NewAvailabilityService(avail, event, part, user, new(MockIdempotencyRepository), auditLog(), new(MockUnitOfWork))
+	signer := utils.NewGuestTokenSigner([]byte("test-secret"), 24*time.Hour)
+	svc := NewGuestService(event, user, part, auditLog(), new(MockUnitOfWork), availSvc, signer, "https://slots.example.com")
+	svc.now = func() time.Time { return guestTestNow }
+	return svc, avail, event, part, user
+}
+
+func TestGuestService_InviteByEmail_CreatesGuest(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupGuestSvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByEmail", ctx, "guest@example.com").Return(nil, utils.NotFound("user not found"))
+	userRepo.On("Create", ctx, mock.MatchedBy(func(u *models.User) bool {
+		return u.IsGuest && u.Email == "guest@example.com" && u.Name == "Guest" && u.Timezone == "UTC"
+	})).Return(nil)
+	partRepo.On("GetParticipant", ctx, "e1", mock.AnythingOfType("string")).Return(nil, utils.NotFound("participant not found"))
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	partRepo.On("AddParticipant", ctx, mock.MatchedBy(func(p *models.EventParticipant) bool {
+		return p.EventID == "e1" && p.Status == models.ParticipantStatusInvited
+	})).Return(nil)
+
+	invitation, err := svc.InviteByEmail(ctx, "e1", models.GuestInvite{Email: "guest@example.com", Name: "Guest"})
+
+	require.NoError(t, err)
+	assert.True(t, invitation.IsGuest)
+	assert.NotEmpty(t, invitation.UserID)
+	assert.Equal(t, "https://slots.example.com/api/v1/respond/"+invitation.Token, invitation.RespondURL)
+	assert.Equal(t, guestTestNow.Add(24*time.Hour), *invitation.ExpiresAt)
+
+	claim, err := svc.signer.Parse(invitation.Token, guestTestNow)
+	require.NoError(t, err)
+	assert.Equal(t, "e1", claim.EventID)
+	assert.Equal(t, invitation.UserID, claim.UserID)
+	userRepo.AssertExpectations(t)
+	partRepo.AssertExpectations(t)
+}
+
+func TestGuestService_InviteByEmail_RegisteredUserGetsNoLink(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupGuestSvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByEmail", ctx, "member@example.com").Return(&models.User{ID: "u1", Email: "member@example.com"}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "u1").Return(nil, utils.NotFound("participant not found"))
+	eventRepo.On("Touch", ctx, "e1", 0).Return(2, nil)
+	partRepo.On("AddParticipant", ctx, mock.AnythingOfType("*models.EventParticipant")).Return(nil)
+
+	invitation, err := svc.InviteByEmail(ctx, "e1", models.GuestInvite{Email: "member@example.com"})
+
+	require.NoError(t, err)
+	assert.False(t, invitation.IsGuest)
+	assert.Equal(t, "u1", invitation.UserID)
+	assert.Empty(t, invitation.Token)
+	assert.Nil(t, invitation.ExpiresAt)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestGuestService_InviteByEmail_ReinviteReissuesLink(t *testing.T) {
+	svc, _, eventRepo, partRepo, userRepo := setupGuestSvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByEmail", ctx, "guest@example.com").Return(&models.User{ID: "g1", Email: "guest@example.com", IsGuest: true}, nil)
+	partRepo.On("GetParticipant", ctx, "e1", "g1").Return(&models.EventParticipant{}, nil)
+
+	invitation, err := svc.InviteByEmail(ctx, "e1", models.GuestInvite{Email: "guest@example.com"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, invitation.Token)
+	partRepo.AssertNotCalled(t, "AddParticipant", mock.Anything, mock.Anything)
+}
+
+func TestGuestService_InviteByEmail_InvalidEmail(t *testing.T) {
+	svc, _, eventRepo, _, _ := setupGuestSvc()
+
+	for _, email := range []string{"", "not-an-email", "Guest <guest@example.com>"} {
+		_, err := svc.InviteByEmail(context.Background(), "e1", models.GuestInvite{Email: email})
+		assert.ErrorContains(t, err, "invalid email", email)
+	}
+	eventRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestGuestService_InviteByEmail_EventNotFound(t *testing.T) {
+	svc, _, eventRepo, _, _ := setupGuestSvc()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("event not found"))
+
+	_, err := svc.InviteByEmail(ctx, "ghost", models.GuestInvite{Email: "guest@example.com"})
+
+	assert.EqualError(t, err, "event not found")
+}
+
+func TestGuestService_GetInvitation(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, _ := setupGuestSvc()
+	ctx := context.Background()
+	token, expiresAt := svc.signer.Sign("e1", "g1", guestTestNow)
+
+	partRepo.On("GetParticipant", ctx, "e1", "g1").Return(&models.EventParticipant{Status: models.ParticipantStatusInvited}, nil)
+	// Calls made after the token is resolved carry the guest principal
+	eventRepo.On("GetByID", mock.Anything, "e1").Return(&models.Event{
+		ID:              "e1",
+		Title:           "Interview",
+		DurationMinutes: 30,
+		Participants:    []models.EventParticipant{{UserID: "u1"}, {UserID: "g1"}},
+	}, nil)
+	availRepo.On("GetByEventAndUser", mock.Anything, "e1", "g1").Return([]models.AvailabilitySlot(nil), nil)
+
+	view, err := svc.GetInvitation(ctx, token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "e1", view.EventID)
+	assert.Equal(t, "Interview", view.Title)
+	assert.Equal(t, models.ParticipantStatusInvited, view.Status)
+	assert.Equal(t, []models.AvailabilitySlot{}, view.AvailableSlots)
+	assert.Equal(t, expiresAt, view.ExpiresAt)
+}
+
+func TestGuestService_RemovedGuestLinkIsRejected(t *testing.T) {
+	svc, _, _, partRepo, _ := setupGuestSvc()
+	ctx := context.Background()
+	token, _ := svc.signer.Sign("e1", "g1", guestTestNow)
+
+	partRepo.On("GetParticipant", ctx, "e1", "g1").Return(nil, utils.NotFound("participant not found"))
+
+	_, err := svc.GetInvitation(ctx, token)
+
+	assert.ErrorIs(t, err, utils.ErrInvalidGuestToken)
+}
+
+func TestGuestService_ExpiredLinkIsRejected(t *testing.T) {
+	svc, _, _, partRepo, _ := setupGuestSvc()
+	token, _ := svc.signer.Sign("e1", "g1", guestTestNow.Add(-48*time.Hour))
+
+	_, err := svc.SubmitAvailability(context.Background(), token, models.AvailabilityRequest{})
+
+	assert.ErrorIs(t, err, utils.ErrExpiredGuestToken)
+	partRepo.AssertNotCalled(t, "GetParticipant", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGuestService_SubmitAvailability_ScopedToTokenEvent(t *testing.T) {
+	svc, availRepo, eventRepo, partRepo, userRepo := setupGuestSvc()
+	ctx := context.Background()
+	token, _ := svc.signer.Sign("e1", "g1", guestTestNow)
+
+	partRepo.On("GetParticipant", mock.Anything, "e1", "g1").Return(&models.EventParticipant{}, nil)
+	eventRepo.On("GetByID", mock.Anything, "e1").Return(&models.Event{ID: "e1"}, nil)
+	userRepo.On("GetByID", mock.Anything, "g1").Return(&models.User{ID: "g1", IsGuest: true}, nil)
+	availRepo.On("GetByEventAndUser", mock.Anything, "e1", "g1").Return([]models.AvailabilitySlot{}, nil)
+	eventRepo.On("Touch", mock.Anything, "e1", 0).Return(2, nil)
+	availRepo.On("SaveUserSlots", mock.Anything, "e1", "g1", mock.AnythingOfType("[]models.AvailabilitySlot"), models.ParticipantStatusResponded).Return(nil)
+
+	_, err := svc.SubmitAvailability(ctx, token, models.AvailabilityRequest{AvailableSlots: validAvailabilitySlots()})
+
+	require.NoError(t, err)
+	availRepo.AssertExpectations(t)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// MaxImportRows is the most rows a single import may contain.
+const MaxImportRows = 1000
+
+// ImportService imports users, and event participants, from CSV files with
+// the columns name,email[,timezone]. A header row naming the columns is
+// optional. Users are matched to existing accounts by email; the others are
+// created. Each row succeeds or fails on its own.
+type ImportService struct {
+	userService  *UserService
+	eventService *EventService
+	eventRepo    repository.EventRepository
+}
+
+// NewImportService creates a new import service
+func NewImportService(userService *UserService, eventService *EventService, eventRepo repository.EventRepository) *ImportService {
+	return &ImportService{
+		userService:  userService,
+		eventService: eventService,
+		eventRepo:    eventRepo,
+	}
+}
+
+// importRow is a parsed CSV row, or the reason it could not be parsed.
+type importRow struct {
+	line int
+	user models.User
+	err  error
+}
+
+// ImportUsers creates the users listed in file that do not exist yet. A dry
+// run validates every row and reports what would happen without writing.
+func (s *ImportService) ImportUsers(ctx context.Context, file io.Reader, dryRun bool) (*models.ImportResult, error) {
+	rows, err := parseImportCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ImportResult{DryRun: dryRun, Results: make([]models.ImportRowResult, 0, len(rows))}
+	for _, row := range s.resolveRows(ctx, rows, dryRun) {
+		addImportRow(result, row)
+	}
+	return result, nil
+}
+
+// ImportParticipants resolves the users listed in file as ImportUsers does
+// and invites them to an event in one participant batch. Only the organizer
+// and co-organizers may import participants.
+func (s *ImportService) ImportParticipants(ctx context.Context, eventID string, file io.Reader, dryRun bool) (*models.ImportResult, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	rows, err := parseImportCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := s.resolveRows(ctx, rows, dryRun)
+	result := &models.ImportResult{DryRun: dryRun, Results: make([]models.ImportRowResult, 0, len(rows))}
+	result.Version = event.Version
+
+	if dryRun {
+		present := make(map[string]bool, len(event.Participants))
+		for _, p := range event.Participants {
+			present[p.UserID] = true
+		}
+		for i := range resolved {
+			switch {
+			case resolved[i].Result == models.ImportResultError:
+			case present[resolved[i].UserID]:
+				resolved[i].Participant = models.ParticipantResultAlreadyPresent
+			default:
+				resolved[i].Participant = models.ParticipantResultAdded
+			}
+		}
+	} else if err := s.addParticipants(ctx, eventID, resolved, result); err != nil {
+		return nil, err
+	}
+
+	for _, row := range resolved {
+		addImportRow(result, row)
+	}
+	return result, nil
+}
+
+// addParticipants adds the resolved users to an event in a partial batch and
+// records each outcome on its row.
+func (s *ImportService) addParticipants(ctx context.Context, eventID string, rows []models.ImportRowResult, result *models.ImportResult) error {
+	var ops []models.ParticipantOperation
+	var indexes []int
+	for i, row := range rows {
+		if row.UserID != "" {
+			ops = append(ops, models.ParticipantOperation{Op: models.ParticipantOpAdd, UserID: row.UserID})
+			indexes = append(indexes, i)
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	batch, err := s.eventService.BatchParticipants(ctx, eventID, models.ParticipantBatchRequest{
+		Mode:       models.BatchModePartial,
+		Operations: ops,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, outcome := range batch.Results {
+		row := &rows[indexes[i]]
+		row.Participant = outcome.Result
+		row.Code = outcome.Code
+		row.Error = outcome.Error
+	}
+	result.Version = batch.Version
+	return nil
+}
+
+// resolveRows matches or creates the user of each row. Only the first row
+// with a given email is imported.
+func (s *ImportService) resolveRows(ctx context.Context, rows []importRow, dryRun bool) []models.ImportRowResult {
+	results := make([]models.ImportRowResult, 0, len(rows))
+	firstLine := make(map[string]int, len(rows))
+	for _, row := range rows {
+		email := strings.ToLower(row.user.Email)
+		if line, ok := firstLine[email]; ok && row.err == nil && email != "" {
+			row.err = utils.InvalidField("email", "email already appears on row %d", line)
+		} else if email != "" {
+			firstLine[email] = row.line
+		}
+		results = append(results, s.resolveRow(ctx, row, dryRun))
+	}
+	return results
+}
+
+// resolveRow finds the user of a row by email, or creates it.
+func (s *ImportService) resolveRow(ctx context.Context, row importRow, dryRun bool) models.ImportRowResult {
+	result := models.ImportRowResult{Row: row.line, Email: row.user.Email}
+	fail := func(err error) models.ImportRowResult {
+		_, info := utils.ErrorStatus(err)
+		result.Result = models.ImportResultError
+		result.Code = info.Code
+		result.Error = info.Message
+		return result
+	}
+
+	if row.err != nil {
+		return fail(row.err)
+	}
+	if err := utils.ValidateStruct(&row.user); err != nil {
+		return fail(err)
+	}
+
+	existing, err := s.userService.GetUserByEmail(ctx, row.user.Email)
+	if err == nil {
+		result.Result = models.ImportResultExisting
+		result.UserID = existing.ID
+		return result
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return fail(err)
+	}
+
+	result.Result = models.ImportResultCreated
+	if dryRun {
+		return result
+	}
+
+	user := row.user
+	if err := s.userService.CreateUser(ctx, &user); err != nil {
+		// Someone else created the user since the lookup
+		if errors.Is(err, utils.ErrConflict) {
+			if existing, lookupErr := s.userService.GetUserByEmail(ctx, row.user.Email); lookupErr == nil {
+				result.Result = models.ImportResultExisting
+				result.UserID = existing.ID
+				return result
+			}
+		}
+		return fail(err)
+	}
+	result.UserID = user.ID
+	return result
+}
+
+// addImportRow appends a row's outcome to result and counts it. A row counts
+// as failed if either its user or its participant could not be imported.
+func addImportRow(result *models.ImportResult, row models.ImportRowResult) {
+	result.Results = append(result.Results, row)
+	switch {
+	case row.Result == models.ImportResultError || row.Participant == models.ParticipantResultFailed:
+		result.Failed++
+	case row.Result == models.ImportResultCreated:
+		result.Created++
+	default:
+		result.Existing++
+	}
+}
+
+// parseImportCSV reads the rows of an import file. A file that is not valid
+// CSV, or has no rows or too many, is rejected; a row with the wrong number
+// of columns is returned with its error.
+func parseImportCSV(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, utils.Invalid("invalid CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if len(rows) == 0 && line == 1 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			if isImportHeader(record) {
+				continue
+			}
+		}
+
+		if len(rows) == MaxImportRows {
+			return nil, utils.Invalid("at most %d rows can be imported at once", MaxImportRows)
+		}
+
+		row := importRow{line: line}
+		switch {
+		case len(record) < 2 || len(record) > 3:
+			row.err = utils.Invalid("expected name,email[,timezone] but got %d columns", len(record))
+		default:
+			row.user = models.User{Name: record[0], Email: record[1]}
+			if len(record) == 3 {
+				row.user.Timezone = record[2]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, utils.Invalid("the file has no rows to import")
+	}
+	return rows, nil
+}
+
+// isImportHeader reports whether record names the import columns rather
+// than holding a user.
+func isImportHeader(record []string) bool {
+	return len(record) >= 2 && strings.EqualFold(record[0], "name") && strings.EqualFold(record[1], "email")
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupImportService() (*ImportService, *MockUserRepository, *MockEventRepository, *MockParticipantRepository) {
+	userRepo := new(MockUserRepository)
+	eventRepo := new(MockEventRepository)
+	partRepo := new(MockParticipantRepository)
+	eventService := NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork))
+	return NewImportService(NewUserService(userRepo, auditLog(), new(MockUnitOfWork)), eventService, eventRepo), userRepo, eventRepo, partRepo
+}
+
+const importCSV = "name,email,timezone\n" +
+	"Ada Lovelace,ada@example.com,Europe/London\n" +
+	"Existing User,p1@example.com\n" +
+	"No Email\n" +
+	"Bad Zone,zone@example.com,Mars/Olympus\n" +
+	"Ada Again,ADA@example.com\n"
+
+func TestImportService_ImportUsers(t *testing.T) {
+	svc, userRepo, _, _ := setupImportService()
+	ctx := context.Background()
+
+	userRepo.On("GetByEmail", ctx, "ada@example.com").Return(nil, utils.NotFound("user not found")).Twice()
+	userRepo.On("GetByEmail", ctx, "p1@example.com").Return(&models.User{ID: "p1"}, nil)
+	userRepo.On("Create", ctx, mock.MatchedBy(func(u *models.User) bool {
+		return u.Email == "ada@example.com" && u.Timezone == "Europe/London"
+	})).Return(nil)
+
+	result, err := svc.ImportUsers(ctx, strings.NewReader(importCSV), false)
+
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Existing)
+	assert.Equal(t, 3, result.Failed)
+
+	rows := result.Results
+	require.Len(t, rows, 5)
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, []int{rows[0].Row, rows[1].Row, rows[2].Row, rows[3].Row, rows[4].Row})
+	assert.Equal(t, models.ImportResultCreated, rows[0].Result)
+	assert.Contains(t, rows[0].UserID, utils.UserIDPrefix)
+	assert.Equal(t, models.ImportResultExisting, rows[1].Result)
+	assert.Equal(t, "p1", rows[1].UserID)
+	for _, row := range rows[2:] {
+		assert.Equal(t, models.ImportResultError, row.Result)
+		assert.Equal(t, utils.CodeValidationError, row.Code)
+	}
+	assert.Contains(t, rows[4].Error, "row 2")
+	userRepo.AssertExpectations(t)
+}
+
+func TestImportService_ImportUsers_DryRun(t *testing.T) {
+	svc, userRepo, _, _ := setupImportService()
+	ctx := context.Background()
+
+	userRepo.On("GetByEmail", ctx, "ada@example.com").Return(nil, utils.NotFound("user not found"))
+	userRepo.On("GetByEmail", ctx, "p1@example.com").Return(&models.User{ID: "p1"}, nil)
+
+	result, err := svc.ImportUsers(ctx, strings.NewReader("Ada,ada@example.com\nP One,p1@example.com"), true)
+
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, models.ImportResultCreated, result.Results[0].Result)
+	assert.Empty(t, result.Results[0].UserID)
+	assert.Equal(t, 1, result.Results[0].Row)
+	assert.Equal(t, models.ImportResultExisting, result.Results[1].Result)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestImportService_ImportUsers_CreatedConcurrently(t *testing.T) {
+	svc, userRepo, _, _ := setupImportService()
+	ctx := context.Background()
+
+	userRepo.On("GetByEmail", ctx, "ada@example.com").Return(nil, utils.NotFound("user not found")).Twice()
+	userRepo.On("Create", ctx, mock.Anything).Return(utils.Conflict("email already exists"))
+	userRepo.On("GetByEmail", ctx, "ada@example.com").Return(&models.User{ID: "ada"}, nil)
+
+	result, err := svc.ImportUsers(ctx, strings.NewReader("Ada,ada@example.com"), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportResultExisting, result.Results[0].Result)
+	assert.Equal(t, "ada", result.Results[0].UserID)
+}
+
+func TestImportService_ImportUsers_RejectsFile(t *testing.T) {
+	tooMany := strings.Repeat("A,a@example.com\n", MaxImportRows+1)
+
+	tests := map[string]string{
+		"empty":       "",
+		"header only": "name,email\n",
+		"bad quoting": "\"Ada,ada@example.com\n",
+		"too many":    tooMany,
+	}
+	for name, file := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, _, _, _ := setupImportService()
+
+			_, err := svc.ImportUsers(context.Background(), strings.NewReader(file), false)
+
+			assert.ErrorIs(t, err, utils.ErrValidation)
+		})
+	}
+}
+
+func TestImportService_ImportParticipants(t *testing.T) {
+	svc, userRepo, eventRepo, partRepo := setupImportService()
+	ctx := userContext("org")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, utils.NotFound("user not found")).Twice()
+	userRepo.On("GetByEmail", ctx, "p1@example.com").Return(&models.User{ID: "p1"}, nil)
+	userRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).
+		Run(func(args mock.Arguments) { args.Get(1).(*models.User).ID = "new" }).
+		Return(nil)
+	userRepo.On("GetByIDs", ctx, []string{"new", "p1"}).Return([]*models.User{{ID: "new"}, {ID: "p1"}}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(5, nil)
+	partRepo.On("AddParticipants", ctx, "e1", []models.EventParticipant{
+		{UserID: "new", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleAttendee},
+	}).Return(nil)
+	partRepo.On("RemoveParticipants", ctx, "e1", []string(nil)).Return(nil)
+
+	result, err := svc.ImportParticipants(ctx, "e1", strings.NewReader("New,new@example.com\nP One,p1@example.com\nbroken"), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Version)
+	assert.Equal(t, "new", result.Results[0].UserID)
+	assert.Equal(t, models.ParticipantResultAdded, result.Results[0].Participant)
+	assert.Equal(t, models.ImportResultExisting, result.Results[1].Result)
+	assert.Equal(t, models.ParticipantResultAlreadyPresent, result.Results[1].Participant)
+	assert.Equal(t, models.ImportResultError, result.Results[2].Result)
+	assert.Empty(t, result.Results[2].Participant)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Existing)
+	assert.Equal(t, 1, result.Failed)
+	partRepo.AssertExpectations(t)
+}
+
+func TestImportService_ImportParticipants_DryRun(t *testing.T) {
+	svc, userRepo, eventRepo, _ := setupImportService()
+	ctx := userContext("org")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, utils.NotFound("user not found"))
+	userRepo.On("GetByEmail", ctx, "p1@example.com").Return(&models.User{ID: "p1"}, nil)
+	userRepo.On("GetByEmail", ctx, "u3@example.com").Return(&models.User{ID: "u3"}, nil)
+
+	result, err := svc.ImportParticipants(ctx, "e1",
+		strings.NewReader("New,new@example.com\nP One,p1@example.com\nU Three,u3@example.com"), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Version)
+	assert.Equal(t, []string{"added", "already_present", "added"}, []string{
+		result.Results[0].Participant, result.Results[1].Participant, result.Results[2].Participant,
+	})
+	eventRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestImportService_ImportParticipants_AttendeeForbidden(t *testing.T) {
+	svc, userRepo, eventRepo, _ := setupImportService()
+	ctx := userContext("p1")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+
+	_, err := svc.ImportParticipants(ctx, "e1", strings.NewReader("New,new@example.com"), false)
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+}
+
+func TestParseImportCSV_Lines(t *testing.T) {
+	rows, err := parseImportCSV(strings.NewReader("\ufeffName, Email\n\n  Ada , ada@example.com ,UTC\n\"Multi\nLine\",m@example.com,,extra\n"))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 3, rows[0].line)
+	assert.Equal(t, models.User{Name: "Ada", Email: "ada@example.com", Timezone: "UTC"}, rows[0].user)
+	assert.Equal(t, 4, rows[1].line)
+	assert.EqualError(t, rows[1].err, fmt.Sprintf("expected name,email[,timezone] but got %d columns", 4))
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"meeting-slot-service/internal/models"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -21,6 +22,31 @@ type MockParticipantRepository struct {
 type MockUserRepository struct {
 	mock.Mock
 }
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+type MockGroupRepository struct {
+	mock.Mock
+}
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+// MockUnitOfWork runs fn directly with the caller's context, so repository
+// mocks match the same ctx, and records how the last unit of work ended.
+type MockUnitOfWork struct {
+	Calls int
+	Err   error
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	m.Err = fn(ctx)
+	return m.Err
+}
 
 func (m *MockEventRepository) Create(ctx context.Context, event *models.Event) error {
 	args := m.Called(ctx, event)
@@ -40,21 +66,70 @@ func (m *MockEventRepository) Update(ctx context.Context, event *models.Event) e
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) UpdateOrganizer(ctx context.Context, eventID, organizerID string) error {
+	args := m.Called(ctx, eventID, organizerID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) AddProposedSlot(ctx context.Context, slot *models.ProposedSlot) error {
+	args := m.Called(ctx, slot)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) UpdateProposedSlot(ctx context.Context, slot *models.ProposedSlot) error {
+	args := m.Called(ctx, slot)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) DeleteProposedSlot(ctx context.Context, eventID string, slotID uint) error {
+	args := m.Called(ctx, eventID, slotID)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) Touch(ctx context.Context, id string, version int) (int, error) {
+	args := m.Called(ctx, id, version)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockEventRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) List(ctx context.Context, filter models.EventFilter) ([]*models.Event, int, error) {
-	args := m.Called(ctx, filter)
-	return args.Get(0).([]*models.Event), args.Int(1), args.Error(2)
+func (m *MockEventRepository) GetDeleted(ctx context.Context, id string, deletedAfter time.Time) (*models.Event, error) {
+	args := m.Called(ctx, id, deletedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
 }
 
-func (m *MockAvailabilityRepository) CreateSlots(ctx context.Context, slots []models.AvailabilitySlot) error {
-	args := m.Called(ctx, slots)
+func (m *MockEventRepository) Restore(ctx context.Context, id string, deletedAfter time.Time) error {
+	args := m.Called(ctx, id, deletedAfter)
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) GetIDsByDeletedOrganizer(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	args := m.Called(ctx, deletedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockEventRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockEventRepository) List(ctx context.Context, filter models.EventFilter) (*models.EventPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EventPage), args.Error(1)
+}
+
 func (m *MockAvailabilityRepository) GetByEventAndUser(ctx context.Context, eventID, userID string) ([]models.AvailabilitySlot, error) {
 	args := m.Called(ctx, eventID, userID)
 	return args.Get(0).([]models.AvailabilitySlot), args.Error(1)
@@ -65,8 +140,13 @@ func (m *MockAvailabilityRepository) GetByEvent(ctx context.Context, eventID str
 	return args.Get(0).([]models.AvailabilitySlot), args.Error(1)
 }
 
-func (m *MockAvailabilityRepository) UpdateUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot) error {
-	args := m.Called(ctx, eventID, userID, slots)
+func (m *MockAvailabilityRepository) GetByUser(ctx context.Context, userID string) ([]models.AvailabilitySlot, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]models.AvailabilitySlot), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) SaveUserSlots(ctx context.Context, eventID, userID string, slots []models.AvailabilitySlot, participantStatus string) error {
+	args := m.Called(ctx, eventID, userID, slots, participantStatus)
 	return args.Error(0)
 }
 
@@ -80,6 +160,11 @@ func (m *MockParticipantRepository) AddParticipant(ctx context.Context, particip
 	return args.Error(0)
 }
 
+func (m *MockParticipantRepository) AddParticipants(ctx context.Context, eventID string, participants []models.EventParticipant) error {
+	args := m.Called(ctx, eventID, participants)
+	return args.Error(0)
+}
+
 func (m *MockParticipantRepository) GetEventParticipants(ctx context.Context, eventID string) ([]models.EventParticipant, error) {
 	args := m.Called(ctx, eventID)
 	return args.Get(0).([]models.EventParticipant), args.Error(1)
@@ -98,11 +183,42 @@ func (m *MockParticipantRepository) RemoveParticipant(ctx context.Context, event
 	return args.Error(0)
 }
 
+func (m *MockParticipantRepository) RemoveParticipants(ctx context.Context, eventID string, userIDs []string) error {
+	args := m.Called(ctx, eventID, userIDs)
+	return args.Error(0)
+}
+
 func (m *MockParticipantRepository) UpdateParticipantStatus(ctx context.Context, eventID, userID, status string) error {
 	args := m.Called(ctx, eventID, userID, status)
 	return args.Error(0)
 }
 
+func (m *MockParticipantRepository) UpdateParticipantResponse(ctx context.Context, eventID, userID, status, reason string) error {
+	args := m.Called(ctx, eventID, userID, status, reason)
+	return args.Error(0)
+}
+
+func (m *MockParticipantRepository) UpdateParticipantRole(ctx context.Context, eventID, userID, role string) error {
+	args := m.Called(ctx, eventID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockParticipantRepository) GetGroupEventIDs(ctx context.Context, groupID string) ([]string, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockParticipantRepository) GetUserParticipations(ctx context.Context, userID string) ([]models.UserParticipation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.UserParticipation), args.Error(1)
+}
+
 func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
 	return m.Called(ctx, user).Error(0)
 }
@@ -115,6 +231,11 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*models.Us
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) IsDeleted(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
@@ -123,6 +244,14 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*mod
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	return m.Called(ctx, user).Error(0)
 }
@@ -131,7 +260,113 @@ func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	return m.Called(ctx, id).Error(0)
 }
 
+func (m *MockUserRepository) Restore(ctx context.Context, id string, deletedAfter time.Time) error {
+	return m.Called(ctx, id, deletedAfter).Error(0)
+}
+
+func (m *MockUserRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) Erase(ctx context.Context, id, name, email string) (*models.User, error) {
+	args := m.Called(ctx, id, name, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	args := m.Called(ctx, limit, offset)
 	return args.Get(0).([]*models.User), args.Error(1)
 }
+
+func (m *MockIdempotencyRepository) Get(ctx context.Context, scope, key string) (*models.IdempotencyRecord, error) {
+	args := m.Called(ctx, scope, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) DeleteByUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Save(ctx context.Context, record *models.IdempotencyRecord) error {
+	return m.Called(ctx, record).Error(0)
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) Record(ctx context.Context, entry *models.AuditEntry) error {
+	return m.Called(ctx, entry).Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter models.AuditFilter) (*models.AuditPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuditPage), args.Error(1)
+}
+
+func (m *MockAuditRepository) RedactUser(ctx context.Context, userID string) error {
+	return m.Called(ctx, userID).Error(0)
+}
+
+func (m *MockGroupRepository) Create(ctx context.Context, group *models.Group) error {
+	return m.Called(ctx, group).Error(0)
+}
+
+func (m *MockGroupRepository) GetByID(ctx context.Context, id string) (*models.Group, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) List(ctx context.Context, userID string) ([]*models.Group, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*models.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) Update(ctx context.Context, group *models.Group) error {
+	return m.Called(ctx, group).Error(0)
+}
+
+func (m *MockGroupRepository) Delete(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockGroupRepository) AddMembers(ctx context.Context, groupID string, userIDs []string) error {
+	return m.Called(ctx, groupID, userIDs).Error(0)
+}
+
+func (m *MockGroupRepository) RemoveMember(ctx context.Context, groupID, userID string) error {
+	return m.Called(ctx, groupID, userID).Error(0)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// BatchParticipants adds, removes and changes the role of several
+// participants of an event in one unit of work. The users are looked up in
+// one query and new participants are inserted in one statement.
+//
+// In atomic mode, the default, a failing operation rejects the whole batch
+// with an ErrValidation error detailing every failure. In partial mode the
+// other operations are applied and the failures are reported in the result.
+// Adding a user who is already a participant is reported as already_present
+// and is not a failure. A user may appear in only one operation per batch.
+func (s *EventService) BatchParticipants(ctx context.Context, eventID string, req models.ParticipantBatchRequest) (*models.ParticipantBatchResult, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = models.BatchModeAtomic
+	}
+	if mode != models.BatchModeAtomic && mode != models.BatchModePartial {
+		return nil, utils.InvalidField("mode", "invalid mode %q: must be %q or %q",
+			mode, models.BatchModeAtomic, models.BatchModePartial)
+	}
+	if len(req.Operations) == 0 {
+		return nil, utils.InvalidField("operations", "at least one operation is required")
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	batch, err := s.newParticipantBatch(ctx, event, req.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ParticipantBatchResult{
+		Mode:    mode,
+		Results: make([]models.ParticipantOperationResult, 0, len(req.Operations)),
+		Version: event.Version,
+	}
+	var failures []utils.FieldError
+	for i, op := range req.Operations {
+		outcome := models.ParticipantOperationResult{Op: op.Op, UserID: op.UserID}
+		if outcome.Result, err = batch.plan(op); err != nil {
+			_, info := utils.ErrorStatus(err)
+			outcome.Result = models.ParticipantResultFailed
+			outcome.Code = info.Code
+			outcome.Error = info.Message
+			failures = append(failures, utils.FieldError{
+				Field:   fmt.Sprintf("operations[%d]", i),
+				Message: info.Message,
+			})
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Results = append(result.Results, outcome)
+	}
+
+	if len(failures) > 0 && mode == models.BatchModeAtomic {
+		return nil, &utils.Error{
+			Kind:    utils.ErrValidation,
+			Message: fmt.Sprintf("%d of %d operations failed; nothing was changed", len(failures), len(req.Operations)),
+			Fields:  failures,
+		}
+	}
+
+	if batch.empty() {
+		return result, nil
+	}
+
+	version, err := changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+		if err := s.participantRepo.AddParticipants(ctx, eventID, batch.adds); err != nil {
+			return err
+		}
+		for _, p := range batch.roles {
+			if err := s.participantRepo.UpdateParticipantRole(ctx, eventID, p.UserID, p.Role); err != nil {
+				return err
+			}
+		}
+		if err := s.participantRepo.RemoveParticipants(ctx, eventID, batch.removes); err != nil {
+			return err
+		}
+		return batch.record(ctx, s.auditRepo, eventID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Version = version
+	return result, nil
+}
+
+// participantBatch plans the writes of a participant batch against the
+// event's current participants.
+type participantBatch struct {
+	participants map[string]models.EventParticipant
+	users        map[string]*models.User
+	seen         map[string]bool
+
+	adds    []models.EventParticipant
+	roles   []models.EventParticipant
+	removes []string
+}
+
+// newParticipantBatch loads the users named by ops in one query.
+func (s *EventService) newParticipantBatch(ctx context.Context, event *models.Event, ops []models.ParticipantOperation) (*participantBatch, error) {
+	ids := make([]string, 0, len(ops))
+	for _, op := range ops {
+		if op.UserID != "" {
+			ids = append(ids, op.UserID)
+		}
+	}
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &participantBatch{
+		participants: make(map[string]models.EventParticipant, len(event.Participants)),
+		users:        make(map[string]*models.User, len(users)),
+		seen:         make(map[string]bool, len(ops)),
+	}
+	for _, p := range event.Participants {
+		batch.participants[p.UserID] = p
+	}
+	for _, user := range users {
+		batch.users[user.ID] = user
+	}
+	return batch, nil
+}
+
+// plan checks op and queues its write, returning the outcome to report.
+func (b *participantBatch) plan(op models.ParticipantOperation) (string, error) {
+	if op.UserID == "" {
+		return "", utils.InvalidField("user_id", "user_id is required")
+	}
+	if b.seen[op.UserID] {
+		return "", utils.Invalid("user %s appears in more than one operation", op.UserID)
+	}
+	b.seen[op.UserID] = true
+
+	if op.Role != "" && op.Role != models.ParticipantRoleAttendee && op.Role != models.ParticipantRoleCoOrganizer {
+		return "", utils.InvalidField("role", "invalid role %q: must be %q or %q",
+			op.Role, models.ParticipantRoleAttendee, models.ParticipantRoleCoOrganizer)
+	}
+	if op.Role == models.ParticipantRoleCoOrganizer {
+		if user := b.users[op.UserID]; user != nil && user.IsGuest {
+			return "", utils.InvalidField("role", "guests cannot be co-organizers")
+		}
+	}
+	_, present := b.participants[op.UserID]
+
+	switch op.Op {
+	case models.ParticipantOpAdd:
+		if b.users[op.UserID] == nil {
+			return "", utils.NotFound("user not found")
+		}
+		if present {
+			return models.ParticipantResultAlreadyPresent, nil
+		}
+		role := op.Role
+		if role == "" {
+			role = models.ParticipantRoleAttendee
+		}
+		b.adds = append(b.adds, models.EventParticipant{
+			UserID: op.UserID,
+			Status: models.ParticipantStatusInvited,
+			Role:   role,
+		})
+		return models.ParticipantResultAdded, nil
+
+	case models.ParticipantOpRemove:
+		if !present {
+			return "", utils.NotFound("participant not found")
+		}
+		b.removes = append(b.removes, op.UserID)
+		return models.ParticipantResultRemoved, nil
+
+	case models.ParticipantOpSetRole:
+		if op.Role == "" {
+			return "", utils.InvalidField("role", "role is required for %s", models.ParticipantOpSetRole)
+		}
+		if !present {
+			return "", utils.NotFound("participant not found")
+		}
+		b.roles = append(b.roles, models.EventParticipant{UserID: op.UserID, Role: op.Role})
+		return models.ParticipantResultUpdated, nil
+
+	default:
+		return "", utils.InvalidField("op", "invalid op %q: must be %s, %s or %s",
+			op.Op, models.ParticipantOpAdd, models.ParticipantOpRemove, models.ParticipantOpSetRole)
+	}
+}
+
+// record adds an audit log entry for each participant the batch changed.
+func (b *participantBatch) record(ctx context.Context, auditRepo repository.AuditRepository, eventID string) error {
+	for i := range b.adds {
+		add := &b.adds[i]
+		if err := recordParticipant(ctx, auditRepo, models.AuditActionParticipantAdd, eventID, add.UserID, nil, add); err != nil {
+			return err
+		}
+	}
+	for _, p := range b.roles {
+		before := b.participants[p.UserID]
+		after := before
+		after.Role = p.Role
+		if err := recordParticipant(ctx, auditRepo, models.AuditActionParticipantUpdate, eventID, p.UserID, &before, &after); err != nil {
+			return err
+		}
+	}
+	for _, userID := range b.removes {
+		before := b.participants[userID]
+		if err := recordParticipant(ctx, auditRepo, models.AuditActionParticipantRemove, eventID, userID, &before, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// empty reports whether the batch has nothing to write.
+func (b *participantBatch) empty() bool {
+	return len(b.adds) == 0 && len(b.roles) == 0 && len(b.removes) == 0
+}
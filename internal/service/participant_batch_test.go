@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupParticipantBatch() (*EventService, *MockEventRepository, *MockUserRepository, *MockParticipantRepository) {
+	eventRepo := new(MockEventRepository)
+	userRepo := new(MockUserRepository)
+	partRepo := new(MockParticipantRepository)
+	return NewEventService(eventRepo, userRepo, partRepo, auditLog(), new(MockUnitOfWork)), eventRepo, userRepo, partRepo
+}
+
+func batchEvent() *models.Event {
+	event := authzEvent()
+	event.Version = 4
+	return event
+}
+
+func TestEventService_BatchParticipants_Atomic(t *testing.T) {
+	svc, eventRepo, userRepo, partRepo := setupParticipantBatch()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByIDs", ctx, []string{"u3", "p1", "p2", "co"}).Return([]*models.User{
+		{ID: "u3"}, {ID: "p1"}, {ID: "p2"}, {ID: "co"},
+	}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(5, nil)
+	partRepo.On("AddParticipants", ctx, "e1", []models.EventParticipant{
+		{UserID: "u3", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleAttendee},
+	}).Return(nil)
+	partRepo.On("UpdateParticipantRole", ctx, "e1", "p2", models.ParticipantRoleCoOrganizer).Return(nil)
+	partRepo.On("RemoveParticipants", ctx, "e1", []string{"co"}).Return(nil)
+
+	result, err := svc.BatchParticipants(ctx, "e1", models.ParticipantBatchRequest{
+		Operations: []models.ParticipantOperation{
+			{Op: models.ParticipantOpAdd, UserID: "u3"},
+			{Op: models.ParticipantOpAdd, UserID: "p1"},
+			{Op: models.ParticipantOpSetRole, UserID: "p2", Role: models.ParticipantRoleCoOrganizer},
+			{Op: models.ParticipantOpRemove, UserID: "co"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.BatchModeAtomic, result.Mode)
+	assert.Equal(t, 4, result.Succeeded)
+	assert.Zero(t, result.Failed)
+	assert.Equal(t, 5, result.Version)
+	assert.Equal(t, []string{"added", "already_present", "updated", "removed"}, []string{
+		result.Results[0].Result, result.Results[1].Result, result.Results[2].Result, result.Results[3].Result,
+	})
+	eventRepo.AssertExpectations(t)
+	partRepo.AssertExpectations(t)
+}
+
+func TestEventService_BatchParticipants_AtomicRejectsAll(t *testing.T) {
+	svc, eventRepo, userRepo, partRepo := setupParticipantBatch()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByIDs", ctx, mock.Anything).Return([]*models.User{{ID: "u3"}}, nil)
+
+	_, err := svc.BatchParticipants(ctx, "e1", models.ParticipantBatchRequest{
+		Operations: []models.ParticipantOperation{
+			{Op: models.ParticipantOpAdd, UserID: "u3"},
+			{Op: models.ParticipantOpAdd, UserID: "ghost"},
+			{Op: models.ParticipantOpRemove, UserID: "nobody"},
+		},
+	})
+
+	require.ErrorIs(t, err, utils.ErrValidation)
+	var e *utils.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, []utils.FieldError{
+		{Field: "operations[1]", Message: "user not found"},
+		{Field: "operations[2]", Message: "participant not found"},
+	}, e.Fields)
+	eventRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+	partRepo.AssertNotCalled(t, "AddParticipants", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventService_BatchParticipants_Partial(t *testing.T) {
+	svc, eventRepo, userRepo, partRepo := setupParticipantBatch()
+	ctx := context.Background()
+
+	guest := &models.User{ID: "g1", IsGuest: true}
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByIDs", ctx, mock.Anything).Return([]*models.User{{ID: "u3"}, guest}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(5, nil)
+	partRepo.On("AddParticipants", ctx, "e1", []models.EventParticipant{
+		{UserID: "u3", Status: models.ParticipantStatusInvited, Role: models.ParticipantRoleAttendee},
+	}).Return(nil)
+	partRepo.On("RemoveParticipants", ctx, "e1", []string(nil)).Return(nil)
+
+	result, err := svc.BatchParticipants(ctx, "e1", models.ParticipantBatchRequest{
+		Mode: models.BatchModePartial,
+		Operations: []models.ParticipantOperation{
+			{Op: models.ParticipantOpAdd, UserID: "u3"},
+			{Op: models.ParticipantOpRemove, UserID: "u3"},
+			{Op: models.ParticipantOpAdd, UserID: "g1", Role: models.ParticipantRoleCoOrganizer},
+			{Op: models.ParticipantOpAdd, UserID: "ghost"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 3, result.Failed)
+	assert.Equal(t, models.ParticipantResultAdded, result.Results[0].Result)
+	for _, r := range result.Results[1:] {
+		assert.Equal(t, models.ParticipantResultFailed, r.Result)
+	}
+	assert.Equal(t, utils.CodeValidationError, result.Results[1].Code)
+	assert.Equal(t, utils.CodeValidationError, result.Results[2].Code)
+	assert.Equal(t, utils.CodeNotFound, result.Results[3].Code)
+	assert.Equal(t, 5, result.Version)
+	partRepo.AssertExpectations(t)
+}
+
+func TestEventService_BatchParticipants_NothingToWrite(t *testing.T) {
+	svc, eventRepo, userRepo, _ := setupParticipantBatch()
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+	userRepo.On("GetByIDs", ctx, []string{"p1"}).Return([]*models.User{{ID: "p1"}}, nil)
+
+	result, err := svc.BatchParticipants(ctx, "e1", models.ParticipantBatchRequest{
+		Operations: []models.ParticipantOperation{{Op: models.ParticipantOpAdd, UserID: "p1"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ParticipantResultAlreadyPresent, result.Results[0].Result)
+	assert.Equal(t, 4, result.Version)
+	eventRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEventService_BatchParticipants_AttendeeForbidden(t *testing.T) {
+	svc, eventRepo, userRepo, _ := setupParticipantBatch()
+	ctx := userContext("p1")
+
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+
+	_, err := svc.BatchParticipants(ctx, "e1", models.ParticipantBatchRequest{
+		Operations: []models.ParticipantOperation{{Op: models.ParticipantOpRemove, UserID: "p2"}},
+	})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	userRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+}
+
+func TestEventService_BatchParticipants_InvalidMode(t *testing.T) {
+	svc, eventRepo, _, _ := setupParticipantBatch()
+
+	_, err := svc.BatchParticipants(context.Background(), "e1", models.ParticipantBatchRequest{
+		Mode:       "best_effort",
+		Operations: []models.ParticipantOperation{{Op: models.ParticipantOpAdd, UserID: "u3"}},
+	})
+
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	eventRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"meeting-slot-service/internal/utils"
+)
+
+// patchDocument parses a merge patch, which must be a JSON object, and
+// rejects it if it sets any of the read-only members.
+func patchDocument(patch []byte, readOnly ...string) (map[string]json.RawMessage, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &doc); err != nil || doc == nil {
+		return nil, utils.Invalid("patch must be a JSON object")
+	}
+	for _, field := range readOnly {
+		if _, ok := doc[field]; ok {
+			return nil, utils.InvalidField(field, "%s cannot be changed with PATCH", field)
+		}
+	}
+	return doc, nil
+}
+
+// isJSONObject reports whether raw holds a JSON object.
+func isJSONObject(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
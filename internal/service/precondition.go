@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"meeting-slot-service/internal/repository"
+)
+
+// ifMatchKey is the context key for the event version a request is
+// conditional on.
+type ifMatchKey struct{}
+
+// WithIfMatch returns a context in which changes to an event succeed only
+// while the event is still at version, as named by an If-Match header.
+func WithIfMatch(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, version)
+}
+
+// ifMatch returns the version set with WithIfMatch, or 0 when changes are
+// unconditional.
+func ifMatch(ctx context.Context) int {
+	version, _ := ctx.Value(ifMatchKey{}).(int)
+	return version
+}
+
+// changeEvent runs write and bumps the event's version in one unit of work,
+// and returns the new version. The version is bumped first, so a request
+// whose If-Match is stale fails before anything is written.
+func changeEvent(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	eventRepo repository.EventRepository,
+	eventID string,
+	write func(ctx context.Context) error,
+) (int, error) {
+	var version int
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if version, err = eventRepo.Touch(ctx, eventID, ifMatch(ctx)); err != nil {
+			return err
+		}
+		return write(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
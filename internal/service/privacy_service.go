@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// erasedUserName replaces the name of an erased user.
+const erasedUserName = "Erased user"
+
+// erasedUserEmail returns the email that replaces an erased user's. It stays
+// unique, and the .invalid domain can never receive mail.
+func erasedUserEmail(userID string) string {
+	return userID + "@erased.invalid"
+}
+
+// PrivacyService serves data subject requests: exporting the personal data
+// held about a user, and erasing it.
+type PrivacyService struct {
+	userRepo         repository.UserRepository
+	eventRepo        repository.EventRepository
+	participantRepo  repository.ParticipantRepository
+	availabilityRepo repository.AvailabilityRepository
+	idempotencyRepo  repository.IdempotencyRepository
+	auditRepo        repository.AuditRepository
+	uow              repository.UnitOfWork
+	now              func() time.Time
+}
+
+// NewPrivacyService creates a new privacy service
+func NewPrivacyService(
+	userRepo repository.UserRepository,
+	eventRepo repository.EventRepository,
+	participantRepo repository.ParticipantRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	idempotencyRepo repository.IdempotencyRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+) *PrivacyService {
+	return &PrivacyService{
+		userRepo:         userRepo,
+		eventRepo:        eventRepo,
+		participantRepo:  participantRepo,
+		availabilityRepo: availabilityRepo,
+		idempotencyRepo:  idempotencyRepo,
+		auditRepo:        auditRepo,
+		uow:              uow,
+		now:              time.Now,
+	}
+}
+
+// ExportUser returns a user's profile, participations, availability and
+// organized events. Only the user and admins may export a user's data.
+func (s *PrivacyService) ExportUser(ctx context.Context, userID string) (*models.UserExport, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	participations, err := s.participantRepo.GetUserParticipations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.availabilityRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organized, err := s.organizedEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserExport{
+		ExportedAt:      s.now().UTC(),
+		Profile:         user,
+		Participations:  participations,
+		Availability:    availability,
+		OrganizedEvents: organized,
+	}, nil
+}
+
+// organizedEvents returns every event a user organizes. The participants'
+// profiles are left out: they are other people's data.
+func (s *PrivacyService) organizedEvents(ctx context.Context, userID string) ([]*models.Event, error) {
+	filter := models.EventFilter{
+		OrganizerID: userID,
+		Sort:        models.EventSortDefault,
+		Page:        1,
+		Limit:       100,
+	}
+
+	events := make([]*models.Event, 0)
+	for {
+		page, err := s.eventRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Events {
+			for i := range event.Participants {
+				event.Participants[i].User = nil
+			}
+		}
+		events = append(events, page.Events...)
+
+		if page.NextCursor == "" {
+			return events, nil
+		}
+		filter.Cursor = page.NextCursor
+		filter.Page = 0
+	}
+}
+
+// EraseUser anonymizes a user: their name and email are replaced and the
+// reasons they gave when replying to invitations removed. The user keeps
+// their ID, participations and availability, so other people's events are
+// unaffected. The state recorded in the audit log for the user, their
+// participations and their availability is dropped, and the erasure itself
+// is recorded without it. Stored responses to their idempotent requests are
+// deleted, so they are not replayed. Deleted users can be erased without waiting for the
+// purge. Only the user and admins may erase a user.
+func (s *PrivacyService) EraseUser(ctx context.Context, userID string) (*models.User, error) {
+	if p := auth.FromContext(ctx); !unrestricted(p) && p.UserID != userID {
+		return nil, utils.Forbidden("only the user can erase their data")
+	}
+
+	var user *models.User
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = s.userRepo.Erase(ctx, userID, erasedUserName, erasedUserEmail(userID)); err != nil {
+			return err
+		}
+		if err := s.idempotencyRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.auditRepo.RedactUser(ctx, userID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionUserErase,
+			entityType: models.AuditEntityUser,
+			entityID:   userID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type privacyMocks struct {
+	users        *MockUserRepository
+	events       *MockEventRepository
+	participants *MockParticipantRepository
+	availability *MockAvailabilityRepository
+	idempotency  *MockIdempotencyRepository
+	audit        *MockAuditRepository
+}
+
+func setupPrivacyService() (*PrivacyService, privacyMocks) {
+	m := privacyMocks{
+		users:        new(MockUserRepository),
+		events:       new(MockEventRepository),
+		participants: new(MockParticipantRepository),
+		availability: new(MockAvailabilityRepository),
+		idempotency:  new(MockIdempotencyRepository),
+		audit:        new(MockAuditRepository),
+	}
+	svc := NewPrivacyService(m.users, m.events, m.participants, m.availability, m.idempotency, m.audit, new(MockUnitOfWork))
+	return svc, m
+}
+
+func TestPrivacyService_ExportUser(t *testing.T) {
+	svc, m := setupPrivacyService()
+	ctx := userContext("org")
+	exportedAt := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return exportedAt }
+
+	first := authzEvent()
+	first.Participants[0].User = &models.User{ID: "co", Email: "co@example.com"}
+	second := authzEvent()
+	second.ID = "e2"
+
+	m.users.On("GetByID", ctx, "org").Return(&models.User{ID: "org", Email: "org@example.com"}, nil)
+	m.participants.On("GetUserParticipations", ctx, "org").
+		Return([]models.UserParticipation{{EventID: "e9", EventTitle: "Offsite", Status: models.ParticipantStatusInvited}}, nil)
+	m.availability.On("GetByUser", ctx, "org").Return([]models.AvailabilitySlot{{EventID: "e9", UserID: "org"}}, nil)
+	m.events.On("List", ctx, models.EventFilter{OrganizerID: "org", Sort: models.EventSortDefault, Page: 1, Limit: 100}).
+		Return(&models.EventPage{Events: []*models.Event{first}, NextCursor: "next"}, nil)
+	m.events.On("List", ctx, models.EventFilter{OrganizerID: "org", Sort: models.EventSortDefault, Cursor: "next", Limit: 100}).
+		Return(&models.EventPage{Events: []*models.Event{second}}, nil)
+
+	export, err := svc.ExportUser(ctx, "org")
+
+	require.NoError(t, err)
+	assert.Equal(t, exportedAt, export.ExportedAt)
+	assert.Equal(t, "org@example.com", export.Profile.Email)
+	assert.Len(t, export.Participations, 1)
+	assert.Len(t, export.Availability, 1)
+	require.Len(t, export.OrganizedEvents, 2)
+	assert.Nil(t, export.OrganizedEvents[0].Participants[0].User)
+	m.events.AssertExpectations(t)
+}
+
+func TestPrivacyService_ExportUser_SomeoneElse(t *testing.T) {
+	svc, m := setupPrivacyService()
+
+	_, err := svc.ExportUser(userContext("p1"), "org")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	m.users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyService_EraseUser(t *testing.T) {
+	svc, m := setupPrivacyService()
+	ctx := adminContext()
+
+	m.users.On("Erase", ctx, "p1", "Erased user", "p1@erased.invalid").
+		Return(&models.User{ID: "p1", Name: "Erased user", Email: "p1@erased.invalid"}, nil)
+	m.idempotency.On("DeleteByUser", ctx, "p1").Return(nil)
+	m.audit.On("RedactUser", ctx, "p1").Return(nil)
+	m.audit.On("Record", ctx, mock.MatchedBy(func(e *models.AuditEntry) bool {
+		return e.Action == models.AuditActionUserErase && e.EntityType == models.AuditEntityUser && e.EntityID == "p1" &&
+			e.Before == nil && e.After == nil
+	})).Return(nil)
+
+	user, err := svc.EraseUser(ctx, "p1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Erased user", user.Name)
+	m.users.AssertExpectations(t)
+	m.idempotency.AssertExpectations(t)
+	m.audit.AssertExpectations(t)
+}
+
+func TestPrivacyService_EraseUser_RecordsActor(t *testing.T) {
+	svc, m := setupPrivacyService()
+	ctx := userContext("p1")
+
+	m.users.On("Erase", ctx, "p1", mock.Anything, mock.Anything).Return(&models.User{ID: "p1"}, nil)
+	m.idempotency.On("DeleteByUser", ctx, "p1").Return(nil)
+	m.audit.On("RedactUser", ctx, "p1").Return(nil)
+	m.audit.On("Record", ctx, mock.MatchedBy(func(e *models.AuditEntry) bool { return e.Actor == "p1" })).Return(nil)
+
+	_, err := svc.EraseUser(ctx, "p1")
+
+	require.NoError(t, err)
+	m.audit.AssertExpectations(t)
+}
+
+func TestPrivacyService_EraseUser_Rejects(t *testing.T) {
+	t.Run("someone else", func(t *testing.T) {
+		svc, m := setupPrivacyService()
+
+		_, err := svc.EraseUser(userContext("org"), "p1")
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		m.users.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		svc, m := setupPrivacyService()
+		ctx := adminContext()
+		m.users.On("Erase", ctx, "ghost", mock.Anything, mock.Anything).Return(nil, utils.NotFound("user not found"))
+
+		_, err := svc.EraseUser(ctx, "ghost")
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		m.audit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// ProposedSlotService handles changes to an event's proposed slots one slot
+// at a time
+type ProposedSlotService struct {
+	eventRepo        repository.EventRepository
+	availabilityRepo repository.AvailabilityRepository
+	auditRepo        repository.AuditRepository
+	uow              repository.UnitOfWork
+}
+
+// NewProposedSlotService creates a new proposed slot service
+func NewProposedSlotService(
+	eventRepo repository.EventRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+) *ProposedSlotService {
+	return &ProposedSlotService{
+		eventRepo:        eventRepo,
+		availabilityRepo: availabilityRepo,
+		auditRepo:        auditRepo,
+		uow:              uow,
+	}
+}
+
+// ListProposedSlots returns an event's proposed slots and the event's version
+func (s *ProposedSlotService) ListProposedSlots(ctx context.Context, eventID string) ([]models.ProposedSlot, int, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, 0, err
+	}
+
+	return event.ProposedSlots, event.Version, nil
+}
+
+// GetProposedSlot returns a single proposed slot and the event's version
+func (s *ProposedSlotService) GetProposedSlot(ctx context.Context, eventID string, slotID uint) (*models.ProposedSlot, int, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, 0, err
+	}
+
+	i, err := findProposedSlot(event, slotID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &event.ProposedSlots[i], event.Version, nil
+}
+
+// AddProposedSlot adds a proposed slot to an event
+func (s *ProposedSlotService) AddProposedSlot(ctx context.Context, eventID string, slot *models.ProposedSlot) (*models.ProposedSlotChange, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := validateSlot(0, slot.StartTime, slot.EndTime, slot.Timezone); err != nil {
+		return nil, err
+	}
+
+	slot.ID = 0
+	slot.EventID = eventID
+	after := append(append([]models.ProposedSlot{}, event.ProposedSlots...), *slot)
+
+	return s.changeSlots(ctx, event, after, models.AuditActionProposedSlotAdd, nil, slot, func(ctx context.Context) error {
+		return s.eventRepo.AddProposedSlot(ctx, slot)
+	})
+}
+
+// UpdateProposedSlot replaces the times of a proposed slot, keeping its ID
+func (s *ProposedSlotService) UpdateProposedSlot(ctx context.Context, eventID string, slotID uint, slot *models.ProposedSlot) (*models.ProposedSlotChange, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	i, err := findProposedSlot(event, slotID)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceSlot(ctx, event, i, slot)
+}
+
+// PatchProposedSlot applies a JSON merge patch (RFC 7396) to a proposed slot
+func (s *ProposedSlotService) PatchProposedSlot(ctx context.Context, eventID string, slotID uint, patch []byte) (*models.ProposedSlotChange, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	i, err := findProposedSlot(event, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := patchDocument(patch, "id"); err != nil {
+		return nil, err
+	}
+	var slot models.ProposedSlot
+	if err := utils.ApplyMergePatch(event.ProposedSlots[i], patch, &slot); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateStruct(&slot); err != nil {
+		return nil, err
+	}
+	return s.replaceSlot(ctx, event, i, &slot)
+}
+
+// RemoveProposedSlot removes a proposed slot. An event keeps at least one.
+func (s *ProposedSlotService) RemoveProposedSlot(ctx context.Context, eventID string, slotID uint) (*models.ProposedSlotChange, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	i, err := findProposedSlot(event, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if len(event.ProposedSlots) == 1 {
+		return nil, utils.Conflict("cannot remove the only proposed slot of an event")
+	}
+
+	after := append(append([]models.ProposedSlot{}, event.ProposedSlots[:i]...), event.ProposedSlots[i+1:]...)
+
+	removed := &event.ProposedSlots[i]
+	return s.changeSlots(ctx, event, after, models.AuditActionProposedSlotRemove, removed, nil, func(ctx context.Context) error {
+		return s.eventRepo.DeleteProposedSlot(ctx, eventID, slotID)
+	})
+}
+
+// replaceSlot stores slot in place of the event's i-th proposed slot.
+func (s *ProposedSlotService) replaceSlot(ctx context.Context, event *models.Event, i int, slot *models.ProposedSlot) (*models.ProposedSlotChange, error) {
+	if err := validateSlot(0, slot.StartTime, slot.EndTime, slot.Timezone); err != nil {
+		return nil, err
+	}
+
+	slot.ID = event.ProposedSlots[i].ID
+	slot.EventID = event.ID
+	slot.CreatedAt = event.ProposedSlots[i].CreatedAt
+	after := append([]models.ProposedSlot{}, event.ProposedSlots...)
+	after[i] = *slot
+
+	return s.changeSlots(ctx, event, after, models.AuditActionProposedSlotUpdate, &event.ProposedSlots[i], slot, func(ctx context.Context) error {
+		return s.eventRepo.UpdateProposedSlot(ctx, slot)
+	})
+}
+
+// changeSlots runs write as a change of the event, records it in the audit
+// log as action turning before into slot, and reports the participants whose
+// availability the new proposed slots no longer cover. before is nil for an
+// added slot and slot for a removed one.
+func (s *ProposedSlotService) changeSlots(
+	ctx context.Context,
+	event *models.Event,
+	after []models.ProposedSlot,
+	action string,
+	before, slot *models.ProposedSlot,
+	write func(ctx context.Context) error,
+) (*models.ProposedSlotChange, error) {
+	var availability []models.AvailabilitySlot
+	version, err := changeEvent(ctx, s.uow, s.eventRepo, event.ID, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+
+		// An added slot only has an ID once it is written
+		audited := before
+		if audited == nil {
+			audited = slot
+		}
+		err := recordAudit(ctx, s.auditRepo, auditChange{
+			action:     action,
+			entityType: models.AuditEntityProposedSlot,
+			entityID:   strconv.FormatUint(uint64(audited.ID), 10),
+			eventID:    event.ID,
+			before:     before,
+			after:      slot,
+		})
+		if err != nil {
+			return err
+		}
+
+		availability, err = s.availabilityRepo.GetByEvent(ctx, event.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProposedSlotChange{
+		Slot:                   slot,
+		StrandedParticipantIDs: strandedParticipants(availability, event.ProposedSlots, after),
+		Version:                version,
+	}, nil
+}
+
+// findProposedSlot returns the index of the event's proposed slot with slotID
+func findProposedSlot(event *models.Event, slotID uint) (int, error) {
+	for i, slot := range event.ProposedSlots {
+		if slot.ID == slotID {
+			return i, nil
+		}
+	}
+	return 0, utils.NotFound("proposed slot %d not found", slotID)
+}
+
+// strandedParticipants returns, in order of first appearance, the users with
+// an availability slot that the before windows cover and the after windows
+// do not.
+func strandedParticipants(availability []models.AvailabilitySlot, before, after []models.ProposedSlot) []string {
+	beforeWindows := mergedWindows(before)
+	afterWindows := mergedWindows(after)
+
+	stranded := []string{}
+	seen := make(map[string]bool)
+	for _, slot := range availability {
+		if seen[slot.UserID] {
+			continue
+		}
+		ts := utils.TimeSlot{
+			Start: utils.NormalizeToUTC(slot.StartTime),
+			End:   utils.NormalizeToUTC(slot.EndTime),
+		}
+		if covered(ts, beforeWindows) && !covered(ts, afterWindows) {
+			seen[slot.UserID] = true
+			stranded = append(stranded, slot.UserID)
+		}
+	}
+	return stranded
+}
+
+// covered reports whether one of the merged windows contains slot.
+func covered(slot utils.TimeSlot, windows []utils.TimeSlot) bool {
+	for _, w := range windows {
+		if w.Contains(slot) {
+			return true
+		}
+	}
+	return false
+}
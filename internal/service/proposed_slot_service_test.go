@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func slotAt(day, startHour, endHour int) (time.Time, time.Time) {
+	return time.Date(2025, 1, day, startHour, 0, 0, 0, time.UTC), time.Date(2025, 1, day, endHour, 0, 0, 0, time.UTC)
+}
+
+func availabilityAt(userID string, day, startHour, endHour int) models.AvailabilitySlot {
+	start, end := slotAt(day, startHour, endHour)
+	return models.AvailabilitySlot{EventID: "e1", UserID: userID, StartTime: start, EndTime: end, Timezone: "UTC"}
+}
+
+func TestProposedSlotService_AddProposedSlot(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	availRepo := new(MockAvailabilityRepository)
+	uow := new(MockUnitOfWork)
+	svc := NewProposedSlotService(eventRepo, availRepo, auditLog(), uow)
+	ctx := context.Background()
+
+	start, end := slotAt(14, 9, 11)
+	slot := &models.ProposedSlot{StartTime: start, EndTime: end, Timezone: "UTC"}
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("AddProposedSlot", ctx, slot).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.ProposedSlot).ID = 3
+	})
+	availRepo.On("GetByEvent", ctx, "e1").Return([]models.AvailabilitySlot{availabilityAt("u2", 12, 9, 10)}, nil)
+
+	change, err := svc.AddProposedSlot(ctx, "e1", slot)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uow.Calls)
+	assert.Equal(t, uint(3), change.Slot.ID)
+	assert.Equal(t, "e1", change.Slot.EventID)
+	assert.Empty(t, change.StrandedParticipantIDs)
+	assert.Equal(t, 4, change.Version)
+}
+
+func TestProposedSlotService_UpdateProposedSlot_ReportsStranded(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	availRepo := new(MockAvailabilityRepository)
+	svc := NewProposedSlotService(eventRepo, availRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	// Slot 1 moves from 9-11 to 10-12 on the same day
+	start, end := slotAt(12, 10, 12)
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("UpdateProposedSlot", ctx, mock.MatchedBy(func(s *models.ProposedSlot) bool {
+		return s.ID == 1 && s.EventID == "e1" && s.StartTime.Equal(start)
+	})).Return(nil)
+	availRepo.On("GetByEvent", ctx, "e1").Return([]models.AvailabilitySlot{
+		availabilityAt("u2", 12, 9, 10),
+		availabilityAt("u2", 12, 10, 11),
+		availabilityAt("u3", 12, 10, 11),
+		availabilityAt("u4", 13, 9, 10),
+		availabilityAt("u5", 12, 9, 11),
+	}, nil)
+
+	change, err := svc.UpdateProposedSlot(ctx, "e1", 1, &models.ProposedSlot{StartTime: start, EndTime: end, Timezone: "UTC"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), change.Slot.ID)
+	assert.Equal(t, []string{"u2", "u5"}, change.StrandedParticipantIDs)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestProposedSlotService_PatchProposedSlot(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	availRepo := new(MockAvailabilityRepository)
+	svc := NewProposedSlotService(eventRepo, availRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("UpdateProposedSlot", ctx, mock.AnythingOfType("*models.ProposedSlot")).Return(nil)
+	availRepo.On("GetByEvent", ctx, "e1").Return([]models.AvailabilitySlot{}, nil)
+
+	change, err := svc.PatchProposedSlot(ctx, "e1", 2, []byte(`{"end_time":"2025-01-13T12:00:00Z"}`))
+
+	assert.NoError(t, err)
+	start, end := slotAt(13, 9, 12)
+	assert.Equal(t, uint(2), change.Slot.ID)
+	assert.True(t, change.Slot.StartTime.Equal(start))
+	assert.True(t, change.Slot.EndTime.Equal(end))
+}
+
+func TestProposedSlotService_RemoveProposedSlot_ReportsStranded(t *testing.T) {
+	eventRepo := new(MockEventRepository)
+	availRepo := new(MockAvailabilityRepository)
+	svc := NewProposedSlotService(eventRepo, availRepo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	eventRepo.On("GetByID", ctx, "e1").Return(patchableEvent(), nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("DeleteProposedSlot", ctx, "e1", uint(2)).Return(nil)
+	availRepo.On("GetByEvent", ctx, "e1").Return([]models.AvailabilitySlot{
+		availabilityAt("u2", 12, 9, 10),
+		availabilityAt("u3", 13, 9, 10),
+	}, nil)
+
+	change, err := svc.RemoveProposedSlot(ctx, "e1", 2)
+
+	assert.NoError(t, err)
+	assert.Nil(t, change.Slot)
+	assert.Equal(t, []string{"u3"}, change.StrandedParticipantIDs)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestProposedSlotService_Rejected(t *testing.T) {
+	onlySlot := patchableEvent()
+	onlySlot.ProposedSlots = onlySlot.ProposedSlots[:1]
+	start, end := slotAt(12, 9, 11)
+
+	tests := []struct {
+		name    string
+		event   *models.Event
+		call    func(svc *ProposedSlotService, ctx context.Context) error
+		wantErr error
+	}{
+		{
+			name:  "unknown slot",
+			event: patchableEvent(),
+			call: func(svc *ProposedSlotService, ctx context.Context) error {
+				_, err := svc.UpdateProposedSlot(ctx, "e1", 9, &models.ProposedSlot{StartTime: start, EndTime: end, Timezone: "UTC"})
+				return err
+			},
+			wantErr: utils.ErrNotFound,
+		},
+		{
+			name:  "end before start",
+			event: patchableEvent(),
+			call: func(svc *ProposedSlotService, ctx context.Context) error {
+				_, err := svc.AddProposedSlot(ctx, "e1", &models.ProposedSlot{StartTime: end, EndTime: start, Timezone: "UTC"})
+				return err
+			},
+			wantErr: utils.ErrValidation,
+		},
+		{
+			name:  "patch sets id",
+			event: patchableEvent(),
+			call: func(svc *ProposedSlotService, ctx context.Context) error {
+				_, err := svc.PatchProposedSlot(ctx, "e1", 1, []byte(`{"id":5}`))
+				return err
+			},
+			wantErr: utils.ErrValidation,
+		},
+		{
+			name:  "removing the only slot",
+			event: onlySlot,
+			call: func(svc *ProposedSlotService, ctx context.Context) error {
+				_, err := svc.RemoveProposedSlot(ctx, "e1", 1)
+				return err
+			},
+			wantErr: utils.ErrConflict,
+		},
+		{
+			name:  "not an organizer",
+			event: patchableEvent(),
+			call: func(svc *ProposedSlotService, _ context.Context) error {
+				_, err := svc.RemoveProposedSlot(userContext("u2"), "e1", 1)
+				return err
+			},
+			wantErr: utils.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := new(MockEventRepository)
+			uow := new(MockUnitOfWork)
+			svc := NewProposedSlotService(eventRepo, new(MockAvailabilityRepository), auditLog(), uow)
+			eventRepo.On("GetByID", mock.Anything, "e1").Return(tt.event, nil)
+
+			err := tt.call(svc, context.Background())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Zero(t, uow.Calls)
+		})
+	}
+}
@@ -35,7 +35,11 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 	// Get event
 	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
-		return nil, fmt.Errorf("event not found: %w", err)
+		return nil, err
+	}
+
+	if err := authorizeView(ctx, event); err != nil {
+		return nil, err
 	}
 
 	// Get participants
@@ -49,6 +53,7 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 			EventID:            eventID,
 			DurationMinutes:    event.DurationMinutes,
 			TotalParticipants:  0,
+			DeclinedUsers:      []string{},
 			BestRecommendation: nil,
 			Message:            "No participants found for this event",
 		}, nil
@@ -71,12 +76,30 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 		})
 	}
 
-	// Get all participant user IDs
+	// Get all participant user IDs. Declined participants are reported
+	// separately rather than counted as unavailable.
 	participantIDs := make([]string, 0, len(participants))
+	declinedUsers := []string{}
 	for _, p := range participants {
+		if p.Status == models.ParticipantStatusDeclined {
+			declinedUsers = append(declinedUsers, p.UserID)
+			continue
+		}
 		participantIDs = append(participantIDs, p.UserID)
 	}
 
+	if len(participantIDs) == 0 {
+		return &models.RecommendationResponse{
+			EventID:              eventID,
+			DurationMinutes:      event.DurationMinutes,
+			TotalParticipants:    0,
+			DeclinedParticipants: len(declinedUsers),
+			DeclinedUsers:        declinedUsers,
+			BestRecommendation:   nil,
+			Message:              "All participants have declined this event",
+		}, nil
+	}
+
 	// Find best recommendation
 	bestRecommendation, message := s.findBestSlot(
 		event.ProposedSlots,
@@ -86,11 +109,13 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, eventID
 	)
 
 	return &models.RecommendationResponse{
-		EventID:            eventID,
-		DurationMinutes:    event.DurationMinutes,
-		TotalParticipants:  len(participants),
-		BestRecommendation: bestRecommendation,
-		Message:            message,
+		EventID:              eventID,
+		DurationMinutes:      event.DurationMinutes,
+		TotalParticipants:    len(participantIDs),
+		DeclinedParticipants: len(declinedUsers),
+		DeclinedUsers:        declinedUsers,
+		BestRecommendation:   bestRecommendation,
+		Message:              message,
 	}, nil
 }
 
@@ -206,8 +231,13 @@ func (s *RecommendationService) checkCandidateSlot(
 		availabilityRate = float64(len(availableUsers)) / float64(len(participantIDs))
 	}
 
-	// Convert times back to original timezone for response
-	loc, _ := time.LoadLocation(timezone)
+	// Convert times back to original timezone for response. Slots stored
+	// before timezones were validated may carry an unknown name; report those
+	// in UTC and say so, instead of labelling UTC times with the bad name.
+	loc, err := utils.LoadTimezone(timezone)
+	if err != nil {
+		loc, timezone = time.UTC, "UTC"
+	}
 	startInTZ := candidate.Start.In(loc)
 	endInTZ := candidate.End.In(loc)
 
@@ -180,3 +180,77 @@ func TestCheckCandidateSlot(t *testing.T) {
 	assert.Contains(t, result.AvailableUsers, "user2")
 	assert.Contains(t, result.UnavailableUsers, "user3")
 }
+
+func TestRecommendationService_ExcludesDeclinedParticipants(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockAvailRepo := new(MockAvailabilityRepository)
+	mockPartRepo := new(MockParticipantRepository)
+
+	service := NewRecommendationService(mockEventRepo, mockAvailRepo, mockPartRepo)
+
+	ctx := context.Background()
+	eventID := "evt_123"
+
+	event := &models.Event{
+		ID:              eventID,
+		DurationMinutes: 60,
+		ProposedSlots: []models.ProposedSlot{
+			{
+				StartTime: time.Date(2025, 1, 12, 14, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2025, 1, 12, 16, 0, 0, 0, time.UTC),
+				Timezone:  "UTC",
+			},
+		},
+	}
+
+	participants := []models.EventParticipant{
+		{UserID: "user1", Status: models.ParticipantStatusResponded},
+		{UserID: "user2", Status: models.ParticipantStatusDeclined},
+	}
+
+	availabilitySlots := []models.AvailabilitySlot{
+		{
+			UserID:    "user1",
+			StartTime: time.Date(2025, 1, 12, 14, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 1, 12, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	mockEventRepo.On("GetByID", ctx, eventID).Return(event, nil)
+	mockPartRepo.On("GetEventParticipants", ctx, eventID).Return(participants, nil)
+	mockAvailRepo.On("GetByEvent", ctx, eventID).Return(availabilitySlots, nil)
+
+	result, err := service.GetRecommendations(ctx, eventID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.TotalParticipants)
+	assert.Equal(t, 1, result.DeclinedParticipants)
+	assert.Equal(t, []string{"user2"}, result.DeclinedUsers)
+	assert.Equal(t, 1.0, result.BestRecommendation.AvailabilityRate)
+	assert.NotContains(t, result.BestRecommendation.UnavailableUsers, "user2")
+}
+
+func TestRecommendationService_AllParticipantsDeclined(t *testing.T) {
+	mockEventRepo := new(MockEventRepository)
+	mockAvailRepo := new(MockAvailabilityRepository)
+	mockPartRepo := new(MockParticipantRepository)
+
+	service := NewRecommendationService(mockEventRepo, mockAvailRepo, mockPartRepo)
+
+	ctx := context.Background()
+	eventID := "evt_123"
+
+	mockEventRepo.On("GetByID", ctx, eventID).Return(&models.Event{ID: eventID, DurationMinutes: 30}, nil)
+	mockPartRepo.On("GetEventParticipants", ctx, eventID).Return([]models.EventParticipant{
+		{UserID: "user1", Status: models.ParticipantStatusDeclined},
+	}, nil)
+	mockAvailRepo.On("GetByEvent", ctx, eventID).Return([]models.AvailabilitySlot{}, nil)
+
+	result, err := service.GetRecommendations(ctx, eventID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.TotalParticipants)
+	assert.Equal(t, 1, result.DeclinedParticipants)
+	assert.Nil(t, result.BestRecommendation)
+	assert.Contains(t, result.Message, "declined")
+}
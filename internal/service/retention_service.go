@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/repository"
+	"meeting-slot-service/internal/utils"
+)
+
+// RetentionService restores deleted users and events while their retention
+// period lasts, and purges them once it is over. Deleting only hides a user
+// or event, so a restore brings back their participants, availability and
+// group memberships as they were.
+type RetentionService struct {
+	userRepo  repository.UserRepository
+	eventRepo repository.EventRepository
+	auditRepo repository.AuditRepository
+	uow       repository.UnitOfWork
+	period    time.Duration
+	now       func() time.Time
+}
+
+// NewRetentionService creates a new retention service. period is how long a
+// deleted user or event can be restored.
+func NewRetentionService(
+	userRepo repository.UserRepository,
+	eventRepo repository.EventRepository,
+	auditRepo repository.AuditRepository,
+	uow repository.UnitOfWork,
+	period time.Duration,
+) *RetentionService {
+	return &RetentionService{
+		userRepo:  userRepo,
+		eventRepo: eventRepo,
+		auditRepo: auditRepo,
+		uow:       uow,
+		period:    period,
+		now:       time.Now,
+	}
+}
+
+// RestoreUser undoes the deletion of a user. Only admins may restore a user:
+// a deleted user's own credentials no longer authenticate. Restoring a user
+// who is not deleted returns them unchanged.
+func (s *RetentionService) RestoreUser(ctx context.Context, userID string) (*models.User, error) {
+	if !unrestricted(auth.FromContext(ctx)) {
+		return nil, utils.Forbidden("only admins can restore an account")
+	}
+
+	if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		return user, nil
+	} else if !errors.Is(err, utils.ErrNotFound) {
+		return nil, err
+	}
+
+	var user *models.User
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Restore(ctx, userID, s.cutoff()); err != nil {
+			return err
+		}
+		var err error
+		if user, err = s.userRepo.GetByID(ctx, userID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionUserRestore,
+			entityType: models.AuditEntityUser,
+			entityID:   userID,
+			after:      user,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RestoreEvent undoes the deletion of an event and bumps its version. The
+// organizer and co-organizers, who may delete an event, may restore it.
+// Restoring an event that is not deleted returns it unchanged.
+func (s *RetentionService) RestoreEvent(ctx context.Context, eventID string) (*models.Event, error) {
+	cutoff := s.cutoff()
+	event, err := s.eventRepo.GetDeleted(ctx, eventID, cutoff)
+	if errors.Is(err, utils.ErrNotFound) {
+		live, liveErr := s.eventRepo.GetByID(ctx, eventID)
+		if liveErr != nil {
+			return nil, err
+		}
+		if err := authorizeManage(ctx, live); err != nil {
+			return nil, err
+		}
+		return live, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeManage(ctx, event); err != nil {
+		return nil, err
+	}
+
+	var restored *models.Event
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.eventRepo.Restore(ctx, eventID, cutoff); err != nil {
+			return err
+		}
+		if _, err := s.eventRepo.Touch(ctx, eventID, 0); err != nil {
+			return err
+		}
+		var err error
+		if restored, err = s.eventRepo.GetByID(ctx, eventID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, auditChange{
+			action:     models.AuditActionEventRestore,
+			entityType: models.AuditEntityEvent,
+			entityID:   eventID,
+			eventID:    eventID,
+			after:      auditEvent(restored),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge deletes for good the users and events deleted before the retention
+// period. A purged user's participations, availability, API keys and owned
+// groups go with them. The events they organize are handed over to their
+// first co-organizer, or purged with them when there is none. Only admins
+// may purge.
+func (s *RetentionService) Purge(ctx context.Context) (*models.PurgeResult, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.purge(ctx)
+}
+
+// RunPurgeJob purges at once and then every interval until ctx is done. It
+// acts on its own authority rather than a caller's.
+func (s *RetentionService) RunPurgeJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.purge(ctx)
+		switch {
+		case err != nil:
+			log.Printf("Purge failed: %v", err)
+		case result.Users > 0 || result.Events > 0:
+			log.Printf("Purged %d users and %d events deleted before %s",
+				result.Users, result.Events, result.DeletedBefore.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RetentionService) purge(ctx context.Context) (*models.PurgeResult, error) {
+	result := &models.PurgeResult{DeletedBefore: s.cutoff()}
+
+	if err := s.handOverEvents(ctx, result.DeletedBefore); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if result.Events, err = s.eventRepo.Purge(ctx, result.DeletedBefore); err != nil {
+		return nil, err
+	}
+	if result.Users, err = s.userRepo.Purge(ctx, result.DeletedBefore); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// handOverEvents makes the first co-organizer the organizer of each event
+// whose organizer is about to be purged. Events without a co-organizer are
+// left to be purged with their organizer.
+func (s *RetentionService) handOverEvents(ctx context.Context, deletedBefore time.Time) error {
+	eventIDs, err := s.eventRepo.GetIDsByDeletedOrganizer(ctx, deletedBefore)
+	if err != nil {
+		return err
+	}
+
+	for _, eventID := range eventIDs {
+		event, err := s.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		successor := firstCoOrganizer(event)
+		if successor == "" {
+			continue
+		}
+
+		before := auditEvent(event)
+		err = s.uow.Do(ctx, func(ctx context.Context) error {
+			version, err := changeEvent(ctx, s.uow, s.eventRepo, eventID, func(ctx context.Context) error {
+				return s.eventRepo.UpdateOrganizer(ctx, eventID, successor)
+			})
+			if err != nil {
+				return err
+			}
+
+			event.OrganizerID = successor
+			event.Version = version
+			return recordAudit(ctx, s.auditRepo, auditChange{
+				action:     models.AuditActionEventTransfer,
+				entityType: models.AuditEntityEvent,
+				entityID:   eventID,
+				eventID:    eventID,
+				before:     before,
+				after:      auditEvent(event),
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstCoOrganizer returns the co-organizer added to event first, or "" when
+// it has none.
+func firstCoOrganizer(event *models.Event) string {
+	for _, participant := range event.Participants {
+		if participant.Role == models.ParticipantRoleCoOrganizer {
+			return participant.UserID
+		}
+	}
+	return ""
+}
+
+// cutoff returns the start of the retention period: users and events deleted
+// after it can still be restored, the others are due to be purged.
+func (s *RetentionService) cutoff() time.Time {
+	return s.now().UTC().Add(-s.period)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"meeting-slot-service/internal/auth"
+	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var retentionNow = time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+// retentionCutoff is 30 days before retentionNow.
+var retentionCutoff = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func setupRetentionService() (*RetentionService, *MockUserRepository, *MockEventRepository) {
+	userRepo := new(MockUserRepository)
+	eventRepo := new(MockEventRepository)
+	svc := NewRetentionService(userRepo, eventRepo, auditLog(), new(MockUnitOfWork), 30*24*time.Hour)
+	svc.now = func() time.Time { return retentionNow }
+	return svc, userRepo, eventRepo
+}
+
+func TestRetentionService_RestoreUser(t *testing.T) {
+	svc, userRepo, _ := setupRetentionService()
+	ctx := adminContext()
+
+	userRepo.On("GetByID", ctx, "u1").Return(nil, utils.NotFound("user not found")).Once()
+	userRepo.On("Restore", ctx, "u1", retentionCutoff).Return(nil)
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil).Once()
+
+	user, err := svc.RestoreUser(ctx, "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.ID)
+	userRepo.AssertExpectations(t)
+}
+
+func TestRetentionService_RestoreUser_NotDeleted(t *testing.T) {
+	svc, userRepo, _ := setupRetentionService()
+	ctx := adminContext()
+
+	userRepo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+
+	user, err := svc.RestoreUser(ctx, "u1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.ID)
+	userRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RestoreUser_NotAdmin(t *testing.T) {
+	svc, userRepo, _ := setupRetentionService()
+
+	_, err := svc.RestoreUser(userContext("u2"), "u1")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = svc.RestoreUser(userContext("u1"), "u1")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	userRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RestoreEvent(t *testing.T) {
+	svc, _, eventRepo := setupRetentionService()
+	ctx := userContext("co")
+
+	restored := batchEvent()
+	restored.Version = 5
+	eventRepo.On("GetDeleted", ctx, "e1", retentionCutoff).Return(batchEvent(), nil)
+	eventRepo.On("Restore", ctx, "e1", retentionCutoff).Return(nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(5, nil)
+	eventRepo.On("GetByID", ctx, "e1").Return(restored, nil)
+
+	event, err := svc.RestoreEvent(ctx, "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, event.Version)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestRetentionService_RestoreEvent_AttendeeForbidden(t *testing.T) {
+	svc, _, eventRepo := setupRetentionService()
+	ctx := userContext("p1")
+
+	eventRepo.On("GetDeleted", ctx, "e1", retentionCutoff).Return(batchEvent(), nil)
+
+	_, err := svc.RestoreEvent(ctx, "e1")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	eventRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RestoreEvent_NotDeleted(t *testing.T) {
+	svc, _, eventRepo := setupRetentionService()
+	ctx := userContext("org")
+
+	eventRepo.On("GetDeleted", ctx, "e1", retentionCutoff).Return(nil, utils.NotFound("deleted event not found"))
+	eventRepo.On("GetByID", ctx, "e1").Return(batchEvent(), nil)
+
+	event, err := svc.RestoreEvent(ctx, "e1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, event.Version)
+	eventRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RestoreEvent_PastRetention(t *testing.T) {
+	svc, _, eventRepo := setupRetentionService()
+	ctx := userContext("org")
+
+	eventRepo.On("GetDeleted", ctx, "e1", retentionCutoff).Return(nil, utils.NotFound("deleted event not found"))
+	eventRepo.On("GetByID", ctx, "e1").Return(nil, utils.NotFound("event not found"))
+
+	_, err := svc.RestoreEvent(ctx, "e1")
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.Contains(t, err.Error(), "deleted event not found")
+}
+
+func TestRetentionService_Purge(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		svc, userRepo, eventRepo := setupRetentionService()
+		ctx := adminContext()
+		eventRepo.On("GetIDsByDeletedOrganizer", ctx, retentionCutoff).Return([]string(nil), nil)
+		eventRepo.On("Purge", ctx, retentionCutoff).Return(int64(2), nil)
+		userRepo.On("Purge", ctx, retentionCutoff).Return(int64(1), nil)
+
+		result, err := svc.Purge(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.PurgeResult{DeletedBefore: retentionCutoff, Users: 1, Events: 2}, *result)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		svc, userRepo, eventRepo := setupRetentionService()
+
+		_, err := svc.Purge(userContext("org"))
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		eventRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRetentionService_Purge_HandsOverEvents(t *testing.T) {
+	var entries []*models.AuditEntry
+	userRepo := new(MockUserRepository)
+	eventRepo := new(MockEventRepository)
+	svc := NewRetentionService(userRepo, eventRepo, recordedAudit(&entries), new(MockUnitOfWork), 30*24*time.Hour)
+	svc.now = func() time.Time { return retentionNow }
+	ctx := adminContext()
+
+	// e1 has a co-organizer to take it over; e2 has none and goes with its
+	// organizer
+	eventRepo.On("GetIDsByDeletedOrganizer", ctx, retentionCutoff).Return([]string{"e1", "e2"}, nil)
+	eventRepo.On("GetByID", ctx, "e1").Return(&models.Event{ID: "e1", OrganizerID: "gone", Participants: []models.EventParticipant{
+		{UserID: "p1", Role: models.ParticipantRoleAttendee},
+		{UserID: "co", Role: models.ParticipantRoleCoOrganizer},
+		{UserID: "co2", Role: models.ParticipantRoleCoOrganizer},
+	}}, nil)
+	eventRepo.On("GetByID", ctx, "e2").Return(&models.Event{ID: "e2", OrganizerID: "gone", Participants: []models.EventParticipant{
+		{UserID: "p1", Role: models.ParticipantRoleAttendee},
+	}}, nil)
+	eventRepo.On("Touch", ctx, "e1", 0).Return(4, nil)
+	eventRepo.On("UpdateOrganizer", ctx, "e1", "co").Return(nil)
+	eventRepo.On("Purge", ctx, retentionCutoff).Return(int64(1), nil)
+	userRepo.On("Purge", ctx, retentionCutoff).Return(int64(1), nil)
+
+	result, err := svc.Purge(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.PurgeResult{DeletedBefore: retentionCutoff, Users: 1, Events: 1}, *result)
+	eventRepo.AssertExpectations(t)
+	eventRepo.AssertNotCalled(t, "UpdateOrganizer", mock.Anything, "e2", mock.Anything)
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AuditActionEventTransfer, entries[0].Action)
+	assert.Equal(t, "e1", entries[0].EntityID)
+}
+
+func TestRetentionService_RunPurgeJob(t *testing.T) {
+	svc, userRepo, eventRepo := setupRetentionService()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	eventRepo.On("GetIDsByDeletedOrganizer", ctx, retentionCutoff).Return([]string(nil), nil)
+	eventRepo.On("Purge", ctx, retentionCutoff).Return(int64(0), nil)
+	userRepo.On("Purge", ctx, retentionCutoff).Return(int64(0), nil)
+
+	svc.RunPurgeJob(ctx, time.Hour)
+
+	eventRepo.AssertNumberOfCalls(t, "Purge", 1)
+	userRepo.AssertNumberOfCalls(t, "Purge", 1)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"time"
+
+	"meeting-slot-service/internal/utils"
+)
+
+// validateSlot checks that a slot ends after it starts and that its timezone
+// is a known IANA name. index identifies the slot in error messages.
+func validateSlot(index int, start, end time.Time, timezone string) error {
+	if !end.After(start) {
+		return utils.Invalid("invalid time slot %d: end time must be after start time", index)
+	}
+	if _, err := utils.LoadTimezone(timezone); err != nil {
+		return utils.Invalid("invalid time slot %d: %w", index, err)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"meeting-slot-service/internal/models"
 	"meeting-slot-service/internal/repository"
 	"meeting-slot-service/internal/utils"
@@ -10,13 +9,17 @@ import (
 
 // UserService handles user business logic
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	auditRepo repository.AuditRepository
+	uow       repository.UnitOfWork
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repository.UserRepository) *UserService {
+func NewUserService(userRepo repository.UserRepository, auditRepo repository.AuditRepository, uow repository.UnitOfWork) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		uow:       uow,
 	}
 }
 
@@ -29,17 +32,29 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 
 	// Validate email
 	if user.Email == "" {
-		return fmt.Errorf("email is required")
+		return utils.InvalidField("email", "email is required")
+	}
+
+	// Default the timezone to UTC, otherwise require a valid IANA name
+	if user.Timezone == "" {
+		user.Timezone = "UTC"
+	} else if _, err := utils.LoadTimezone(user.Timezone); err != nil {
+		return utils.InvalidField("timezone", "%w", err)
 	}
 
 	// Check if email already exists
 	existingUser, err := s.userRepo.GetByEmail(ctx, user.Email)
 	if err == nil && existingUser != nil {
-		return fmt.Errorf("email already exists")
+		return utils.Conflict("email already exists")
 	}
 
 	// Create user
-	return s.userRepo.Create(ctx, user)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.recordUser(ctx, models.AuditActionUserCreate, user.ID, nil, user)
+	})
 }
 
 // GetUser retrieves a user by ID
@@ -52,20 +67,106 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*models
 	return s.userRepo.GetByEmail(ctx, email)
 }
 
-// UpdateUser updates an existing user
+// UpdateUser updates an existing user. Only the user and admins may change
+// a user.
 func (s *UserService) UpdateUser(ctx context.Context, user *models.User) error {
+	if err := authorizeSelf(ctx, user.ID); err != nil {
+		return err
+	}
+
 	// Check if user exists
-	_, err := s.userRepo.GetByID(ctx, user.ID)
+	existing, err := s.userRepo.GetByID(ctx, user.ID)
 	if err != nil {
 		return err
 	}
 
-	return s.userRepo.Update(ctx, user)
+	// Keep the stored timezone unless a new, valid one is supplied
+	if user.Timezone == "" {
+		user.Timezone = existing.Timezone
+	} else if _, err := utils.LoadTimezone(user.Timezone); err != nil {
+		return utils.InvalidField("timezone", "%w", err)
+	}
+
+	return s.saveUser(ctx, existing, user)
 }
 
-// DeleteUser deletes a user
+// userReadOnlyFields are the user members a patch may not set.
+var userReadOnlyFields = []string{"id", "is_guest", "created_at", "updated_at"}
+
+// PatchUser applies a JSON merge patch (RFC 7396) to a user, so only the
+// fields sent change. Removing the timezone resets it to UTC. Only the user
+// and admins may patch a user.
+func (s *UserService) PatchUser(ctx context.Context, userID string, patch []byte) (*models.User, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := patchDocument(patch, userReadOnlyFields...); err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := utils.ApplyMergePatch(existing, patch, &user); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateStruct(&user); err != nil {
+		return nil, err
+	}
+	if user.Timezone == "" {
+		user.Timezone = "UTC"
+	}
+
+	if err := s.saveUser(ctx, existing, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// saveUser stores the new state of an existing user.
+func (s *UserService) saveUser(ctx context.Context, existing, user *models.User) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.recordUser(ctx, models.AuditActionUserUpdate, user.ID, existing, user)
+	})
+}
+
+// DeleteUser soft-deletes a user. RetentionService restores and purges
+// deleted users. Only the user and admins may delete a user.
 func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
-	return s.userRepo.Delete(ctx, userID)
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	existing, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
+		return s.recordUser(ctx, models.AuditActionUserDelete, userID, existing, nil)
+	})
+}
+
+// recordUser records a change to a user in the audit log. before or after
+// is nil when the user was created or deleted.
+func (s *UserService) recordUser(ctx context.Context, action, userID string, before, after *models.User) error {
+	return recordAudit(ctx, s.auditRepo, auditChange{
+		action:     action,
+		entityType: models.AuditEntityUser,
+		entityID:   userID,
+		before:     before,
+		after:      after,
+	})
 }
 
 // ListUsers retrieves users with pagination
@@ -5,31 +5,44 @@ import (
 	"errors"
 	"testing"
 
+	"meeting-slot-service/internal/auth"
 	"meeting-slot-service/internal/models"
+	"meeting-slot-service/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserService_CreateUser_Success(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	user := &models.User{Name: "Alice", Email: "alice@example.com"}
 
-	repo.On("GetByEmail", ctx, "alice@example.com").Return(nil, errors.New("not found"))
+	repo.On("GetByEmail", ctx, "alice@example.com").Return(nil, utils.NotFound("user not found"))
 	repo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return(nil)
 
 	err := svc.CreateUser(ctx, user)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, user.ID) // ID should be generated
+	assert.Equal(t, "UTC", user.Timezone)
 	repo.AssertExpectations(t)
 }
 
+func TestUserService_CreateUser_InvalidTimezone(t *testing.T) {
+	svc := NewUserService(new(MockUserRepository), auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	err := svc.CreateUser(ctx, &models.User{Name: "Bob", Email: "bob@example.com", Timezone: "Moon/Base"})
+
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
 func TestUserService_CreateUser_MissingEmail(t *testing.T) {
-	svc := NewUserService(new(MockUserRepository))
+	svc := NewUserService(new(MockUserRepository), auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	err := svc.CreateUser(ctx, &models.User{Name: "Bob"})
@@ -39,7 +52,7 @@ func TestUserService_CreateUser_MissingEmail(t *testing.T) {
 
 func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	existing := &models.User{ID: "u1", Email: "dup@example.com"}
@@ -53,10 +66,10 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 
 func TestUserService_CreateUser_RepoError(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	repo.On("GetByEmail", ctx, "err@example.com").Return(nil, errors.New("not found"))
+	repo.On("GetByEmail", ctx, "err@example.com").Return(nil, utils.NotFound("user not found"))
 	repo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return(errors.New("db error"))
 
 	err := svc.CreateUser(ctx, &models.User{Name: "Dan", Email: "err@example.com"})
@@ -67,7 +80,7 @@ func TestUserService_CreateUser_RepoError(t *testing.T) {
 
 func TestUserService_GetUser_Success(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	expected := &models.User{ID: "u1", Name: "Alice", Email: "alice@example.com"}
@@ -82,10 +95,10 @@ func TestUserService_GetUser_Success(t *testing.T) {
 
 func TestUserService_GetUser_NotFound(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	repo.On("GetByID", ctx, "missing").Return(nil, errors.New("not found"))
+	repo.On("GetByID", ctx, "missing").Return(nil, utils.NotFound("user not found"))
 
 	user, err := svc.GetUser(ctx, "missing")
 
@@ -96,7 +109,7 @@ func TestUserService_GetUser_NotFound(t *testing.T) {
 
 func TestUserService_UpdateUser_Success(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	user := &models.User{ID: "u1", Name: "Updated", Email: "alice@example.com"}
@@ -109,12 +122,29 @@ func TestUserService_UpdateUser_Success(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestUserService_UpdateUser_KeepsStoredTimezone(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	existing := &models.User{ID: "u1", Name: "Alice", Email: "alice@example.com", Timezone: "Asia/Kolkata"}
+	user := &models.User{ID: "u1", Name: "Updated", Email: "alice@example.com"}
+	repo.On("GetByID", ctx, "u1").Return(existing, nil)
+	repo.On("Update", ctx, user).Return(nil)
+
+	err := svc.UpdateUser(ctx, user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Kolkata", user.Timezone)
+	repo.AssertExpectations(t)
+}
+
 func TestUserService_UpdateUser_NotFound(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
-	repo.On("GetByID", ctx, "ghost").Return(nil, errors.New("not found"))
+	repo.On("GetByID", ctx, "ghost").Return(nil, utils.NotFound("user not found"))
 
 	err := svc.UpdateUser(ctx, &models.User{ID: "ghost"})
 
@@ -122,24 +152,110 @@ func TestUserService_UpdateUser_NotFound(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestUserService_UpdateUser_OtherUserForbidden(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+
+	err := svc.UpdateUser(userContext("u2"), &models.User{ID: "u1", Email: "mallory@example.com"})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_PatchUser_OnlySentFieldsChange(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	existing := &models.User{ID: "u1", Name: "Alice", Email: "alice@example.com", Timezone: "Europe/Berlin"}
+	repo.On("GetByID", ctx, "u1").Return(existing, nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*models.User")).Return(nil)
+
+	user, err := svc.PatchUser(ctx, "u1", []byte(`{"name":"Alice Smith"}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice Smith", user.Name)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, "Europe/Berlin", user.Timezone)
+	repo.AssertExpectations(t)
+}
+
+func TestUserService_PatchUser_RemovedTimezoneResetsToUTC(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+	ctx := context.Background()
+
+	repo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1", Name: "Alice", Email: "alice@example.com", Timezone: "Asia/Tokyo"}, nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*models.User")).Return(nil)
+
+	user, err := svc.PatchUser(ctx, "u1", []byte(`{"timezone":null}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC", user.Timezone)
+}
+
+func TestUserService_PatchUser_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"removing a required field", `{"email":null}`},
+		{"invalid value", `{"timezone":"Mars/Olympus_Mons"}`},
+		{"read-only field", `{"is_guest":true}`},
+		{"wrong type", `{"name":42}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUserRepository)
+			svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+			ctx := context.Background()
+
+			repo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1", Name: "Alice", Email: "alice@example.com"}, nil)
+
+			_, err := svc.PatchUser(ctx, "u1", []byte(tt.patch))
+
+			assert.ErrorIs(t, err, utils.ErrValidation)
+			repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserService_PatchUser_OtherUserForbidden(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+
+	_, err := svc.PatchUser(userContext("u2"), "u1", []byte(`{"timezone":"Asia/Tokyo"}`))
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
 func TestUserService_DeleteUser_Success(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	var entries []*models.AuditEntry
+	svc := NewUserService(repo, recordedAudit(&entries), new(MockUnitOfWork))
 	ctx := context.Background()
 
+	repo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1", Email: "u1@example.com"}, nil)
 	repo.On("Delete", ctx, "u1").Return(nil)
 
 	err := svc.DeleteUser(ctx, "u1")
 
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AuditActionUserDelete, entries[0].Action)
+	assert.Contains(t, string(entries[0].Before), "u1@example.com")
+	assert.Nil(t, entries[0].After)
 }
 
 func TestUserService_DeleteUser_RepoError(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
+	repo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
 	repo.On("Delete", ctx, "u1").Return(errors.New("db error"))
 
 	err := svc.DeleteUser(ctx, "u1")
@@ -148,9 +264,33 @@ func TestUserService_DeleteUser_RepoError(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestUserService_DeleteUser_OtherUserForbidden(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+
+	err := svc.DeleteUser(userContext("u2"), "u1")
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUserService_DeleteUser_Self(t *testing.T) {
+	repo := new(MockUserRepository)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
+	ctx := userContext("u1")
+
+	repo.On("GetByID", ctx, "u1").Return(&models.User{ID: "u1"}, nil)
+	repo.On("Delete", ctx, "u1").Return(nil)
+
+	err := svc.DeleteUser(ctx, "u1")
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
 func TestUserService_ListUsers_DefaultPagination(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	users := []*models.User{{ID: "u1"}, {ID: "u2"}}
@@ -166,7 +306,7 @@ func TestUserService_ListUsers_DefaultPagination(t *testing.T) {
 
 func TestUserService_ListUsers_LimitCappedAt100(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	repo.On("List", ctx, 100, 0).Return([]*models.User{}, nil)
@@ -179,7 +319,7 @@ func TestUserService_ListUsers_LimitCappedAt100(t *testing.T) {
 
 func TestUserService_ListUsers_OffsetCalculated(t *testing.T) {
 	repo := new(MockUserRepository)
-	svc := NewUserService(repo)
+	svc := NewUserService(repo, auditLog(), new(MockUnitOfWork))
 	ctx := context.Background()
 
 	// page=3, limit=10 → offset=20
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error kinds. Every error a client should see is classified as one of these,
+// checked with errors.Is. Anything unclassified is an internal error.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPreconditionFailed means the resource changed since the version
+	// the client named in If-Match.
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// Error codes returned in ErrorInfo.Code. This is the complete catalog; see
+// the README for when each one is used.
+const (
+	// CodeBadRequest: the request could not be read, e.g. malformed JSON.
+	CodeBadRequest = "BAD_REQUEST"
+	// CodeValidationError: a value in the request was rejected (422).
+	// Details name the offending fields when known.
+	CodeValidationError = "VALIDATION_ERROR"
+	// CodeUnauthorized: credentials or a guest link are missing or invalid.
+	CodeUnauthorized = "UNAUTHORIZED"
+	// CodeForbidden: the caller may not perform the action.
+	CodeForbidden = "FORBIDDEN"
+	// CodeNotFound: a referenced resource does not exist.
+	CodeNotFound = "NOT_FOUND"
+	// CodeConflict: the request clashes with the current state, e.g. a
+	// duplicate email or a reused idempotency key.
+	CodeConflict = "CONFLICT"
+	// CodePreconditionFailed: If-Match names a version the resource is no
+	// longer at.
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	// CodeInternalError: an unexpected failure, such as a database outage.
+	CodeInternalError = "INTERNAL_ERROR"
+)
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error is a classified error. Its message is safe to show to clients.
+type Error struct {
+	// Kind is one of the Err* sentinels above.
+	Kind    error
+	Message string
+	// Fields lists per-field details for validation errors.
+	Fields []FieldError
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Is reports whether target is the error's kind.
+func (e *Error) Is(target error) bool { return target == e.Kind }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// newError formats a message like fmt.Errorf, keeping any %w operand as the
+// cause.
+func newError(kind error, format string, args ...interface{}) *Error {
+	err := fmt.Errorf(format, args...)
+	return &Error{Kind: kind, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// NotFound returns an ErrNotFound error with the given message.
+func NotFound(format string, args ...interface{}) error {
+	return newError(ErrNotFound, format, args...)
+}
+
+// Conflict returns an ErrConflict error with the given message.
+func Conflict(format string, args ...interface{}) error {
+	return newError(ErrConflict, format, args...)
+}
+
+// Invalid returns an ErrValidation error with the given message.
+func Invalid(format string, args ...interface{}) error {
+	return newError(ErrValidation, format, args...)
+}
+
+// InvalidField returns an ErrValidation error naming the rejected field.
+func InvalidField(field, format string, args ...interface{}) error {
+	e := newError(ErrValidation, format, args...)
+	e.Fields = []FieldError{{Field: field, Message: e.Message}}
+	return e
+}
+
+// Forbidden returns an ErrForbidden error with the given message.
+func Forbidden(format string, args ...interface{}) error {
+	return newError(ErrForbidden, format, args...)
+}
+
+// PreconditionFailed returns an ErrPreconditionFailed error with the given
+// message.
+func PreconditionFailed(format string, args ...interface{}) error {
+	return newError(ErrPreconditionFailed, format, args...)
+}
+
+// ErrorStatus maps an error to its HTTP status and response body. This is
+// the only place error kinds are translated to HTTP. Unclassified errors map
+// to 500 with a generic message so internal details are not leaked.
+func ErrorStatus(err error) (int, ErrorInfo) {
+	var status int
+	var code string
+	switch {
+	case errors.Is(err, ErrValidation):
+		status, code = http.StatusUnprocessableEntity, CodeValidationError
+	case errors.Is(err, ErrUnauthorized):
+		status, code = http.StatusUnauthorized, CodeUnauthorized
+	case errors.Is(err, ErrForbidden):
+		status, code = http.StatusForbidden, CodeForbidden
+	case errors.Is(err, ErrNotFound):
+		status, code = http.StatusNotFound, CodeNotFound
+	case errors.Is(err, ErrConflict):
+		status, code = http.StatusConflict, CodeConflict
+	case errors.Is(err, ErrPreconditionFailed):
+		status, code = http.StatusPreconditionFailed, CodePreconditionFailed
+	default:
+		return http.StatusInternalServerError, ErrorInfo{Code: CodeInternalError, Message: "An internal error occurred"}
+	}
+
+	info := ErrorInfo{Code: code, Message: err.Error()}
+	var e *Error
+	if errors.As(err, &e) {
+		info.Details = e.Fields
+	}
+	return status, info
+}
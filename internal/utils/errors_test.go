@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{"not found", NotFound("event not found"), http.StatusNotFound, CodeNotFound, "event not found"},
+		{"conflict", Conflict("email already exists"), http.StatusConflict, CodeConflict, "email already exists"},
+		{"validation", Invalid("invalid mode %q", "upsert"), http.StatusUnprocessableEntity, CodeValidationError, `invalid mode "upsert"`},
+		{"precondition failed", PreconditionFailed("event has changed"), http.StatusPreconditionFailed, CodePreconditionFailed, "event has changed"},
+		{"forbidden", Forbidden("not a member of this event"), http.StatusForbidden, CodeForbidden, "not a member of this event"},
+		{"wrapped forbidden sentinel", fmt.Errorf("%w: admin access required", ErrForbidden), http.StatusForbidden, CodeForbidden, "forbidden: admin access required"},
+		{"guest token", ErrExpiredGuestToken, http.StatusUnauthorized, CodeUnauthorized, "guest token has expired"},
+		{"wrapped timezone", fmt.Errorf("%w: %q", ErrInvalidTimezone, "Mars/Olympus"), http.StatusUnprocessableEntity, CodeValidationError, `invalid timezone: "Mars/Olympus"`},
+		{"unclassified", errors.New("dial tcp: connection refused"), http.StatusInternalServerError, CodeInternalError, "An internal error occurred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, info := ErrorStatus(tt.err)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.code, info.Code)
+			assert.Equal(t, tt.message, info.Message)
+		})
+	}
+}
+
+func TestErrorStatus_FieldDetails(t *testing.T) {
+	_, info := ErrorStatus(InvalidField("email", "email is required"))
+
+	assert.Equal(t, []FieldError{{Field: "email", Message: "email is required"}}, info.Details)
+}
+
+func TestError_KeepsCause(t *testing.T) {
+	err := Invalid("invalid time slot %d: %w", 2, ErrInvalidTimezone)
+
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "invalid time slot 2: invalid timezone")
+}
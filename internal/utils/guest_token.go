@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidGuestToken is returned for a malformed token or a bad signature.
+	ErrInvalidGuestToken error = &Error{Kind: ErrUnauthorized, Message: "invalid guest token"}
+	// ErrExpiredGuestToken is returned for a correctly signed token past its
+	// expiry.
+	ErrExpiredGuestToken error = &Error{Kind: ErrUnauthorized, Message: "guest token has expired"}
+)
+
+// GuestToken is the claim carried by a guest's respond link. It grants access
+// to a single participant of a single event until ExpiresAt.
+type GuestToken struct {
+	EventID   string
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// guestTokenPayload is the signed, serialized form of a GuestToken.
+type guestTokenPayload struct {
+	EventID   string `json:"e"`
+	UserID    string `json:"u"`
+	ExpiresAt int64  `json:"x"`
+}
+
+// GuestTokenSigner issues and verifies HMAC-SHA256 signed guest tokens.
+type GuestTokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewGuestTokenSigner creates a signer whose tokens are valid for ttl.
+func NewGuestTokenSigner(secret []byte, ttl time.Duration) *GuestTokenSigner {
+	return &GuestTokenSigner{secret: secret, ttl: ttl}
+}
+
+// Sign issues a token for the given participant, valid from now until now
+// plus the signer's TTL.
+func (s *GuestTokenSigner) Sign(eventID, userID string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).UTC().Truncate(time.Second)
+	payload, _ := json.Marshal(guestTokenPayload{
+		EventID:   eventID,
+		UserID:    userID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(encoded), expiresAt
+}
+
+// Parse verifies a token's signature and expiry and returns its claim.
+func (s *GuestTokenSigner) Parse(token string, now time.Time) (*GuestToken, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.signature(encoded))) {
+		return nil, ErrInvalidGuestToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidGuestToken
+	}
+	var payload guestTokenPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.EventID == "" || payload.UserID == "" {
+		return nil, ErrInvalidGuestToken
+	}
+
+	expiresAt := time.Unix(payload.ExpiresAt, 0).UTC()
+	if !now.Before(expiresAt) {
+		return nil, ErrExpiredGuestToken
+	}
+
+	return &GuestToken{
+		EventID:   payload.EventID,
+		UserID:    payload.UserID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// signature returns the base64url HMAC of the encoded payload.
+func (s *GuestTokenSigner) signature(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestTokenSigner_RoundTrip(t *testing.T) {
+	signer := NewGuestTokenSigner([]byte("secret"), time.Hour)
+	now := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, expiresAt := signer.Sign("evt_1", "usr_1", now)
+	assert.Equal(t, now.Add(time.Hour), expiresAt)
+
+	claim, err := signer.Parse(token, now.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", claim.EventID)
+	assert.Equal(t, "usr_1", claim.UserID)
+	assert.Equal(t, expiresAt, claim.ExpiresAt)
+}
+
+func TestGuestTokenSigner_Expired(t *testing.T) {
+	signer := NewGuestTokenSigner([]byte("secret"), time.Hour)
+	now := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, _ := signer.Sign("evt_1", "usr_1", now)
+
+	_, err := signer.Parse(token, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrExpiredGuestToken)
+}
+
+func TestGuestTokenSigner_Tampered(t *testing.T) {
+	signer := NewGuestTokenSigner([]byte("secret"), time.Hour)
+	now := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, _ := signer.Sign("evt_1", "usr_1", now)
+	other, _ := signer.Sign("evt_2", "usr_1", now)
+	payload, _, _ := strings.Cut(other, ".")
+	_, sig, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"swapped payload", payload + "." + sig},
+		{"missing signature", payload},
+		{"garbage", "not-a-token"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Parse(tt.token, now)
+			assert.ErrorIs(t, err, ErrInvalidGuestToken)
+		})
+	}
+}
+
+func TestGuestTokenSigner_WrongSecret(t *testing.T) {
+	now := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+	token, _ := NewGuestTokenSigner([]byte("secret"), time.Hour).Sign("evt_1", "usr_1", now)
+
+	_, err := NewGuestTokenSigner([]byte("other"), time.Hour).Parse(token, now)
+	assert.ErrorIs(t, err, ErrInvalidGuestToken)
+}
@@ -10,6 +10,8 @@ import (
 const (
 	EventIDPrefix = "evt_"
 	UserIDPrefix  = "usr_"
+	APIKeyPrefix  = "key_"
+	GroupIDPrefix = "grp_"
 )
 
 // GenerateEventID generates a unique event ID with 'evt_' prefix
@@ -27,6 +29,20 @@ func GenerateUserID() string {
 	return fmt.Sprintf("%s%s", UserIDPrefix, shortID)
 }
 
+// GenerateAPIKeyID generates a unique API key ID with 'key_' prefix
+func GenerateAPIKeyID() string {
+	id := generateUUID()
+	shortID := strings.ReplaceAll(id[:13], "-", "")
+	return fmt.Sprintf("%s%s", APIKeyPrefix, shortID)
+}
+
+// GenerateGroupID generates a unique group ID with 'grp_' prefix
+func GenerateGroupID() string {
+	id := generateUUID()
+	shortID := strings.ReplaceAll(id[:13], "-", "")
+	return fmt.Sprintf("%s%s", GroupIDPrefix, shortID)
+}
+
 // generateUUID generates a standard UUID
 func generateUUID() string {
 	return uuid.New().String()
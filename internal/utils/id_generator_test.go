@@ -22,6 +22,22 @@ func TestGenerateUserID(t *testing.T) {
 	assert.Greater(t, len(id), 10)
 }
 
+func TestGenerateAPIKeyID(t *testing.T) {
+	id := GenerateAPIKeyID()
+
+	// Should start with key_
+	assert.Contains(t, id, APIKeyPrefix)
+	assert.Greater(t, len(id), 10)
+}
+
+func TestGenerateGroupID(t *testing.T) {
+	id := GenerateGroupID()
+
+	// Should start with grp_
+	assert.Contains(t, id, GroupIDPrefix)
+	assert.Greater(t, len(id), 10)
+}
+
 func TestGenerateUUID(t *testing.T) {
 	id := generateUUID()
 
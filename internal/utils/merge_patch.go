@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MergePatch applies a JSON merge patch (RFC 7396) to the JSON document
+// target. Members of patch replace those of target, objects are merged
+// recursively, null removes a member, and any other value, including an
+// array, replaces the target value entirely.
+func MergePatch(target, patch []byte) ([]byte, error) {
+	var t, p interface{}
+	if len(bytes.TrimSpace(target)) > 0 {
+		if err := decodeNumbers(target, &t); err != nil {
+			return nil, fmt.Errorf("invalid merge patch target: %w", err)
+		}
+	}
+	if err := decodeNumbers(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return json.Marshal(mergePatch(t, p))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = mergePatch(t[name], value)
+	}
+	return t
+}
+
+// decodeNumbers unmarshals data keeping numbers exact, so IDs and other
+// integers survive the round trip.
+func decodeNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// ApplyMergePatch applies patch to the JSON form of current and decodes the
+// result into a zero-valued result, so members the patch removes end up
+// empty. A patched value of the wrong type is an ErrValidation error.
+func ApplyMergePatch(current interface{}, patch []byte, result interface{}) error {
+	target, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	merged, err := MergePatch(target, patch)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(merged, result)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil, errors.Is(err, ErrValidation):
+		return err
+	case errors.As(err, &typeErr):
+		return InvalidField(typeErr.Field, "%s must be of type %s", typeErr.Field, typeErr.Type)
+	default:
+		return Invalid("invalid patch: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Cases from RFC 7396, appendix A
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"remove one of two", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"array replaced", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"value replaced by array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"nested merge", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"array of objects replaced", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"non-object patch replaces", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null inside new object dropped", `{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{"object replaces array", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"large integers kept exact", `{"id":9007199254740993}`, `{"n":1}`, `{"id":9007199254740993,"n":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.target), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	type doc struct {
+		Title    string `json:"title"`
+		Duration int    `json:"duration_minutes"`
+		Notes    string `json:"notes"`
+	}
+	current := doc{Title: "Planning", Duration: 30, Notes: "bring snacks"}
+
+	t.Run("only sent fields change", func(t *testing.T) {
+		var got doc
+		err := ApplyMergePatch(current, []byte(`{"duration_minutes":45,"notes":null}`), &got)
+
+		require.NoError(t, err)
+		assert.Equal(t, doc{Title: "Planning", Duration: 45}, got)
+	})
+
+	t.Run("wrong type is a validation error", func(t *testing.T) {
+		var got doc
+		err := ApplyMergePatch(current, []byte(`{"duration_minutes":"long"}`), &got)
+
+		assert.ErrorIs(t, err, ErrValidation)
+		_, info := ErrorStatus(err)
+		if assert.Len(t, info.Details, 1) {
+			assert.Equal(t, "duration_minutes", info.Details[0].Field)
+		}
+	})
+}
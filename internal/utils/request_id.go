@@ -0,0 +1,44 @@
+package utils
+
+import "context"
+
+// RequestIDHeader names the header that carries a request's ID
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds a request ID supplied by a client, matching the
+// size of the audit log's request_id column.
+const maxRequestIDLength = 100
+
+// requestIDKey is the context key for the ID of the current request.
+type requestIDKey struct{}
+
+// GenerateRequestID generates a unique request ID
+func GenerateRequestID() string {
+	return generateUUID()
+}
+
+// ValidRequestID reports whether a client-supplied request ID can be used
+// as is: it is not empty, not too long, and only printable ASCII.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a context carrying the ID of the current request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set with WithRequestID, or ""
+// outside a request.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRequestID(t *testing.T) {
+	id := GenerateRequestID()
+
+	assert.True(t, ValidRequestID(id))
+	assert.NotEqual(t, id, GenerateRequestID())
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID("req-42"))
+	assert.False(t, ValidRequestID(""))
+	assert.False(t, ValidRequestID("has space"))
+	assert.False(t, ValidRequestID("line\nbreak"))
+	assert.False(t, ValidRequestID(strings.Repeat("a", maxRequestIDLength+1)))
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	assert.Equal(t, "req-42", RequestIDFromContext(WithRequestID(context.Background(), "req-42")))
+}
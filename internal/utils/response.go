@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 )
 
@@ -14,15 +15,18 @@ type Response struct {
 
 // ErrorInfo contains error details
 type ErrorInfo struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
 }
 
-// PaginationInfo contains pagination metadata
+// PaginationInfo contains pagination metadata. Page and Total are only set
+// for page-based listings; NextCursor is empty on the last page.
 type PaginationInfo struct {
-	Page  int `json:"page"`
-	Limit int `json:"limit"`
-	Total int `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response
@@ -48,6 +52,16 @@ func WriteSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
 	WriteJSON(w, statusCode, response)
 }
 
+// WriteShaped writes a success response with data reduced to shape
+func WriteShaped(w http.ResponseWriter, statusCode int, data interface{}, shape Shape) {
+	shaped, err := shape.Apply(data)
+	if err != nil {
+		WriteErrorFrom(w, err)
+		return
+	}
+	WriteSuccess(w, statusCode, shaped)
+}
+
 // WriteError writes an error response
 func WriteError(w http.ResponseWriter, statusCode int, code, message string) {
 	response := Response{
@@ -60,15 +74,51 @@ func WriteError(w http.ResponseWriter, statusCode int, code, message string) {
 	WriteJSON(w, statusCode, response)
 }
 
-// WritePaginatedResponse writes a paginated response
-func WritePaginatedResponse(w http.ResponseWriter, data interface{}, page, limit, total int) {
+// WriteErrorFrom writes the response ErrorStatus maps err to. Internal errors
+// are logged, since their details are not returned to the client.
+func WriteErrorFrom(w http.ResponseWriter, err error) {
+	status, info := ErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("internal error: %v", err)
+	}
+	WriteJSON(w, status, Response{Success: false, Error: &info})
+}
+
+// WritePaginatedResponse writes a page-based paginated response, with each
+// item reduced to shape
+func WritePaginatedResponse(w http.ResponseWriter, data interface{}, shape Shape, page, limit, total int, nextCursor string) {
+	data, err := shape.Apply(data)
+	if err != nil {
+		WriteErrorFrom(w, err)
+		return
+	}
 	response := PaginatedResponse{
 		Success: true,
 		Data:    data,
 		Pagination: PaginationInfo{
-			Page:  page,
-			Limit: limit,
-			Total: total,
+			Page:       page,
+			Limit:      limit,
+			Total:      &total,
+			NextCursor: nextCursor,
+		},
+	}
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// WriteCursorResponse writes a response for a listing that followed a
+// cursor, which is not counted, with each item reduced to shape
+func WriteCursorResponse(w http.ResponseWriter, data interface{}, shape Shape, limit int, nextCursor string) {
+	data, err := shape.Apply(data)
+	if err != nil {
+		WriteErrorFrom(w, err)
+		return
+	}
+	response := PaginatedResponse{
+		Success: true,
+		Data:    data,
+		Pagination: PaginationInfo{
+			Limit:      limit,
+			NextCursor: nextCursor,
 		},
 	}
 	WriteJSON(w, http.StatusOK, response)
@@ -76,13 +126,21 @@ func WritePaginatedResponse(w http.ResponseWriter, data interface{}, page, limit
 
 // Common error responses
 func WriteBadRequest(w http.ResponseWriter, message string) {
-	WriteError(w, http.StatusBadRequest, "BAD_REQUEST", message)
+	WriteError(w, http.StatusBadRequest, CodeBadRequest, message)
 }
 
 func WriteNotFound(w http.ResponseWriter, message string) {
-	WriteError(w, http.StatusNotFound, "NOT_FOUND", message)
+	WriteError(w, http.StatusNotFound, CodeNotFound, message)
 }
 
 func WriteInternalError(w http.ResponseWriter, message string) {
-	WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	WriteError(w, http.StatusInternalServerError, CodeInternalError, message)
+}
+
+func WriteUnauthorized(w http.ResponseWriter, message string) {
+	WriteError(w, http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+func WriteForbidden(w http.ResponseWriter, message string) {
+	WriteError(w, http.StatusForbidden, CodeForbidden, message)
 }
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Shape selects the parts of a response's data a client asked for with
+// ?fields= and ?expand=. Both take comma-separated member names; dots reach
+// into nested objects and through arrays, as in "participants.status".
+type Shape struct {
+	// Fields lists the members to keep. Empty keeps every member.
+	Fields []string
+	// Expand lists the optional members to embed.
+	Expand []string
+	// expandable lists the optional members the endpoint offers. Those not
+	// in Expand are left out.
+	expandable []string
+}
+
+// ParseShape reads ?fields= and ?expand= from query. expandable lists the
+// optional members the endpoint offers; asking to expand anything else is
+// an ErrValidation error.
+func ParseShape(query url.Values, expandable ...string) (Shape, error) {
+	shape := Shape{
+		Fields:     splitList(query["fields"]),
+		Expand:     splitList(query["expand"]),
+		expandable: expandable,
+	}
+	for _, path := range shape.Expand {
+		if !containsString(expandable, path) {
+			if len(expandable) == 0 {
+				return Shape{}, InvalidField("expand", "cannot expand %q: nothing can be expanded here", path)
+			}
+			return Shape{}, InvalidField("expand", "cannot expand %q: must be one of %s",
+				path, strings.Join(expandable, ", "))
+		}
+	}
+	return shape, nil
+}
+
+// Expands reports whether the client asked to embed the member at path.
+func (s Shape) Expands(path string) bool {
+	return containsString(s.Expand, path)
+}
+
+// Apply reduces data to the shape: optional members that were not expanded
+// are removed and, when Fields is set, only the named members are kept.
+// Names that match no member are ignored.
+func (s Shape) Apply(data interface{}) (interface{}, error) {
+	var collapsed []string
+	for _, path := range s.expandable {
+		if !s.Expands(path) {
+			collapsed = append(collapsed, path)
+		}
+	}
+	if len(collapsed) == 0 && len(s.Fields) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := decodeNumbers(raw, &tree); err != nil {
+		return nil, err
+	}
+
+	for _, path := range collapsed {
+		removeMember(tree, strings.Split(path, "."))
+	}
+	if len(s.Fields) > 0 {
+		fields := fieldTree{}
+		for _, path := range s.Fields {
+			fields.add(strings.Split(path, "."))
+		}
+		tree = fields.keep(tree)
+	}
+	return tree, nil
+}
+
+// fieldTree holds the member paths to keep. A nil subtree keeps the whole
+// member.
+type fieldTree map[string]fieldTree
+
+func (t fieldTree) add(path []string) {
+	sub, seen := t[path[0]]
+	if len(path) == 1 {
+		t[path[0]] = nil
+		return
+	}
+	if seen && sub == nil {
+		// The whole member is already kept
+		return
+	}
+	if sub == nil {
+		sub = fieldTree{}
+		t[path[0]] = sub
+	}
+	sub.add(path[1:])
+}
+
+func (t fieldTree) keep(node interface{}) interface{} {
+	switch v := node.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = t.keep(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		kept := make(map[string]interface{}, len(t))
+		for name, sub := range t {
+			value, ok := v[name]
+			if !ok {
+				continue
+			}
+			if sub != nil {
+				value = sub.keep(value)
+			}
+			kept[name] = value
+		}
+		return kept
+	default:
+		return node
+	}
+}
+
+// removeMember deletes the member at path from node, applying the rest of
+// the path to every element of arrays along the way.
+func removeMember(node interface{}, path []string) {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, item := range v {
+			removeMember(item, path)
+		}
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		removeMember(v[path[0]], path[1:])
+	}
+}
+
+// splitList splits comma-separated query values, dropping empty names.
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				list = append(list, name)
+			}
+		}
+	}
+	return list
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShape_Apply(t *testing.T) {
+	event := `{
+		"id": "e1",
+		"title": "Planning",
+		"status": "open",
+		"availability_summary": {"participants": 2, "responded": 1},
+		"participants": [
+			{"user_id": "u1", "status": "accepted", "user": {"id": "u1", "name": "Ann"}},
+			{"user_id": "u2", "status": "invited", "user": {"id": "u2", "name": "Bob"}}
+		]
+	}`
+	expandable := []string{"participants.user", "availability_summary"}
+
+	tests := []struct {
+		name  string
+		query string
+		data  string
+		want  string
+	}{
+		{
+			name:  "no shape keeps everything but expansions",
+			query: "",
+			data:  event,
+			want: `{"id":"e1","title":"Planning","status":"open","participants":[
+				{"user_id":"u1","status":"accepted"},{"user_id":"u2","status":"invited"}]}`,
+		},
+		{
+			name:  "top-level fields",
+			query: "fields=id,title,status",
+			data:  event,
+			want:  `{"id":"e1","title":"Planning","status":"open"}`,
+		},
+		{
+			name:  "nested fields reach through arrays",
+			query: "fields=id,participants.status",
+			data:  event,
+			want:  `{"id":"e1","participants":[{"status":"accepted"},{"status":"invited"}]}`,
+		},
+		{
+			name:  "expansion",
+			query: "fields=id,participants&expand=participants.user",
+			data:  event,
+			want: `{"id":"e1","participants":[
+				{"user_id":"u1","status":"accepted","user":{"id":"u1","name":"Ann"}},
+				{"user_id":"u2","status":"invited","user":{"id":"u2","name":"Bob"}}]}`,
+		},
+		{
+			name:  "whole member wins over nested path",
+			query: "fields=participants.status,participants&expand=participants.user",
+			data:  event,
+			want: `{"participants":[
+				{"user_id":"u1","status":"accepted","user":{"id":"u1","name":"Ann"}},
+				{"user_id":"u2","status":"invited","user":{"id":"u2","name":"Bob"}}]}`,
+		},
+		{
+			name:  "fields applied to every element of a list",
+			query: "fields=id&expand=availability_summary",
+			data:  `[` + event + `,{"id":"e2","title":"Retro"}]`,
+			want:  `[{"id":"e1"},{"id":"e2"}]`,
+		},
+		{
+			name:  "unknown fields ignored",
+			query: "fields=id,nope",
+			data:  event,
+			want:  `{"id":"e1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			shape, err := ParseShape(query, expandable...)
+			require.NoError(t, err)
+
+			var data interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+
+			got, err := shape.Apply(data)
+			require.NoError(t, err)
+			raw, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(raw))
+		})
+	}
+}
+
+func TestShape_ApplyWithoutShapeReturnsData(t *testing.T) {
+	data := map[string]int{"a": 1}
+
+	got, err := Shape{}.Apply(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestParseShape_UnknownExpansion(t *testing.T) {
+	_, err := ParseShape(url.Values{"expand": {"owner"}}, "participants.user")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrValidation))
+
+	_, err = ParseShape(url.Values{"expand": {"owner"}})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrValidation))
+}
+
+func TestParseShape_SplitsLists(t *testing.T) {
+	shape, err := ParseShape(url.Values{
+		"fields": {"id, title", "status,"},
+		"expand": {"availability_summary"},
+	}, "availability_summary")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "title", "status"}, shape.Fields)
+	assert.True(t, shape.Expands("availability_summary"))
+	assert.False(t, shape.Expands("participants.user"))
+}
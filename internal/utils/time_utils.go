@@ -1,9 +1,25 @@
 package utils
 
 import (
+	"fmt"
+	"sort"
 	"time"
 )
 
+// Timezone and wall-clock parsing errors. They are validation errors, so
+// they are reported as client errors wherever they surface.
+var (
+	ErrInvalidTimezone      error = &Error{Kind: ErrValidation, Message: "invalid timezone"}
+	ErrInvalidLocalTime     error = &Error{Kind: ErrValidation, Message: "invalid local time"}
+	ErrNonexistentLocalTime error = &Error{Kind: ErrValidation, Message: "nonexistent local time"}
+)
+
+// localTimeLayouts are the accepted wall-clock layouts, without any offset.
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
 // TimeSlot represents a time interval
 type TimeSlot struct {
 	Start time.Time
@@ -26,6 +42,49 @@ func (ts TimeSlot) Duration() time.Duration {
 	return ts.End.Sub(ts.Start)
 }
 
+// Intersect returns the part of ts that also lies within other, and false
+// when the two do not overlap.
+func (ts TimeSlot) Intersect(other TimeSlot) (TimeSlot, bool) {
+	if !ts.Overlaps(other) {
+		return TimeSlot{}, false
+	}
+	start, end := ts.Start, ts.End
+	if other.Start.After(start) {
+		start = other.Start
+	}
+	if other.End.Before(end) {
+		end = other.End
+	}
+	return TimeSlot{Start: start, End: end}, true
+}
+
+// MergeTimeSlots sorts slots by start time and merges any that overlap or
+// touch end-to-start. The input slice is not modified.
+func MergeTimeSlots(slots []TimeSlot) []TimeSlot {
+	if len(slots) == 0 {
+		return nil
+	}
+
+	sorted := make([]TimeSlot, len(slots))
+	copy(sorted, slots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []TimeSlot{sorted[0]}
+	for _, slot := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if slot.Start.After(last.End) {
+			merged = append(merged, slot)
+			continue
+		}
+		if slot.End.After(last.End) {
+			last.End = slot.End
+		}
+	}
+	return merged
+}
+
 // NormalizeToUTC converts a time to UTC timezone
 func NormalizeToUTC(t time.Time) time.Time {
 	return t.UTC()
@@ -63,3 +122,82 @@ func GenerateCandidateSlots(window TimeSlot, durationMinutes int, intervalMinute
 
 	return candidates
 }
+
+// LoadTimezone resolves an IANA timezone name such as "Europe/Berlin".
+// Unlike time.LoadLocation it rejects the empty string and "Local", which
+// would otherwise silently resolve to UTC or the server's own zone.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: %q is not an IANA timezone name", ErrInvalidTimezone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not an IANA timezone name", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// ParseLocalTime parses a wall-clock time without offset (for example
+// "2026-03-29T02:30") in the given location. DST transitions are resolved
+// with explicit rules instead of time.Date's unspecified normalisation:
+//
+//   - a nonexistent time (skipped by a spring-forward gap) is rejected with
+//     ErrNonexistentLocalTime;
+//   - an ambiguous time (repeated by a fall-back overlap) resolves to its
+//     earlier occurrence, i.e. the offset in effect before the transition.
+func ParseLocalTime(value string, loc *time.Location) (time.Time, error) {
+	var wall time.Time
+	var err error
+	for _, layout := range localTimeLayouts {
+		if wall, err = time.Parse(layout, value); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidLocalTime, value)
+	}
+
+	// Try every offset the zone uses around that wall-clock time and keep the
+	// instants that map back onto it. A full day either side covers both
+	// ordinary DST shifts and historical date-line changes.
+	var match time.Time
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall, wall.Add(24 * time.Hour)} {
+		_, offset := time.Date(probe.Year(), probe.Month(), probe.Day(),
+			probe.Hour(), probe.Minute(), probe.Second(), 0, loc).Zone()
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(candidate, wall) {
+			continue
+		}
+		if match.IsZero() || candidate.Before(match) {
+			match = candidate
+		}
+	}
+	if match.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %s does not exist in %s (skipped by a DST transition)",
+			ErrNonexistentLocalTime, value, loc)
+	}
+	return match, nil
+}
+
+// ParseSlotTime parses a slot boundary. RFC 3339 values carry their own
+// offset and are used as-is; offset-less wall-clock values are resolved in
+// timezone using the rules of ParseLocalTime.
+func ParseSlotTime(value, timezone string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	loc, err := LoadTimezone(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ParseLocalTime(value, loc)
+}
+
+// sameWallClock reports whether t reads the same calendar date and time of
+// day as wall, ignoring location.
+func sameWallClock(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
+}
@@ -159,3 +159,119 @@ func TestNormalizeToUTC(t *testing.T) {
 	// Verify the time is correct (EST is UTC-5)
 	assert.Equal(t, 19, utcTime.Hour()) // 14 + 5 = 19
 }
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		tz      string
+		wantErr bool
+	}{
+		{name: "IANA name", tz: "America/New_York"},
+		{name: "UTC", tz: "UTC"},
+		{name: "empty", tz: "", wantErr: true},
+		{name: "server local zone", tz: "Local", wantErr: true},
+		{name: "unknown name", tz: "Mars/Olympus_Mons", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadTimezone(tt.tz)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTimezone)
+				assert.Nil(t, loc)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.tz, loc.String())
+		})
+	}
+}
+
+func TestParseLocalTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	t.Run("ordinary time", func(t *testing.T) {
+		got, err := ParseLocalTime("2026-01-15T09:30", berlin)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 1, 15, 8, 30, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("with seconds", func(t *testing.T) {
+		got, err := ParseLocalTime("2026-07-01T09:30:15", berlin)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 7, 1, 7, 30, 15, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("nonexistent time in spring-forward gap", func(t *testing.T) {
+		_, err := ParseLocalTime("2026-03-29T02:30", berlin)
+		assert.ErrorIs(t, err, ErrNonexistentLocalTime)
+	})
+
+	t.Run("ambiguous time resolves to earlier occurrence", func(t *testing.T) {
+		got, err := ParseLocalTime("2026-10-25T02:30", berlin)
+		assert.NoError(t, err)
+		// 02:30 CEST (+02:00), not 02:30 CET (+01:00)
+		assert.Equal(t, time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("malformed value", func(t *testing.T) {
+		_, err := ParseLocalTime("tomorrow at nine", berlin)
+		assert.ErrorIs(t, err, ErrInvalidLocalTime)
+	})
+}
+
+func TestParseSlotTime(t *testing.T) {
+	t.Run("RFC 3339 keeps its own offset", func(t *testing.T) {
+		got, err := ParseSlotTime("2026-03-29T02:30:00+05:00", "Europe/Berlin")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 28, 21, 30, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("wall clock resolved in timezone", func(t *testing.T) {
+		got, err := ParseSlotTime("2026-03-08T09:00", "America/New_York")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("wall clock with invalid timezone", func(t *testing.T) {
+		_, err := ParseSlotTime("2026-03-08T09:00", "Nowhere/City")
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+	})
+}
+
+func TestTimeSlotIntersect(t *testing.T) {
+	day := func(h int) time.Time { return time.Date(2025, 1, 12, h, 0, 0, 0, time.UTC) }
+	window := TimeSlot{Start: day(9), End: day(12)}
+
+	got, ok := TimeSlot{Start: day(8), End: day(10)}.Intersect(window)
+	assert.True(t, ok)
+	assert.Equal(t, TimeSlot{Start: day(9), End: day(10)}, got)
+
+	got, ok = TimeSlot{Start: day(10), End: day(11)}.Intersect(window)
+	assert.True(t, ok)
+	assert.Equal(t, TimeSlot{Start: day(10), End: day(11)}, got)
+
+	_, ok = TimeSlot{Start: day(12), End: day(13)}.Intersect(window)
+	assert.False(t, ok, "touching slots do not intersect")
+}
+
+func TestMergeTimeSlots(t *testing.T) {
+	day := func(h int) time.Time { return time.Date(2025, 1, 12, h, 0, 0, 0, time.UTC) }
+
+	input := []TimeSlot{
+		{Start: day(14), End: day(15)},
+		{Start: day(9), End: day(11)},
+		{Start: day(10), End: day(12)}, // overlaps previous
+		{Start: day(12), End: day(13)}, // adjacent to previous
+	}
+
+	merged := MergeTimeSlots(input)
+
+	assert.Equal(t, []TimeSlot{
+		{Start: day(9), End: day(13)},
+		{Start: day(14), End: day(15)},
+	}, merged)
+	assert.Equal(t, day(14), input[0].Start, "input must not be reordered")
+	assert.Nil(t, MergeTimeSlots(nil))
+}
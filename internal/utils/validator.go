@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate checks the `validate` struct tags on request bodies. Field names in
+// errors are taken from the json tags so they match what clients send.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	// The built-in timezone rule accepts "Local"; only IANA names are
+	// meaningful to other clients.
+	if err := v.RegisterValidation("timezone", func(fl validator.FieldLevel) bool {
+		_, err := LoadTimezone(fl.Field().String())
+		return err == nil
+	}); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ValidateStruct checks s against its `validate` tags. A failure is returned
+// as an ErrValidation error with one FieldError per rejected field.
+func ValidateStruct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	e := &Error{Kind: ErrValidation}
+	messages := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		detail := FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+		e.Fields = append(e.Fields, detail)
+		messages = append(messages, detail.Message)
+	}
+	e.Message = "validation failed: " + strings.Join(messages, "; ")
+	return e
+}
+
+// fieldPath drops the struct type name from a namespace such as
+// "Event.proposed_slots[0].end_time".
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// fieldMessage describes a failed rule in words a client can show next to
+// the field.
+func fieldMessage(fe validator.FieldError) string {
+	field := fe.Field()
+
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "timezone":
+		return field + " must be an IANA timezone name"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(fe.Param()), ", "))
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fe.Param())
+	case "gtfield":
+		return fmt.Sprintf("%s must be after %s", field, snakeCase(fe.Param()))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fe.Param(), lengthUnit(fe.Kind()))
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fe.Param(), lengthUnit(fe.Kind()))
+	default:
+		return field + " is invalid"
+	}
+}
+
+// lengthUnit names what min and max count for a value of kind k.
+func lengthUnit(k reflect.Kind) string {
+	switch k {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+// snakeCase converts a Go field name such as StartTime to its json form.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatorTestSlot struct {
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
+	Timezone  string    `json:"timezone" validate:"required,timezone"`
+}
+
+type validatorTestBody struct {
+	Email string              `json:"email" validate:"required,email"`
+	Mode  string              `json:"mode,omitempty" validate:"omitempty,oneof=merge replace"`
+	Slots []validatorTestSlot `json:"slots" validate:"dive"`
+}
+
+func TestValidateStruct_Valid(t *testing.T) {
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	body := validatorTestBody{
+		Email: "alice@example.com",
+		Slots: []validatorTestSlot{{StartTime: start, EndTime: start.Add(time.Hour), Timezone: "Europe/London"}},
+	}
+
+	assert.NoError(t, ValidateStruct(&body))
+}
+
+func TestValidateStruct_FieldDetails(t *testing.T) {
+	start := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	body := validatorTestBody{
+		Email: "not-an-email",
+		Mode:  "upsert",
+		Slots: []validatorTestSlot{{StartTime: start, EndTime: start, Timezone: "Local"}},
+	}
+
+	err := ValidateStruct(&body)
+
+	require.ErrorIs(t, err, ErrValidation)
+	status, info := ErrorStatus(err)
+	assert.Equal(t, 422, status)
+	assert.Equal(t, []FieldError{
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "mode", Rule: "oneof", Message: "mode must be one of: merge, replace"},
+		{Field: "slots[0].end_time", Rule: "gtfield", Message: "end_time must be after start_time"},
+		{Field: "slots[0].timezone", Rule: "timezone", Message: "timezone must be an IANA timezone name"},
+	}, info.Details)
+}
+
+func TestValidateStruct_Required(t *testing.T) {
+	err := ValidateStruct(&validatorTestBody{})
+
+	assert.EqualError(t, err, "validation failed: email is required")
+}
@@ -49,6 +49,11 @@ resource "aws_launch_template" "app" {
     app_port      = var.app_port
     environment   = var.environment
     name_prefix   = local.name_prefix
+    auth_required = var.auth_required
+    auth_jwks     = var.auth_jwks
+    jwt_issuer    = var.auth_jwt_issuer
+    jwt_audience  = var.auth_jwt_audience
+    guest_secret  = var.guest_token_secret
   }))
 
   # Instance metadata options (IMDSv2)
@@ -33,8 +33,22 @@ AWS_REGION=${aws_region}
 DB_SECRET_ARN=${db_secret_arn}
 SERVER_PORT=${app_port}
 ENV=${environment}
+AUTH_REQUIRED=${auth_required}
+AUTH_JWT_ISSUER=${jwt_issuer}
+AUTH_JWT_AUDIENCE=${jwt_audience}
+GUEST_TOKEN_SECRET=${guest_secret}
 EOF
 
+%{ if auth_jwks != "" ~}
+# Write the key set used to verify bearer tokens
+cat > /opt/meeting-slot-service/jwks.json << 'JWKS_EOF'
+${auth_jwks}
+JWKS_EOF
+echo "AUTH_JWKS_FILE=/opt/meeting-slot-service/jwks.json" >> /opt/meeting-slot-service/.env
+chown appuser:appuser /opt/meeting-slot-service/jwks.json
+chmod 600 /opt/meeting-slot-service/jwks.json
+%{ endif ~}
+
 chown appuser:appuser /opt/meeting-slot-service/.env
 
 # Configure CloudWatch Agent
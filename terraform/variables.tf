@@ -94,6 +94,38 @@ variable "app_port" {
   default     = 8080
 }
 
+# Authentication Variables
+variable "auth_required" {
+  description = "Reject API requests that carry no credentials"
+  type        = bool
+  default     = true
+}
+
+variable "auth_jwks" {
+  description = "JSON Web Key Set used to verify bearer tokens"
+  type        = string
+  default     = ""
+  sensitive   = true
+}
+
+variable "auth_jwt_issuer" {
+  description = "Expected iss claim of bearer tokens (empty to skip the check)"
+  type        = string
+  default     = ""
+}
+
+variable "auth_jwt_audience" {
+  description = "Expected aud claim of bearer tokens (empty to skip the check)"
+  type        = string
+  default     = ""
+}
+
+variable "guest_token_secret" {
+  description = "Secret used to sign guest respond links; shared by all instances so links work on any of them and survive deploys"
+  type        = string
+  sensitive   = true
+}
+
 # Auto Scaling Group Variables
 variable "asg_min_size" {
   description = "Minimum number of instances in Auto Scaling Group"